@@ -0,0 +1,8 @@
+data "netbox_available_asn" "example" {
+  asn_range_id = netbox_asn_range.private.id
+  limit        = 10
+}
+
+output "next_free_asn" {
+  value = data.netbox_available_asn.example.asns_available[0]
+}
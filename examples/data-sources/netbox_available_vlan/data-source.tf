@@ -0,0 +1,11 @@
+data "netbox_vlan_group" "example" {
+  name = "Example"
+}
+
+data "netbox_available_vlan" "example" {
+  group_id = data.netbox_vlan_group.example.id
+}
+
+output "next_free_vid" {
+  value = data.netbox_available_vlan.example.vids_available[0]
+}
@@ -0,0 +1,17 @@
+data "netbox_graphql_query" "interfaces" {
+  query     = <<-EOT
+    query($site: [String!]) {
+      interface_list(filters: {site: $site}) {
+        name
+        device { name }
+        ip_addresses { address }
+        connected_endpoints { ... on InterfaceType { name device { name } } }
+      }
+    }
+  EOT
+  variables = jsonencode({ site = ["fra1"] })
+}
+
+output "interfaces" {
+  value = jsondecode(data.netbox_graphql_query.interfaces.data).interface_list
+}
@@ -0,0 +1,19 @@
+data "netbox_objects" "leafs" {
+  path = "dcim/devices"
+  filter {
+    name  = "name__ic"
+    value = "leaf"
+  }
+  filter {
+    name  = "site"
+    value = "fra1"
+  }
+  filter {
+    name  = "status__n"
+    value = "offline"
+  }
+}
+
+output "leaf_serials" {
+  value = [for device in jsondecode(data.netbox_objects.leafs.json) : device.serial]
+}
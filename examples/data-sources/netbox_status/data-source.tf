@@ -0,0 +1,12 @@
+data "netbox_status" "netbox" {
+  lifecycle {
+    postcondition {
+      condition     = contains(keys(self.plugins), "netbox_dns")
+      error_message = "The netbox-plugin-dns plugin is required."
+    }
+  }
+}
+
+output "netbox_version" {
+  value = data.netbox_status.netbox.netbox_version
+}
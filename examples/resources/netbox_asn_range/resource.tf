@@ -0,0 +1,11 @@
+resource "netbox_rir" "private" {
+  name       = "Private"
+  is_private = true
+}
+
+resource "netbox_asn_range" "private" {
+  name   = "Private 4-byte ASNs"
+  rir_id = netbox_rir.private.id
+  start  = 4200000000
+  end    = 4294967294
+}
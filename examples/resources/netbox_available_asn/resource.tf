@@ -0,0 +1,20 @@
+resource "netbox_rir" "private" {
+  name       = "Private"
+  is_private = true
+}
+
+resource "netbox_asn_range" "private" {
+  name   = "Private 4-byte ASNs"
+  rir_id = netbox_rir.private.id
+  start  = 4200000000
+  end    = 4294967294
+}
+
+resource "netbox_site" "example" {
+  name = "Example site"
+}
+
+resource "netbox_available_asn" "example" {
+  asn_range_id = netbox_asn_range.private.id
+  description  = "BGP speakers of ${netbox_site.example.name}"
+}
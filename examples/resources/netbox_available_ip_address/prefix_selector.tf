@@ -0,0 +1,10 @@
+data "netbox_ipam_role" "loopbacks" {
+  name = "Loopbacks"
+}
+
+resource "netbox_available_ip_address" "test" {
+  prefix_selector {
+    role_id = data.netbox_ipam_role.loopbacks.id
+    family  = 4
+  }
+}
@@ -0,0 +1,33 @@
+resource "netbox_prefix" "example" {
+  prefix = "192.168.150.0/24"
+  status = "active"
+}
+
+locals {
+  hosts = {
+    web01 = "Web server"
+    web02 = "Web server"
+    db01  = "Database server"
+  }
+}
+
+# Allocate one IP per host. Adding or removing a host only allocates or
+# releases the IP of that host, the IPs of the other hosts never change.
+resource "netbox_available_ip_addresses" "example_keyed" {
+  prefix_id = netbox_prefix.example.id
+  status    = "active"
+
+  dynamic "address" {
+    for_each = local.hosts
+    content {
+      key         = address.key
+      dns_name    = "${address.key}.example.com"
+      description = address.value
+    }
+  }
+}
+
+# Output the allocated IP addresses by host
+output "allocated_host_ips" {
+  value = netbox_available_ip_addresses.example_keyed.ip_addresses_by_key
+}
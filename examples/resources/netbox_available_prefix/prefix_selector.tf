@@ -0,0 +1,8 @@
+resource "netbox_available_prefix" "test" {
+  prefix_selector {
+    tags   = ["customer-pool"]
+    family = 4
+  }
+  prefix_length = 28
+  status        = "active"
+}
@@ -0,0 +1,10 @@
+resource "netbox_vlan_group" "example" {
+  name       = "Example"
+  slug       = "example"
+  vid_ranges = [[100, 199]]
+}
+
+resource "netbox_available_vlan" "example" {
+  name     = "Example VLAN"
+  group_id = netbox_vlan_group.example.id
+}
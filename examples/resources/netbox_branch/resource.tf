@@ -0,0 +1,12 @@
+resource "netbox_branch" "review" {
+  name        = "change-1234"
+  description = "Rack new leafs in fra1"
+
+  # Set to true once the changes made in the branch have been reviewed
+  merge = false
+}
+
+# In a separate configuration, apply the changes to the branch by its name
+# provider "netbox" {
+#   branch = "change-1234"
+# }
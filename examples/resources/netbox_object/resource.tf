@@ -0,0 +1,8 @@
+resource "netbox_object" "wlan" {
+  path = "wireless/wireless-lans"
+  body = jsonencode({
+    ssid   = "corp"
+    status = "active"
+    tenant = netbox_tenant.example.id
+  })
+}
@@ -0,0 +1,442 @@
+package netbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fbreckle/go-netbox/netbox/client/ipam"
+	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	log "github.com/sirupsen/logrus"
+)
+
+// maxAllocationAttempts is how often an allocation is attempted when the
+// objects chosen by Netbox turn out to be taken by a concurrent request.
+const maxAllocationAttempts = 5
+
+// exhaustedError is returned when a parent object, e.g. a prefix, has fewer
+// available child objects than requested. Netbox responds to such requests
+// with 409 Conflict.
+type exhaustedError struct {
+	parentType string
+	parentID   int64
+	childType  string
+	requested  int
+	detail     string
+}
+
+func (e *exhaustedError) Error() string {
+	return fmt.Sprintf("%s with ID %d %s: %s", e.parentType, e.parentID, e.availability(), e.detail)
+}
+
+// availability describes how many child objects are left, e.g. `has no
+// available IP addresses`.
+func (e *exhaustedError) availability() string {
+	if e.requested > 1 {
+		return fmt.Sprintf("has fewer than %d available %s", e.requested, e.childType)
+	}
+	return fmt.Sprintf("has no available %s", e.childType)
+}
+
+// selectorExhaustedError is returned when none of the prefixes matching a
+// prefix_selector has enough available child objects.
+type selectorExhaustedError struct {
+	childType  string
+	requested  int
+	candidates []string
+}
+
+func (e *selectorExhaustedError) Error() string {
+	if len(e.candidates) == 0 {
+		return "no prefix matches the prefix_selector"
+	}
+	return fmt.Sprintf("all prefixes matching the prefix_selector are exhausted, tried %s", strings.Join(e.candidates, ", "))
+}
+
+// asExhaustedError turns the 409 Conflict response of an allocation into an
+// exhaustedError. Other errors are returned as they are.
+func asExhaustedError(err error, parentType string, parentID int64, childType string, requested int) error {
+	var apiErr netboxAPIError
+	if !errors.As(err, &apiErr) || apiErr.Code() != http.StatusConflict {
+		return err
+	}
+	return &exhaustedError{
+		parentType: parentType,
+		parentID:   parentID,
+		childType:  childType,
+		requested:  requested,
+		detail:     apiErrorDetail(apiErr.GetPayload()),
+	}
+}
+
+// isAllocationConflict reports whether Netbox rejected an allocation because
+// one of the chosen objects already exists, i.e. it was taken by a concurrent
+// request after Netbox determined the available objects. The message is the
+// start of the validation error Netbox returns for the duplicate.
+func isAllocationConflict(err error, message string) bool {
+	var apiErr netboxAPIError
+	if !errors.As(err, &apiErr) || apiErr.Code() != http.StatusBadRequest {
+		return false
+	}
+	for _, m := range apiErrorMessages(apiErr.GetPayload()) {
+		if strings.Contains(m, message) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryAllocation calls allocate until it succeeds, fails with an error that
+// is not a conflict, the timeout expires or maxAllocationAttempts is reached.
+func retryAllocation(ctx context.Context, timeout time.Duration, isConflict func(error) bool, allocate func() error) error {
+	attempt := 0
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		attempt++
+		err := allocate()
+		if err == nil {
+			return nil
+		}
+		if !isConflict(err) || attempt >= maxAllocationAttempts {
+			return retry.NonRetryableError(err)
+		}
+		log.WithFields(log.Fields{
+			"attempt": attempt,
+			"error":   err.Error(),
+		}).Warn("Allocated object was taken concurrently, retrying allocation")
+		return retry.RetryableError(err)
+	})
+}
+
+// allocateAvailableIPs creates an IP address for every entry of data from the
+// next available IP addresses of a prefix or, if prefixID is 0, an IP range.
+// Allocations that collide with addresses created concurrently are retried.
+func allocateAvailableIPs(ctx context.Context, api *providerState, timeout time.Duration, prefixID, rangeID int64, data []*models.AvailableIP) ([]*models.IPAddress, error) {
+	var payload []*models.IPAddress
+	isConflict := func(err error) bool {
+		return isAllocationConflict(err, "Duplicate IP address")
+	}
+
+	err := retryAllocation(ctx, timeout, isConflict, func() error {
+		if prefixID != 0 {
+			params := ipam.NewIpamPrefixesAvailableIpsCreateParamsWithContext(ctx).WithID(prefixID).WithData(data)
+			res, err := api.Ipam.IpamPrefixesAvailableIpsCreate(params, nil)
+			if err != nil {
+				return asExhaustedError(err, "prefix", prefixID, "IP addresses", len(data))
+			}
+			payload = res.Payload
+			return nil
+		}
+
+		params := ipam.NewIpamIPRangesAvailableIpsCreateParamsWithContext(ctx).WithID(rangeID).WithData(data)
+		res, err := api.Ipam.IpamIPRangesAvailableIpsCreate(params, nil)
+		if err != nil {
+			return asExhaustedError(err, "IP range", rangeID, "IP addresses", len(data))
+		}
+		payload = res.Payload
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(payload) != len(data) {
+		return nil, fmt.Errorf("expected %d allocated IP addresses, got %d", len(data), len(payload))
+	}
+	return payload, nil
+}
+
+// allocateAvailablePrefix creates a prefix from the next available prefix of
+// the given length in a parent prefix. Allocations that collide with prefixes
+// created concurrently are retried.
+func allocateAvailablePrefix(ctx context.Context, api *providerState, timeout time.Duration, parentPrefixID int64, data *models.PrefixLength) (*models.Prefix, error) {
+	var payload *models.Prefix
+	isConflict := func(err error) bool {
+		return isAllocationConflict(err, "Duplicate prefix")
+	}
+
+	err := retryAllocation(ctx, timeout, isConflict, func() error {
+		params := ipam.NewIpamPrefixesAvailablePrefixesCreateParamsWithContext(ctx).WithID(parentPrefixID).WithData(data)
+		res, err := api.Ipam.IpamPrefixesAvailablePrefixesCreate(params, nil)
+		if err != nil {
+			return asExhaustedError(err, "prefix", parentPrefixID, fmt.Sprintf("/%d prefixes", *data.PrefixLength), 1)
+		}
+		payload = res.GetPayload()
+		return nil
+	})
+	return payload, err
+}
+
+// allocateAvailableVLAN creates a VLAN from the next available VID of a VLAN
+// group. Allocations that collide with VLANs created concurrently are retried.
+func allocateAvailableVLAN(ctx context.Context, api *providerState, timeout time.Duration, groupID int64, data *models.WritableCreateAvailableVLAN) (*models.VLAN, error) {
+	var payload []*models.VLAN
+	isConflict := func(err error) bool {
+		return isAllocationConflict(err, "already exists")
+	}
+
+	err := retryAllocation(ctx, timeout, isConflict, func() error {
+		params := ipam.NewIpamVlanGroupsAvailableVlansCreateParamsWithContext(ctx).WithID(groupID)
+		res, err := api.Ipam.IpamVlanGroupsAvailableVlansCreate(params, nil, withListBody(data))
+		if err != nil {
+			return asExhaustedError(err, "VLAN group", groupID, "VLAN IDs", 1)
+		}
+		payload = res.GetPayload()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(payload) != 1 {
+		return nil, fmt.Errorf("expected 1 allocated VLAN, got %d", len(payload))
+	}
+	return payload[0], nil
+}
+
+// allocateAvailableASN creates an ASN from the next available AS number of an
+// ASN range. Allocations that collide with ASNs created concurrently are
+// retried.
+func allocateAvailableASN(ctx context.Context, api *providerState, timeout time.Duration, rangeID int64, data *models.WritableASN) (*models.ASN, error) {
+	var payload []*models.ASN
+	isConflict := func(err error) bool {
+		return isAllocationConflict(err, "already exists")
+	}
+
+	body, err := json.Marshal([]*models.WritableASN{data})
+	if err != nil {
+		return nil, err
+	}
+
+	path := genericAPIPath(asnRangesPath, strconv.FormatInt(rangeID, 10), "available-asns")
+	err = retryAllocation(ctx, timeout, isConflict, func() error {
+		res, err := genericAPIRequest(ctx, api, http.MethodPost, path, nil, body)
+		if err != nil {
+			return asExhaustedError(err, "ASN range", rangeID, "ASNs", 1)
+		}
+		return json.Unmarshal(res, &payload)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(payload) != 1 {
+		return nil, fmt.Errorf("expected 1 allocated ASN, got %d", len(payload))
+	}
+	return payload[0], nil
+}
+
+// withListBody sends a list holding the given object as the body of a request.
+// Netbox answers with a single object if a single object is sent to the
+// available objects endpoints, while the generated client expects a list.
+func withListBody(body interface{}) func(*runtime.ClientOperation) {
+	return func(op *runtime.ClientOperation) {
+		params := op.Params
+		op.Params = runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+			if err := params.WriteToRequest(r, reg); err != nil {
+				return err
+			}
+			return r.SetBodyParam([]interface{}{body})
+		})
+	}
+}
+
+// prefixSelectorSchema returns the schema of the `prefix_selector` block,
+// which selects the candidate prefixes to allocate from.
+func prefixSelectorSchema(exactlyOneOf []string) *schema.Schema {
+	// An empty prefix_selector would make every prefix a candidate
+	criteria := []string{}
+	for _, name := range []string{"vrf_id", "role_id", "site_id", "family", "is_pool", tagsKey} {
+		criteria = append(criteria, "prefix_selector.0."+name)
+	}
+
+	return &schema.Schema{
+		Type:         schema.TypeList,
+		Optional:     true,
+		ForceNew:     true,
+		MaxItems:     1,
+		ExactlyOneOf: exactlyOneOf,
+		Description:  "Selects the candidate prefixes to allocate from. The candidates are tried in the order Netbox lists them, i.e. by VRF and network address, until one of them has space. At least one criterion must be given.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"vrf_id": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					AtLeastOneOf: criteria,
+					Description:  "Only prefixes in this VRF are candidates.",
+				},
+				"role_id": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					AtLeastOneOf: criteria,
+					Description:  "Only prefixes with this role are candidates.",
+				},
+				"site_id": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					AtLeastOneOf: criteria,
+					Description:  "Only prefixes assigned to this site are candidates.",
+				},
+				"family": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					ValidateFunc: validation.IntInSlice([]int{4, 6}),
+					AtLeastOneOf: criteria,
+					Description:  "Only prefixes of this address family, `4` or `6`, are candidates.",
+				},
+				"is_pool": {
+					Type:         schema.TypeBool,
+					Optional:     true,
+					AtLeastOneOf: criteria,
+					Description:  "If `true`, only prefixes marked as pools are candidates.",
+				},
+				tagsKey: {
+					Type:         schema.TypeSet,
+					Optional:     true,
+					Elem:         &schema.Schema{Type: schema.TypeString},
+					AtLeastOneOf: criteria,
+					Description:  "Only prefixes with all of these tags are candidates.",
+				},
+			},
+		},
+	}
+}
+
+// candidatePrefixes lists the prefixes matching a prefix_selector in the order
+// Netbox returns them.
+func candidatePrefixes(ctx context.Context, api *providerState, selector map[string]interface{}) ([]*models.Prefix, error) {
+	params := ipam.NewIpamPrefixesListParamsWithContext(ctx)
+	// An empty prefix_selector block has no attributes
+	if vrfID, _ := selector["vrf_id"].(int); vrfID != 0 {
+		params.VrfID = strToPtr(strconv.Itoa(vrfID))
+	}
+	if roleID, _ := selector["role_id"].(int); roleID != 0 {
+		params.RoleID = strToPtr(strconv.Itoa(roleID))
+	}
+	if siteID, _ := selector["site_id"].(int); siteID != 0 {
+		params.SiteID = strToPtr(strconv.Itoa(siteID))
+	}
+	if family, _ := selector["family"].(int); family != 0 {
+		params.Family = float64ToPtr(float64(family))
+	}
+	if isPool, _ := selector["is_pool"].(bool); isPool {
+		params.IsPool = strToPtr("true")
+	}
+
+	// Tags are filtered by their slug
+	if tags, ok := selector[tagsKey].(*schema.Set); ok && tags.Len() > 0 {
+		names := toStringList(tags)
+		found, err := api.tags.lookup(ctx, api, names)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			if len(found[name]) != 1 {
+				return nil, fmt.Errorf("could not map tag %s of the prefix_selector to a unique tag in Netbox", name)
+			}
+			params.Tag = append(params.Tag, *found[name][0].Slug)
+		}
+	}
+
+	prefixes, _, err := listAll(params, 0, func(p *ipam.IpamPrefixesListParams) (*listPage[*models.Prefix], error) {
+		res, err := api.Ipam.IpamPrefixesList(p, nil)
+		if err != nil {
+			return nil, err
+		}
+		payload := res.GetPayload()
+		return &listPage[*models.Prefix]{Count: payload.Count, Next: payload.Next, Results: payload.Results}, nil
+	})
+	return prefixes, err
+}
+
+// allocateFromPrefixSelector calls allocate for the prefixes matching the
+// prefix_selector in turn until one of them is not exhausted. It returns the
+// ID of the prefix the allocation was made from.
+func allocateFromPrefixSelector(ctx context.Context, api *providerState, selector map[string]interface{}, childType string, requested int, allocate func(prefixID int64) error) (int64, error) {
+	candidates, err := candidatePrefixes(ctx, api, selector)
+	if err != nil {
+		return 0, err
+	}
+
+	var tried []string
+	for _, prefix := range candidates {
+		err := allocate(prefix.ID)
+		var exhaustedErr *exhaustedError
+		if errors.As(err, &exhaustedErr) {
+			log.WithFields(log.Fields{
+				"prefix": *prefix.Prefix,
+				"id":     prefix.ID,
+			}).Debug("Candidate prefix is exhausted, trying the next one")
+			tried = append(tried, fmt.Sprintf("%s (ID %d)", *prefix.Prefix, prefix.ID))
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		return prefix.ID, nil
+	}
+	return 0, &selectorExhaustedError{
+		childType:  childType,
+		requested:  requested,
+		candidates: tried,
+	}
+}
+
+// allocatedFromPrefixID returns the ID of the prefix an IP address or prefix
+// was allocated from, i.e. a prefix in the same VRF that contains address.
+// Netbox does not remember it, so the current ID is kept as long as it still
+// contains address and the most specific containing prefix is used
+// otherwise. excludeID is the ID of a prefix that is not a candidate, e.g. the
+// allocated prefix itself. It returns nil if no prefix contains address.
+func allocatedFromPrefixID(ctx context.Context, api *providerState, address string, vrfID, excludeID, currentID int64) (*int64, error) {
+	params := ipam.NewIpamPrefixesListParamsWithContext(ctx)
+	params.Contains = strToPtr(address)
+	params.VrfID = strToPtr("null")
+	if vrfID != 0 {
+		params.VrfID = strToPtr(strconv.FormatInt(vrfID, 10))
+	}
+	if excludeID != 0 {
+		params.IDn = strToPtr(strconv.FormatInt(excludeID, 10))
+	}
+
+	prefixes, _, err := listAll(params, 0, func(p *ipam.IpamPrefixesListParams) (*listPage[*models.Prefix], error) {
+		res, err := api.Ipam.IpamPrefixesList(p, nil)
+		if err != nil {
+			return nil, err
+		}
+		payload := res.GetPayload()
+		return &listPage[*models.Prefix]{Count: payload.Count, Next: payload.Next, Results: payload.Results}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var mostSpecific *models.Prefix
+	bits := -1
+	for _, prefix := range prefixes {
+		if prefix.ID == currentID {
+			return int64ToPtr(prefix.ID), nil
+		}
+		network, err := netip.ParsePrefix(*prefix.Prefix)
+		if err != nil {
+			continue
+		}
+		if network.Bits() > bits {
+			mostSpecific, bits = prefix, network.Bits()
+		}
+	}
+	if mostSpecific == nil {
+		return nil, nil
+	}
+	return int64ToPtr(mostSpecific.ID), nil
+}
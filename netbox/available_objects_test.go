@@ -0,0 +1,324 @@
+package netbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// newAvailableIPsServer returns a server that answers requests for available
+// IP addresses with the given responses in turn.
+func newAvailableIPsServer(t *testing.T, responses ...string) (*providerState, *int) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/ipam/prefixes/5/available-ips/", r.URL.Path)
+		status, body, _ := strings.Cut(responses[requests], " ")
+		code, _ := strconv.Atoi(status)
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(ts.Close)
+
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: ts.URL,
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+	return newProviderState(client), &requests
+}
+
+func TestAllocateAvailableIPsRetriesConflicts(t *testing.T) {
+	api, requests := newAvailableIPsServer(t,
+		`400 [{"address": ["Duplicate IP address found in global table: 10.0.0.1/24"]}]`,
+		`201 [{"id": 7, "address": "10.0.0.2/24"}]`,
+	)
+
+	res, err := allocateAvailableIPs(context.Background(), api, time.Minute, 5, 0, []*models.AvailableIP{{}})
+	assert.NoError(t, err)
+	if assert.Len(t, res, 1) {
+		assert.Equal(t, "10.0.0.2/24", *res[0].Address)
+	}
+	assert.Equal(t, 2, *requests)
+}
+
+func TestAllocateAvailableIPsExhausted(t *testing.T) {
+	api, requests := newAvailableIPsServer(t,
+		`409 {"detail": "Insufficient resources are available to satisfy the request"}`,
+	)
+
+	_, err := allocateAvailableIPs(context.Background(), api, time.Minute, 5, 0, []*models.AvailableIP{{}, {}})
+	assert.EqualError(t, err, "prefix with ID 5 has fewer than 2 available IP addresses: Insufficient resources are available to satisfy the request")
+	assert.Equal(t, 1, *requests)
+
+	d := schema.TestResourceDataRaw(t, resourceNetboxAvailableIPAddressRange().Schema, map[string]interface{}{
+		"prefix_id":     5,
+		"address_count": 2,
+	})
+	assert.Equal(t, diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       "Netbox prefix with ID 5 is exhausted",
+		Detail:        "The prefix has fewer than 2 available IP addresses. Netbox responded: Insufficient resources are available to satisfy the request\n\nFree up IP addresses in the prefix or allocate from a different one.",
+		AttributePath: cty.GetAttrPath("prefix_id"),
+	}}, apiErrorDiagnostics(d, err))
+}
+
+func TestAllocateAvailableIPsOtherErrors(t *testing.T) {
+	api, requests := newAvailableIPsServer(t,
+		`400 [{"status": ["Invalid choice."]}]`,
+	)
+
+	_, err := allocateAvailableIPs(context.Background(), api, time.Minute, 5, 0, []*models.AvailableIP{{}})
+	assert.Error(t, err)
+	assert.Equal(t, 1, *requests)
+}
+
+func TestAllocateFromPrefixSelector(t *testing.T) {
+	var query string
+	var tried []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/ipam/prefixes/":
+			query = r.URL.RawQuery
+			fmt.Fprint(w, `{"count": 3, "next": null, "results": [
+				{"id": 5, "prefix": "10.0.0.0/30"},
+				{"id": 6, "prefix": "10.0.1.0/24"},
+				{"id": 7, "prefix": "10.0.2.0/24"}
+			]}`)
+		case "/api/ipam/prefixes/5/available-ips/":
+			tried = append(tried, "5")
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprint(w, `{"detail": "Insufficient resources are available to satisfy the request"}`)
+		case "/api/ipam/prefixes/6/available-ips/":
+			tried = append(tried, "6")
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `[{"id": 11, "address": "10.0.1.1/24"}]`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: ts.URL,
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+	api := newProviderState(client)
+
+	selector := map[string]interface{}{"role_id": 3, "family": 4, "is_pool": true}
+	var res []*models.IPAddress
+	prefixID, err := allocateFromPrefixSelector(context.Background(), api, selector, "IP addresses", 1, func(candidateID int64) error {
+		res, err = allocateAvailableIPs(context.Background(), api, time.Minute, candidateID, 0, []*models.AvailableIP{{}})
+		return err
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), prefixID)
+	assert.Equal(t, "10.0.1.1/24", *res[0].Address)
+	assert.Equal(t, []string{"5", "6"}, tried)
+	assert.Contains(t, query, "role_id=3")
+	assert.Contains(t, query, "family=4")
+	assert.Contains(t, query, "is_pool=true")
+
+	// All candidates exhausted
+	_, err = allocateFromPrefixSelector(context.Background(), api, selector, "IP addresses", 1, func(candidateID int64) error {
+		return &exhaustedError{parentType: "prefix", parentID: candidateID, childType: "IP addresses", requested: 1}
+	})
+	assert.Equal(t, diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       "All Netbox prefixes matching the prefix_selector are exhausted",
+		Detail:        "None of the matching prefixes has any available IP addresses. Tried 10.0.0.0/30 (ID 5), 10.0.1.0/24 (ID 6), 10.0.2.0/24 (ID 7).\n\nFree up IP addresses in one of the prefixes or add a prefix matching the prefix_selector.",
+		AttributePath: cty.GetAttrPath("prefix_selector"),
+	}}, apiErrorDiagnostics(nil, err))
+}
+
+func TestPrefixSelectorRequiresCriteria(t *testing.T) {
+	for name, r := range map[string]*schema.Resource{
+		"netbox_available_ip_address": resourceNetboxAvailableIPAddress(),
+		"netbox_available_prefix":     resourceNetboxAvailablePrefix(),
+	} {
+		config := map[string]interface{}{"prefix_selector": []interface{}{map[string]interface{}{}}}
+		if name == "netbox_available_prefix" {
+			config["prefix_length"] = 24
+			config["status"] = "active"
+		}
+		diags := r.Validate(terraform.NewResourceConfigRaw(config))
+		if assert.True(t, diags.HasError(), name) {
+			assert.Contains(t, diags[0].Detail, "one of `prefix_selector.0.family,prefix_selector.0.is_pool", name)
+		}
+
+		config["prefix_selector"] = []interface{}{map[string]interface{}{"is_pool": true}}
+		diags = r.Validate(terraform.NewResourceConfigRaw(config))
+		assert.False(t, diags.HasError(), "%s: %v", name, diags)
+	}
+}
+
+func TestAllocatedFromPrefixID(t *testing.T) {
+	var query url.Values
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/ipam/prefixes/", r.URL.Path)
+		query = r.URL.Query()
+		w.Header().Set("Content-Type", "application/json")
+		if query.Get("vrf_id") == "4" {
+			fmt.Fprint(w, `{"count": 0, "next": null, "results": []}`)
+			return
+		}
+		fmt.Fprint(w, `{"count": 3, "next": null, "results": [
+			{"id": 1, "prefix": "10.0.0.0/16"},
+			{"id": 2, "prefix": "10.0.1.0/24"},
+			{"id": 3, "prefix": "10.0.0.0/8"}
+		]}`)
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: ts.URL,
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+	api := newProviderState(client)
+
+	// The most specific containing prefix
+	prefixID, err := allocatedFromPrefixID(context.Background(), api, "10.0.1.5", 0, 9, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64ToPtr(2), prefixID)
+	assert.Equal(t, "10.0.1.5", query.Get("contains"))
+	assert.Equal(t, "null", query.Get("vrf_id"))
+	assert.Equal(t, "9", query.Get("id__n"))
+
+	// The current prefix as long as it still contains the address
+	prefixID, err = allocatedFromPrefixID(context.Background(), api, "10.0.1.5", 0, 0, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64ToPtr(1), prefixID)
+	assert.Empty(t, query.Get("id__n"))
+
+	// No containing prefix in the VRF
+	prefixID, err = allocatedFromPrefixID(context.Background(), api, "10.0.1.5", 4, 0, 1)
+	assert.NoError(t, err)
+	assert.Nil(t, prefixID)
+}
+
+func TestAllocateAvailableVLAN(t *testing.T) {
+	responses := []string{
+		`400 [{"__all__": ["VLAN with this VLAN group and VLAN ID already exists."]}]`,
+		`201 [{"id": 12, "vid": 101, "name": "test"}]`,
+		`409 {"detail": "Insufficient resources are available to satisfy the request"}`,
+	}
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/ipam/vlan-groups/3/available-vlans/", r.URL.Path)
+		var body []map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		if assert.Len(t, body, 1) {
+			assert.Equal(t, "test", body[0]["name"])
+		}
+		status, payload, _ := strings.Cut(responses[requests], " ")
+		code, _ := strconv.Atoi(status)
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		fmt.Fprint(w, payload)
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: ts.URL,
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+	api := newProviderState(client)
+
+	data := &models.WritableCreateAvailableVLAN{Name: strToPtr("test")}
+	vlan, err := allocateAvailableVLAN(context.Background(), api, time.Minute, 3, data)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), vlan.ID)
+	assert.Equal(t, int64(101), *vlan.Vid)
+	assert.Equal(t, 2, requests)
+
+	_, err = allocateAvailableVLAN(context.Background(), api, time.Minute, 3, data)
+	d := schema.TestResourceDataRaw(t, resourceNetboxAvailableVlan().Schema, map[string]interface{}{
+		"group_id": 3,
+		"name":     "test",
+	})
+	assert.Equal(t, diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       "Netbox VLAN group with ID 3 is exhausted",
+		Detail:        "The VLAN group has no available VLAN IDs. Netbox responded: Insufficient resources are available to satisfy the request\n\nFree up VLAN IDs in the VLAN group or allocate from a different one.",
+		AttributePath: cty.GetAttrPath("group_id"),
+	}}, apiErrorDiagnostics(d, err))
+	assert.Equal(t, 3, requests)
+}
+
+func TestAllocateAvailableASN(t *testing.T) {
+	responses := []string{
+		`400 [{"asn": ["ASN with this ASN already exists."]}]`,
+		`201 [{"id": 21, "asn": 4200000001, "rir": {"id": 2}}]`,
+		`409 {"detail": "Insufficient resources are available to satisfy the request"}`,
+	}
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/ipam/asn-ranges/4/available-asns/", r.URL.Path)
+		var body []map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		if assert.Len(t, body, 1) {
+			assert.Equal(t, "test", body[0]["description"])
+		}
+		status, payload, _ := strings.Cut(responses[requests], " ")
+		code, _ := strconv.Atoi(status)
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		fmt.Fprint(w, payload)
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: ts.URL,
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+	api := newProviderState(client)
+
+	data := &models.WritableASN{Description: "test"}
+	asn, err := allocateAvailableASN(context.Background(), api, time.Minute, 4, data)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(21), asn.ID)
+	assert.Equal(t, int64(4200000001), *asn.Asn)
+	assert.Equal(t, 2, requests)
+
+	_, err = allocateAvailableASN(context.Background(), api, time.Minute, 4, data)
+	d := schema.TestResourceDataRaw(t, resourceNetboxAvailableAsn().Schema, map[string]interface{}{
+		"asn_range_id": 4,
+	})
+	assert.Equal(t, diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       "Netbox ASN range with ID 4 is exhausted",
+		Detail:        "The ASN range has no available ASNs. Netbox responded: Insufficient resources are available to satisfy the request\n\nFree up ASNs in the ASN range or allocate from a different one.",
+		AttributePath: cty.GetAttrPath("asn_range_id"),
+	}}, apiErrorDiagnostics(d, err))
+	assert.Equal(t, 3, requests)
+}
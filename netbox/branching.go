@@ -0,0 +1,140 @@
+package netbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+)
+
+// branchHeader is the header used by the netbox-branching plugin to select
+// the branch a request operates on, by the schema ID of the branch.
+const branchHeader = "X-NetBox-Branch"
+
+// branchesPath is the API path of the branches of the netbox-branching plugin.
+const branchesPath = "plugins/branching/branches"
+
+const (
+	branchStatusNew          = "new"
+	branchStatusProvisioning = "provisioning"
+	branchStatusReady        = "ready"
+	branchStatusMerged       = "merged"
+)
+
+// netboxBranch is a branch as returned by the netbox-branching plugin.
+type netboxBranch struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	SchemaID    string `json:"schema_id"`
+	Status      struct {
+		Value string `json:"value"`
+	} `json:"status"`
+}
+
+// findBranch looks up a branch by its name or, failing that, by its schema
+// ID.
+func findBranch(ctx context.Context, api *providerState, nameOrSchemaID string) (*netboxBranch, error) {
+	for _, filter := range []string{"name", "schema_id"} {
+		objects, _, err := genericListAll(ctx, api, genericAPIPath(branchesPath), url.Values{filter: {nameOrSchemaID}}, 2)
+		if err != nil {
+			return nil, err
+		}
+		switch len(objects) {
+		case 0:
+			continue
+		case 1:
+			var branch netboxBranch
+			if err := json.Unmarshal(objects[0], &branch); err != nil {
+				return nil, err
+			}
+			return &branch, nil
+		default:
+			return nil, fmt.Errorf("more than one branch matches %q", nameOrSchemaID)
+		}
+	}
+	return nil, fmt.Errorf("branch %q not found", nameOrSchemaID)
+}
+
+// getBranch reads a branch by its ID.
+func getBranch(ctx context.Context, api *providerState, id int64) (*netboxBranch, error) {
+	res, err := genericAPIRequest(ctx, api, http.MethodGet, genericAPIPath(branchesPath, strconv.FormatInt(id, 10)), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var branch netboxBranch
+	if err := json.Unmarshal(res, &branch); err != nil {
+		return nil, err
+	}
+	return &branch, nil
+}
+
+// waitForBranchStatus waits until the branch reaches one of the target
+// statuses. Provisioning, syncing and merging a branch happen in background
+// jobs in Netbox.
+func waitForBranchStatus(ctx context.Context, api *providerState, id int64, pending, target []string, timeout time.Duration) (*netboxBranch, error) {
+	stateConf := &retry.StateChangeConf{
+		Pending: pending,
+		Target:  target,
+		Refresh: func() (interface{}, string, error) {
+			branch, err := getBranch(ctx, api, id)
+			if err != nil {
+				return nil, "", err
+			}
+			return branch, branch.Status.Value, nil
+		},
+		Timeout:    timeout,
+		Delay:      time.Second,
+		MinTimeout: time.Second,
+	}
+
+	branch, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error waiting for branch %d: %w", id, err)
+	}
+	return branch.(*netboxBranch), nil
+}
+
+// netboxJob is a background job as returned by Netbox.
+type netboxJob struct {
+	ID     int64  `json:"id"`
+	Error  string `json:"error"`
+	Status struct {
+		Value string `json:"value"`
+	} `json:"status"`
+}
+
+// waitForJob waits until a background job of Netbox has completed.
+func waitForJob(ctx context.Context, api *providerState, id int64, timeout time.Duration) error {
+	stateConf := &retry.StateChangeConf{
+		Pending: []string{"pending", "scheduled", "running"},
+		Target:  []string{"completed"},
+		Refresh: func() (interface{}, string, error) {
+			res, err := genericAPIRequest(ctx, api, http.MethodGet, genericAPIPath("core/jobs", strconv.FormatInt(id, 10)), nil, nil)
+			if err != nil {
+				return nil, "", err
+			}
+			var job netboxJob
+			if err := json.Unmarshal(res, &job); err != nil {
+				return nil, "", err
+			}
+			if job.Status.Value == "errored" || job.Status.Value == "failed" {
+				return nil, "", fmt.Errorf("job %d %s: %s", id, job.Status.Value, job.Error)
+			}
+			return &job, job.Status.Value, nil
+		},
+		Timeout:    timeout,
+		Delay:      time.Second,
+		MinTimeout: time.Second,
+	}
+
+	if _, err := stateConf.WaitForStateContext(ctx); err != nil {
+		return fmt.Errorf("error waiting for job %d: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package netbox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindBranch(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/plugins/branching/branches/", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("name") == "review" || r.URL.Query().Get("schema_id") == "td5smq0f" {
+			fmt.Fprint(w, `{"count": 1, "results": [{"id": 3, "name": "review", "schema_id": "td5smq0f", "status": {"value": "ready", "label": "Ready"}}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"count": 0, "results": []}`)
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: ts.URL,
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+	api := newProviderState(client)
+
+	for _, nameOrSchemaID := range []string{"review", "td5smq0f"} {
+		branch, err := findBranch(context.Background(), api, nameOrSchemaID)
+		if assert.NoError(t, err, nameOrSchemaID) {
+			assert.Equal(t, int64(3), branch.ID)
+			assert.Equal(t, "td5smq0f", branch.SchemaID)
+			assert.Equal(t, branchStatusReady, branch.Status.Value)
+		}
+	}
+
+	_, err = findBranch(context.Background(), api, "missing")
+	assert.EqualError(t, err, `branch "missing" not found`)
+}
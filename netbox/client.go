@@ -1,26 +1,68 @@
 package netbox
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"io"
 	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	netboxclient "github.com/fbreckle/go-netbox/netbox/client"
 	httptransport "github.com/go-openapi/runtime/client"
 	"github.com/goware/urlx"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
 )
 
 // Config struct for the netbox provider
 type Config struct {
 	APIToken                    string
+	TokenCommand                []string
+	APITokenScheme              string
 	ServerURL                   string
 	AllowInsecureHTTPS          bool
+	CACertFile                  string
+	CACertPEM                   string
+	ClientCertFile              string
+	ClientKeyFile               string
+	ClientCertPEM               string
+	ClientKeyPEM                string
+	TLSServerName               string
 	Headers                     map[string]interface{}
 	RequestTimeout              int
 	StripTrailingSlashesFromURL bool
+	MaxRetries                  int
+	RetryWaitMin                int
+	RetryWaitMax                int
+	MaxRequestsPerSecond        float64
+	MaxConcurrentRequests       int
+	BranchSchemaID              string
+	ReadOnly                    bool
+	LogHTTPBodies               bool
+	// LogContext carries the logger for requests whose context has none, e.g.
+	// the context the provider was configured with.
+	LogContext context.Context
 }
 
+// The schemes of the Authorization header. Netbox up to 4.4 only supports
+// Token, while the v2 tokens introduced with Netbox 4.5 are sent as Bearer
+// tokens.
+const (
+	apiTokenSchemeAuto   = "auto"
+	apiTokenSchemeToken  = "Token"
+	apiTokenSchemeBearer = "Bearer"
+)
+
+// v2TokenPrefix is the prefix of v2 API tokens.
+const v2TokenPrefix = "nbt_"
+
 // customHeaderTransport is a transport that adds the specified headers on
 // every request.
 type customHeaderTransport struct {
@@ -28,15 +70,95 @@ type customHeaderTransport struct {
 	headers  map[string]interface{}
 }
 
+// retryTransport is a transport that retries idempotent requests which failed
+// with a transient error, waiting with exponential backoff between attempts.
+// The timeout applies to every single attempt, including the time the attempt
+// waits for the throttle.
+type retryTransport struct {
+	original   http.RoundTripper
+	maxRetries int
+	waitMin    time.Duration
+	waitMax    time.Duration
+	timeout    time.Duration
+	// ctx carries the logger used for requests whose context has none.
+	ctx context.Context
+}
+
+// retryableStatusCodes are the response codes that indicate a transient
+// failure of Netbox or a reverse proxy in front of it.
+var retryableStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// idempotentMethods are the HTTP methods that are safe to send more than once.
+var idempotentMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodOptions,
+	http.MethodPut,
+	http.MethodDelete,
+}
+
+// throttleTransport is a transport that limits the rate of requests and the
+// number of requests in flight at the same time. A request stays in flight
+// until its response body is closed.
+type throttleTransport struct {
+	original http.RoundTripper
+	limiter  *rateLimiter
+	slots    chan struct{}
+}
+
+// readOnlyTransport is a transport that refuses to send requests which might
+// change data in Netbox.
+type readOnlyTransport struct {
+	original http.RoundTripper
+	// graphqlPath is the path of the GraphQL API, whose queries are sent as
+	// POST requests.
+	graphqlPath string
+}
+
+// readOnlyMethods are the HTTP methods a readOnlyTransport lets through.
+var readOnlyMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodOptions,
+}
+
+// readOnlyError is returned for requests refused by a readOnlyTransport.
+type readOnlyError struct {
+	method string
+	path   string
+}
+
+func (e *readOnlyError) Error() string {
+	return fmt.Sprintf("refusing to send %s %s, the provider is read-only", e.method, e.path)
+}
+
+// rateLimiter spaces out events so that they happen at a fixed maximum rate.
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
 // Client does the heavy lifting of establishing a base Open API client to Netbox.
 func (cfg *Config) Client() (*netboxclient.NetBoxAPI, error) {
 	log.WithFields(log.Fields{
 		"server_url": cfg.ServerURL,
 	}).Debug("Initializing Netbox client")
 
-	if cfg.APIToken == "" {
+	if cfg.APIToken == "" && len(cfg.TokenCommand) == 0 {
 		return nil, fmt.Errorf("missing netbox API key")
 	}
+	if cfg.APIToken != "" && len(cfg.TokenCommand) > 0 {
+		return nil, fmt.Errorf("only one of api_token and token_command may be set")
+	}
+	if !slices.Contains([]string{"", apiTokenSchemeAuto, apiTokenSchemeToken, apiTokenSchemeBearer}, cfg.APITokenScheme) {
+		return nil, fmt.Errorf("invalid API token scheme %q, expected one of %q, %q or %q", cfg.APITokenScheme, apiTokenSchemeAuto, apiTokenSchemeToken, apiTokenSchemeBearer)
+	}
 
 	// parse serverUrl
 	parsedURL, urlParseError := urlx.Parse(cfg.ServerURL)
@@ -51,41 +173,173 @@ func (cfg *Config) Client() (*netboxclient.NetBoxAPI, error) {
 	}).Debug("Initializing Netbox Open API runtime client")
 
 	// build http client
-	clientOpts := httptransport.TLSClientOptions{
-		InsecureSkipVerify: cfg.AllowInsecureHTTPS,
-	}
-
-	trans, err := httptransport.TLSTransport(clientOpts)
+	tlsConfig, err := cfg.tlsConfig()
 	if err != nil {
 		return nil, err
 	}
 
-	trans.(*http.Transport).Proxy = http.ProxyFromEnvironment
+	var trans http.RoundTripper = &http.Transport{
+		TLSClientConfig: tlsConfig,
+		Proxy:           http.ProxyFromEnvironment,
+	}
+
+	// Requests are logged as they are sent, i.e. with the headers added below
+	secrets := headerSecrets(cfg.Headers)
+	if cfg.APIToken != "" {
+		secrets = append(secrets, cfg.APIToken)
+	}
+	trans = loggingTransport{
+		original:  trans,
+		ctx:       cfg.LogContext,
+		logBodies: cfg.LogHTTPBodies,
+		secrets:   secrets,
+	}
 
-	if len(cfg.Headers) > 0 {
+	headers := cfg.Headers
+	if cfg.BranchSchemaID != "" {
+		headers = make(map[string]interface{}, len(cfg.Headers)+1)
+		for key, value := range cfg.Headers {
+			headers[key] = value
+		}
+		headers[branchHeader] = cfg.BranchSchemaID
+	}
+
+	if len(headers) > 0 {
 		log.WithFields(log.Fields{
-			"custom_headers": cfg.Headers,
+			"custom_headers": headers,
 		}).Debug("Setting custom headers on every request to Netbox")
 
 		trans = customHeaderTransport{
 			original: trans,
-			headers:  cfg.Headers,
+			headers:  headers,
+		}
+	}
+
+	if len(cfg.TokenCommand) > 0 {
+		trans = tokenCommandTransport{
+			original: trans,
+			source:   newTokenCommandSource(cfg.TokenCommand, cfg.LogContext),
+			scheme:   cfg.APITokenScheme,
+		}
+	}
+
+	// Every attempt of a retried request counts against the limits
+	if cfg.MaxRequestsPerSecond > 0 || cfg.MaxConcurrentRequests > 0 {
+		log.WithFields(log.Fields{
+			"max_requests_per_second": cfg.MaxRequestsPerSecond,
+			"max_concurrent_requests": cfg.MaxConcurrentRequests,
+		}).Debug("Throttling requests to Netbox")
+
+		throttle := throttleTransport{
+			original: trans,
+		}
+		if cfg.MaxRequestsPerSecond > 0 {
+			throttle.limiter = newRateLimiter(cfg.MaxRequestsPerSecond)
+		}
+		if cfg.MaxConcurrentRequests > 0 {
+			throttle.slots = make(chan struct{}, cfg.MaxConcurrentRequests)
+		}
+		trans = throttle
+	}
+
+	trans = retryTransport{
+		original:   trans,
+		maxRetries: cfg.MaxRetries,
+		waitMin:    time.Second * time.Duration(cfg.RetryWaitMin),
+		waitMax:    time.Second * time.Duration(cfg.RetryWaitMax),
+		timeout:    time.Second * time.Duration(cfg.RequestTimeout),
+		ctx:        cfg.LogContext,
+	}
+
+	if cfg.ReadOnly {
+		log.Debug("Refusing all requests to Netbox that might change data")
+
+		trans = readOnlyTransport{
+			original:    trans,
+			graphqlPath: path.Join("/", parsedURL.Path, "graphql"),
 		}
 	}
 
 	httpClient := &http.Client{
 		Transport: trans,
-		Timeout:   time.Second * time.Duration(cfg.RequestTimeout),
 	}
 
 	transport := httptransport.NewWithClient(parsedURL.Host, parsedURL.Path+netboxclient.DefaultBasePath, desiredRuntimeClientSchemes, httpClient)
-	transport.DefaultAuthentication = httptransport.APIKeyAuth("Authorization", "header", fmt.Sprintf("Token %v", cfg.APIToken))
-	transport.SetLogger(log.StandardLogger())
+	if cfg.APIToken != "" {
+		transport.DefaultAuthentication = httptransport.APIKeyAuth("Authorization", "header", authorizationHeader(cfg.APITokenScheme, cfg.APIToken))
+	}
+	// Requests are logged by the loggingTransport, the debug output of the
+	// runtime would include the API token
+	transport.SetDebug(false)
 	netboxClient := netboxclient.New(transport, nil)
 
 	return netboxClient, nil
 }
 
+// tlsConfig returns the TLS configuration for the connection to Netbox. A
+// client certificate given as PEM is sent along with the intermediate
+// certificates that follow it.
+func (cfg *Config) tlsConfig() (*tls.Config, error) {
+	opts := httptransport.TLSClientOptions{
+		InsecureSkipVerify: cfg.AllowInsecureHTTPS,
+		CA:                 cfg.CACertFile,
+		Certificate:        cfg.ClientCertFile,
+		Key:                cfg.ClientKeyFile,
+		ServerName:         cfg.TLSServerName,
+	}
+
+	if cfg.CACertFile != "" && cfg.CACertPEM != "" {
+		return nil, fmt.Errorf("only one of ca_cert_file and ca_cert_pem may be set")
+	}
+	if cfg.CACertPEM != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(cfg.CACertPEM)) {
+			return nil, fmt.Errorf("ca_cert_pem does not contain any PEM encoded certificate")
+		}
+		opts.LoadedCAPool = pool
+	}
+
+	if (cfg.ClientCertFile != "" || cfg.ClientKeyFile != "") && (cfg.ClientCertPEM != "" || cfg.ClientKeyPEM != "") {
+		return nil, fmt.Errorf("client_cert_file and client_key_file cannot be combined with client_cert_pem and client_key_pem")
+	}
+	if (cfg.ClientCertFile == "") != (cfg.ClientKeyFile == "") {
+		return nil, fmt.Errorf("client_cert_file and client_key_file must be set together")
+	}
+	if (cfg.ClientCertPEM == "") != (cfg.ClientKeyPEM == "") {
+		return nil, fmt.Errorf("client_cert_pem and client_key_pem must be set together")
+	}
+
+	tlsConfig, err := httptransport.TLSClientAuth(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// The runtime only keeps the leaf of loaded certificates, so the
+	// certificate chain is set directly
+	if cfg.ClientCertPEM != "" {
+		cert, err := tls.X509KeyPair([]byte(cfg.ClientCertPEM), []byte(cfg.ClientKeyPEM))
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// authorizationHeader returns the value of the Authorization header for the
+// token. With the auto scheme, v2 tokens are sent as Bearer tokens and all
+// other tokens with the Token scheme.
+func authorizationHeader(scheme, token string) string {
+	if scheme == "" || scheme == apiTokenSchemeAuto {
+		scheme = apiTokenSchemeToken
+		if strings.HasPrefix(token, v2TokenPrefix) {
+			scheme = apiTokenSchemeBearer
+		}
+	}
+	return fmt.Sprintf("%s %v", scheme, token)
+}
+
 // RoundTrip adds the headers specified in the transport on every request.
 func (t customHeaderTransport) RoundTrip(r *http.Request) (*http.Response, error) {
 	for key, value := range t.headers {
@@ -95,3 +349,201 @@ func (t customHeaderTransport) RoundTrip(r *http.Request) (*http.Response, error
 	resp, err := t.original.RoundTrip(r)
 	return resp, err
 }
+
+// RoundTrip sends the request and retries it as long as it fails with a
+// transient error and there are retries left.
+func (t retryTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	retryable := slices.Contains(idempotentMethods, r.Method) && (r.Body == nil || r.Body == http.NoBody || r.GetBody != nil)
+
+	for attempt := 0; ; attempt++ {
+		req, cancel, err := t.newAttempt(r, attempt)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := t.original.RoundTrip(req)
+		if err != nil {
+			cancel()
+		} else {
+			resp.Body = onCloseBody{ReadCloser: resp.Body, onClose: cancel}
+		}
+
+		if !retryable || attempt >= t.maxRetries || r.Context().Err() != nil || !isTransientFailure(resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		fields := map[string]interface{}{
+			"http.method":  r.Method,
+			"http.path":    r.URL.Path,
+			"http.attempt": attempt + 1,
+			"http.wait":    wait.String(),
+		}
+		if err != nil {
+			fields["error"] = err.Error()
+		} else {
+			fields["http.status"] = resp.StatusCode
+			// Drain the body so the underlying connection can be reused
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		tflog.SubsystemWarn(httpLogContext(r.Context(), t.ctx), httpLogSubsystem, "Retrying request to Netbox after transient failure", fields)
+
+		select {
+		case <-r.Context().Done():
+			return nil, r.Context().Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// newAttempt returns a copy of r to be sent as the given attempt, together
+// with the function that releases its timeout.
+func (t retryTransport) newAttempt(r *http.Request, attempt int) (*http.Request, context.CancelFunc, error) {
+	ctx, cancel := r.Context(), context.CancelFunc(func() {})
+	if t.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, t.timeout)
+	}
+
+	req := r.Clone(ctx)
+	if attempt > 0 && r.GetBody != nil {
+		body, err := r.GetBody()
+		if err != nil {
+			cancel()
+			return nil, nil, fmt.Errorf("could not rewind body of %s %s for retry: %w", r.Method, r.URL, err)
+		}
+		req.Body = body
+	}
+	return req, cancel, nil
+}
+
+// minRetryWait is the wait time the exponential backoff starts from if waitMin
+// is lower, e.g. 0, which would not grow by doubling.
+const minRetryWait = 100 * time.Millisecond
+
+// backoff returns how long to wait before the next attempt. A Retry-After
+// header sent by the server takes precedence over the exponential backoff.
+// The wait never exceeds waitMax.
+func (t retryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	wait := max(t.waitMin, minRetryWait) << attempt
+	if wait <= 0 || wait > t.waitMax {
+		wait = t.waitMax
+	}
+
+	if resp != nil {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			wait = min(retryAfter, t.waitMax)
+		}
+	}
+	return wait
+}
+
+// isTransientFailure reports whether a request failed in a way that might
+// succeed when it is sent again.
+func isTransientFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return slices.Contains(retryableStatusCodes, resp.StatusCode)
+}
+
+// parseRetryAfter parses the value of a Retry-After header, which is either a
+// number of seconds or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Second * time.Duration(seconds), true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// RoundTrip waits until the request may be sent without exceeding the limits
+// of the transport and sends it.
+func (t throttleTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	release := func() {}
+	if t.slots != nil {
+		select {
+		case t.slots <- struct{}{}:
+			release = sync.OnceFunc(func() { <-t.slots })
+		case <-r.Context().Done():
+			return nil, r.Context().Err()
+		}
+	}
+
+	if t.limiter != nil {
+		if err := t.limiter.Wait(r.Context()); err != nil {
+			release()
+			return nil, err
+		}
+	}
+
+	resp, err := t.original.RoundTrip(r)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	resp.Body = onCloseBody{ReadCloser: resp.Body, onClose: release}
+	return resp, nil
+}
+
+// RoundTrip sends the request if it cannot change any data in Netbox. Queries
+// of the GraphQL API are sent with POST, but the GraphQL API of Netbox does not
+// support mutations.
+func (t readOnlyTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if !slices.Contains(readOnlyMethods, r.Method) && !(r.Method == http.MethodPost && path.Clean(r.URL.Path) == t.graphqlPath) {
+		if r.Body != nil {
+			r.Body.Close()
+		}
+		return nil, &readOnlyError{method: r.Method, path: r.URL.Path}
+	}
+	return t.original.RoundTrip(r)
+}
+
+func newRateLimiter(perSecond float64) *rateLimiter {
+	return &rateLimiter{
+		interval: time.Duration(float64(time.Second) / perSecond),
+	}
+}
+
+// Wait blocks until the next event may happen or ctx is done.
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	if !at.After(now) {
+		return nil
+	}
+
+	timer := time.NewTimer(at.Sub(now))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// onCloseBody calls onClose once the response body has been closed, e.g. to
+// release resources held for the request.
+type onCloseBody struct {
+	io.ReadCloser
+	onClose func()
+}
+
+func (b onCloseBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.onClose()
+	return err
+}
@@ -1,11 +1,28 @@
 package netbox
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fbreckle/go-netbox/netbox/client/extras"
+	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/exp/slices"
 )
 
 const customFieldsKey = "custom_fields"
 
+const customFieldsDescription = "Custom fields of the object, keyed by the name of the custom field. All values are strings and are converted according to the type of the custom field: " +
+	"`integer`, `decimal` and `object` (the ID of the referenced object) values are given as numbers, `boolean` values as `true` or `false`, " +
+	"`multiselect` and `multiobject` values as JSON lists (e.g. `jsonencode([1, 2])`) and `json` values as JSON documents. Set a value to an empty string to clear it."
+
 var customFieldsSchema = &schema.Schema{
 	Type:     schema.TypeMap,
 	Optional: true,
@@ -14,17 +31,385 @@ var customFieldsSchema = &schema.Schema{
 		Type:    schema.TypeString,
 		Default: nil,
 	},
+	Description:      customFieldsDescription,
+	DiffSuppressFunc: customFieldValueDiffSuppress,
+}
+
+// customFieldDefinition is the part of a custom field definition that is
+// needed to convert its values.
+type customFieldDefinition struct {
+	fieldType   string
+	objectTypes []string
+}
+
+// customFieldCache remembers the definitions of all custom fields for the
+// lifetime of the provider. Custom field names are unique across all object
+// types.
+type customFieldCache struct {
+	mu          sync.Mutex
+	definitions map[string]customFieldDefinition
+	loaded      bool
+}
+
+func newCustomFieldCache() *customFieldCache {
+	return &customFieldCache{}
+}
+
+// knownCustomFieldTypes remembers the types of all custom field definitions
+// loaded by any customFieldCache. It is used by customFieldValueDiffSuppress,
+// which has no access to the provider state.
+var knownCustomFieldTypes sync.Map
+
+// customFieldType returns the type of the named custom field, or an empty
+// string if its definition has not been loaded.
+func customFieldType(name string) string {
+	fieldType, _ := knownCustomFieldTypes.Load(name)
+	s, _ := fieldType.(string)
+	return s
+}
+
+// lookup returns the definitions of the given custom fields. All custom
+// fields are reloaded if one of the names is unknown, since it might have been
+// created since they were last loaded.
+func (c *customFieldCache) lookup(ctx context.Context, api *providerState, names []string) (map[string]customFieldDefinition, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	reload := !c.loaded
+	for _, name := range names {
+		if _, ok := c.definitions[name]; !ok {
+			reload = true
+		}
+	}
+
+	if reload {
+		fields, _, err := listAll(extras.NewExtrasCustomFieldsListParamsWithContext(ctx), 0, func(p *extras.ExtrasCustomFieldsListParams) (*listPage[*models.CustomField], error) {
+			res, err := api.Extras.ExtrasCustomFieldsList(p, nil)
+			if err != nil {
+				return nil, err
+			}
+			payload := res.GetPayload()
+			return &listPage[*models.CustomField]{Count: payload.Count, Next: payload.Next, Results: payload.Results}, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		c.definitions = make(map[string]customFieldDefinition, len(fields))
+		for _, field := range fields {
+			if field.Name == nil || field.Type == nil || field.Type.Value == nil {
+				continue
+			}
+			// Netbox 4.0 renamed content_types to object_types
+			objectTypes := field.ObjectTypes
+			if len(objectTypes) == 0 {
+				objectTypes = field.ContentTypes
+			}
+			c.definitions[*field.Name] = customFieldDefinition{
+				fieldType:   *field.Type.Value,
+				objectTypes: objectTypes,
+			}
+			knownCustomFieldTypes.Store(*field.Name, *field.Type.Value)
+		}
+		c.loaded = true
+	}
+
+	definitions := make(map[string]customFieldDefinition, len(names))
+	for _, name := range names {
+		if definition, ok := c.definitions[name]; ok {
+			definitions[name] = definition
+		}
+	}
+	return definitions, nil
+}
+
+// invalidate drops all cached custom field definitions. It must be called
+// whenever custom fields are changed in Netbox.
+func (c *customFieldCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.loaded = false
+}
+
+// getCustomFieldsFromResourceData converts the custom fields of a resource to
+// the typed values expected by the Netbox API. The default custom fields of
+// the provider that are assigned to objectType (e.g. `dcim.device`) are added
+// and custom fields that were removed from the configuration are cleared. It
+// returns nil if no custom fields have to be sent.
+func getCustomFieldsFromResourceData(ctx context.Context, api *providerState, d *schema.ResourceData, objectType string) (map[string]interface{}, error) {
+	oldRaw, newRaw := d.GetChange(customFieldsKey)
+	oldFields, _ := oldRaw.(map[string]interface{})
+	newFields, _ := newRaw.(map[string]interface{})
+
+	names := make([]string, 0, len(oldFields)+len(newFields)+len(api.defaultCustomFields))
+	for _, fields := range []map[string]interface{}{oldFields, newFields, api.defaultCustomFields} {
+		for name := range fields {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	if len(names) == 0 {
+		return nil, nil
+	}
+	sort.Strings(names)
+
+	definitions, err := api.customFields.lookup(ctx, api, names)
+	if err != nil {
+		if len(api.defaultCustomFields) > 0 {
+			// It is unknown which default custom fields apply to the object
+			return nil, fmt.Errorf("could not retrieve custom field definitions from Netbox to apply the default custom fields: %w", err)
+		}
+		// Without the definitions, the values can only be sent as strings
+		log.WithFields(log.Fields{
+			"error": err,
+		}).Warn("Could not retrieve custom field definitions from Netbox, sending custom fields as strings")
+		definitions = nil
+	}
+
+	cf := make(map[string]interface{}, len(names))
+	for name, value := range api.defaultCustomFields {
+		if slices.Contains(definitions[name].objectTypes, objectType) {
+			cf[name] = value
+		}
+	}
+	for name := range oldFields {
+		if _, ok := cf[name]; !ok {
+			cf[name] = ""
+		}
+	}
+	for name, value := range newFields {
+		cf[name] = value
+	}
+	if len(cf) == 0 {
+		return nil, nil
+	}
+	if definitions == nil {
+		return cf, nil
+	}
+
+	for name, value := range cf {
+		value, err := customFieldValueToAPI(definitions[name].fieldType, value.(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for custom field %s: %w", name, err)
+		}
+		cf[name] = value
+	}
+	return cf, nil
+}
+
+// withoutDefaultCustomFields removes the default custom fields of the provider
+// from the custom fields read from Netbox, unless they are also set on the
+// resource itself or their value differs from the default. This keeps the
+// default custom fields out of the diff of every resource, while changes
+// made outside of Terraform are still detected.
+func withoutDefaultCustomFields(api *providerState, d *schema.ResourceData, cf map[string]interface{}) map[string]interface{} {
+	if len(api.defaultCustomFields) == 0 || cf == nil {
+		return cf
+	}
+
+	configured := d.Get(customFieldsKey).(map[string]interface{})
+	result := make(map[string]interface{}, len(cf))
+	for name, value := range cf {
+		if defaultValue, ok := api.defaultCustomFields[name]; ok {
+			if _, ok := configured[name]; !ok && customFieldValueDiffSuppress(customFieldsKey+"."+name, defaultValue.(string), value.(string), nil) {
+				continue
+			}
+		}
+		result[name] = value
+	}
+	return result
 }
 
-func getCustomFields(cf interface{}) map[string]interface{} {
+// getCustomFields converts the custom fields returned by the Netbox API to
+// their string representation in the state. Custom fields without a value
+// are omitted. It returns nil if there are no custom fields with a value.
+func getCustomFields(ctx context.Context, api *providerState, cf interface{}) map[string]interface{} {
 	cfm, ok := cf.(map[string]interface{})
 	if !ok || len(cfm) == 0 {
 		return nil
 	}
-	for k, v := range cfm {
-		if v == nil {
-			delete(cfm, k)
+
+	names := make([]string, 0, len(cfm))
+	for name, value := range cfm {
+		if value != nil {
+			names = append(names, name)
 		}
 	}
-	return cfm
+	if len(names) == 0 {
+		return nil
+	}
+	sort.Strings(names)
+
+	definitions, err := api.customFields.lookup(ctx, api, names)
+	if err != nil {
+		// The type can still be derived from the values in most cases
+		log.WithFields(log.Fields{
+			"error": err,
+		}).Debug("Could not retrieve custom field definitions from Netbox, deriving types from values")
+	}
+
+	result := make(map[string]interface{}, len(names))
+	for _, name := range names {
+		result[name] = customFieldValueFromAPI(definitions[name].fieldType, cfm[name])
+	}
+	return result
+}
+
+// customFieldValueToAPI converts the string representation of a custom field
+// value to the value expected by the Netbox API for the given type. An empty
+// string clears the custom field.
+func customFieldValueToAPI(fieldType, value string) (interface{}, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	switch fieldType {
+	case "integer", "object":
+		i, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an integer", value)
+		}
+		return i, nil
+	case "decimal":
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a decimal number", value)
+		}
+		return f, nil
+	case "boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a boolean", value)
+		}
+		return b, nil
+	case "multiselect":
+		var choices []string
+		if err := json.Unmarshal([]byte(value), &choices); err != nil {
+			return nil, fmt.Errorf("%q is not a JSON list of strings", value)
+		}
+		return choices, nil
+	case "multiobject":
+		var ids []int64
+		if err := json.Unmarshal([]byte(value), &ids); err != nil {
+			return nil, fmt.Errorf("%q is not a JSON list of object IDs", value)
+		}
+		return ids, nil
+	case "json":
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(value), &decoded); err != nil {
+			return nil, fmt.Errorf("%q is not valid JSON: %w", value, err)
+		}
+		return decoded, nil
+	default:
+		// text, longtext, url, select, date, datetime and unknown types
+		return value, nil
+	}
+}
+
+// customFieldValueFromAPI converts a custom field value returned by the Netbox
+// API to its string representation. If the type is unknown, it is derived
+// from the value.
+func customFieldValueFromAPI(fieldType string, value interface{}) string {
+	switch fieldType {
+	case "object":
+		return customFieldObjectID(value)
+	case "multiobject":
+		objects, _ := value.([]interface{})
+		ids := make([]json.RawMessage, 0, len(objects))
+		for _, object := range objects {
+			ids = append(ids, json.RawMessage(customFieldObjectID(object)))
+		}
+		return mustMarshalJSON(ids)
+	case "json", "multiselect":
+		return mustMarshalJSON(value)
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return v.String()
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case map[string]interface{}:
+		if _, ok := v["id"]; ok && fieldType == "" {
+			return customFieldObjectID(v)
+		}
+	case []interface{}:
+		if isObjectList(v) && fieldType == "" {
+			return customFieldValueFromAPI("multiobject", v)
+		}
+	}
+	return mustMarshalJSON(value)
+}
+
+// customFieldObjectID returns the ID of an object referenced by a custom
+// field, which Netbox returns as a nested object.
+func customFieldObjectID(value interface{}) string {
+	if object, ok := value.(map[string]interface{}); ok {
+		value = object["id"]
+	}
+	return customFieldValueFromAPI("integer", value)
+}
+
+func isObjectList(values []interface{}) bool {
+	if len(values) == 0 {
+		return false
+	}
+	for _, value := range values {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if _, ok := object["id"]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func mustMarshalJSON(value interface{}) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(encoded)
+}
+
+// customFieldValueDiffSuppress suppresses differences between custom field
+// values that are represented differently in the configuration and in Netbox,
+// e.g. `1` and `true` for boolean fields, `1.50` and `1.5` for decimal fields
+// or JSON with different whitespace for json fields. Values of custom fields
+// whose type is unknown are compared as they are.
+func customFieldValueDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	if old == new {
+		return true
+	}
+	if old == "" || new == "" {
+		return false
+	}
+
+	switch customFieldType(strings.TrimPrefix(k, customFieldsKey+".")) {
+	case "integer", "decimal", "object":
+		oldNumber, oldErr := strconv.ParseFloat(old, 64)
+		newNumber, newErr := strconv.ParseFloat(new, 64)
+		return oldErr == nil && newErr == nil && oldNumber == newNumber
+	case "boolean":
+		oldBool, oldErr := strconv.ParseBool(old)
+		newBool, newErr := strconv.ParseBool(new)
+		return oldErr == nil && newErr == nil && oldBool == newBool
+	case "json", "multiselect", "multiobject":
+		equal, err := jsonSemanticCompare(old, new)
+		return err == nil && equal
+	case "datetime":
+		oldTime, oldErr := time.Parse(time.RFC3339, old)
+		newTime, newErr := time.Parse(time.RFC3339, new)
+		return oldErr == nil && newErr == nil && oldTime.Equal(newTime)
+	}
+
+	return false
 }
@@ -0,0 +1,197 @@
+package netbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomFieldValueToAPI(t *testing.T) {
+	for _, tt := range []struct {
+		fieldType string
+		value     string
+		expected  interface{}
+	}{
+		{"text", "foo", "foo"},
+		{"integer", "42", int64(42)},
+		{"object", "7", int64(7)},
+		{"decimal", "1.5", 1.5},
+		{"boolean", "true", true},
+		{"multiselect", `["a","b"]`, []string{"a", "b"}},
+		{"multiobject", "[1, 2]", []int64{1, 2}},
+		{"json", `{"foo": [1]}`, map[string]interface{}{"foo": []interface{}{float64(1)}}},
+		{"", "foo", "foo"},
+		{"integer", "", nil},
+	} {
+		actual, err := customFieldValueToAPI(tt.fieldType, tt.value)
+		assert.NoError(t, err, "%s %q", tt.fieldType, tt.value)
+		assert.Equal(t, tt.expected, actual, "%s %q", tt.fieldType, tt.value)
+	}
+}
+
+func TestCustomFieldValueToAPIInvalid(t *testing.T) {
+	for fieldType, value := range map[string]string{
+		"integer":     "foo",
+		"decimal":     "foo",
+		"boolean":     "foo",
+		"multiselect": "foo",
+		"multiobject": `["a"]`,
+		"json":        "{",
+	} {
+		_, err := customFieldValueToAPI(fieldType, value)
+		assert.Error(t, err, "%s %q", fieldType, value)
+	}
+}
+
+func TestCustomFieldValueFromAPI(t *testing.T) {
+	for _, tt := range []struct {
+		fieldType string
+		value     interface{}
+		expected  string
+	}{
+		{"text", "foo", "foo"},
+		{"integer", json.Number("42"), "42"},
+		{"decimal", json.Number("1.50"), "1.50"},
+		{"decimal", 1.5, "1.5"},
+		{"boolean", false, "false"},
+		{"object", map[string]interface{}{"id": json.Number("7"), "name": "foo"}, "7"},
+		{"multiobject", []interface{}{map[string]interface{}{"id": json.Number("1")}, map[string]interface{}{"id": json.Number("2")}}, "[1,2]"},
+		{"multiselect", []interface{}{"a", "b"}, `["a","b"]`},
+		{"json", map[string]interface{}{"foo": "bar"}, `{"foo":"bar"}`},
+		{"", map[string]interface{}{"id": json.Number("7")}, "7"},
+		{"", []interface{}{map[string]interface{}{"id": json.Number("1")}}, "[1]"},
+		{"", true, "true"},
+	} {
+		assert.Equal(t, tt.expected, customFieldValueFromAPI(tt.fieldType, tt.value), "%s %v", tt.fieldType, tt.value)
+	}
+}
+
+func TestCustomFieldValueDiffSuppress(t *testing.T) {
+	for name, fieldType := range map[string]string{
+		"diff_text":     "text",
+		"diff_integer":  "integer",
+		"diff_decimal":  "decimal",
+		"diff_boolean":  "boolean",
+		"diff_json":     "json",
+		"diff_multi":    "multiobject",
+		"diff_datetime": "datetime",
+	} {
+		knownCustomFieldTypes.Store(name, fieldType)
+	}
+
+	for _, tt := range []struct {
+		name, old, new string
+		expected       bool
+	}{
+		{"diff_text", "foo", "foo", true},
+		{"diff_text", "foo", "bar", false},
+		{"diff_text", "", "foo", false},
+		{"diff_text", "1", "true", false},
+		{"diff_text", "1.5", "1.50", false},
+		{"diff_text", `{"a": 1}`, `{"a":1}`, false},
+		{"diff_unknown", "1", "true", false},
+		{"diff_unknown", "1.5", "1.50", false},
+		{"diff_boolean", "1", "true", true},
+		{"diff_boolean", "0", "true", false},
+		{"diff_integer", "7", "07", true},
+		{"diff_integer", "7", "8", false},
+		{"diff_decimal", "1.5", "1.50", true},
+		{"diff_json", `{"a": 1, "b": 2}`, `{"b":2,"a":1}`, true},
+		{"diff_multi", "[1,2]", "[1, 2]", true},
+		{"diff_multi", "[1,2]", "[2,1]", false},
+		{"diff_datetime", "2024-01-01T10:00:00Z", "2024-01-01T11:00:00+01:00", true},
+	} {
+		assert.Equal(t, tt.expected, customFieldValueDiffSuppress(customFieldsKey+"."+tt.name, tt.old, tt.new, nil), "%s %q %q", tt.name, tt.old, tt.new)
+	}
+}
+
+func TestGetCustomFieldsFromResourceDataWithDefaults(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"count": 2, "results": [
+			{"id": 1, "name": "cost_center", "type": {"value": "integer"}, "object_types": ["dcim.device", "dcim.site"]},
+			{"id": 2, "name": "owner", "type": {"value": "text"}, "object_types": ["dcim.device"]}
+		]}`)
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: ts.URL,
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+	api := newProviderState(client)
+	api.defaultCustomFields = map[string]interface{}{"cost_center": "42", "owner": "network"}
+
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{customFieldsKey: customFieldsSchema}, map[string]interface{}{
+		customFieldsKey: map[string]interface{}{"owner": "compute"},
+	})
+
+	cf, err := getCustomFieldsFromResourceData(context.Background(), api, d, "dcim.device")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"cost_center": int64(42), "owner": "compute"}, cf)
+
+	cf, err = getCustomFieldsFromResourceData(context.Background(), api, d, "dcim.site")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"cost_center": int64(42), "owner": "compute"}, cf)
+
+	d = schema.TestResourceDataRaw(t, map[string]*schema.Schema{customFieldsKey: customFieldsSchema}, map[string]interface{}{})
+
+	cf, err = getCustomFieldsFromResourceData(context.Background(), api, d, "dcim.site")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"cost_center": int64(42)}, cf)
+
+}
+
+func TestGetCustomFieldsFromResourceDataLookupFailure(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: ts.URL,
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+	api := newProviderState(client)
+
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{customFieldsKey: customFieldsSchema}, map[string]interface{}{
+		customFieldsKey: map[string]interface{}{"owner": "compute"},
+	})
+
+	// Without default custom fields, the values are sent as strings
+	cf, err := getCustomFieldsFromResourceData(context.Background(), api, d, "dcim.device")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"owner": "compute"}, cf)
+
+	// Default custom fields are never dropped silently
+	api.defaultCustomFields = map[string]interface{}{"cost_center": "42"}
+	_, err = getCustomFieldsFromResourceData(context.Background(), api, d, "dcim.device")
+	assert.ErrorContains(t, err, "could not retrieve custom field definitions from Netbox to apply the default custom fields")
+}
+
+func TestWithoutDefaultCustomFields(t *testing.T) {
+	api := newProviderState(nil)
+	api.defaultCustomFields = map[string]interface{}{"cost_center": "42", "owner": "network", "managed": "true"}
+
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{customFieldsKey: customFieldsSchema}, map[string]interface{}{
+		customFieldsKey: map[string]interface{}{"owner": "network"},
+	})
+
+	cf := withoutDefaultCustomFields(api, d, map[string]interface{}{
+		"cost_center": "42",
+		"owner":       "network",
+		"managed":     "false",
+		"other":       "foo",
+	})
+	assert.Equal(t, map[string]interface{}{"owner": "network", "managed": "false", "other": "foo"}, cf)
+}
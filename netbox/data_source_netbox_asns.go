@@ -4,8 +4,8 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
+	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -38,6 +38,7 @@ func dataSourceNetboxAsns() *schema.Resource {
 				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
 				Default:          0,
 			},
+			"total_count": totalCountSchema,
 			"asns": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -64,14 +65,10 @@ func dataSourceNetboxAsns() *schema.Resource {
 }
 
 func dataSourceNetboxAsnsRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	params := ipam.NewIpamAsnsListParams()
 
-	if limitValue, ok := d.GetOk("limit"); ok {
-		params.Limit = int64ToPtr(int64(limitValue.(int)))
-	}
-
 	if filter, ok := d.GetOk("filter"); ok {
 		var filterParams = filter.(*schema.Set)
 		for _, f := range filterParams.List() {
@@ -93,17 +90,22 @@ func dataSourceNetboxAsnsRead(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
-	res, err := api.Ipam.IpamAsnsList(params, nil)
+	filteredAsns, count, err := listAll(params, int64(d.Get("limit").(int)), func(p *ipam.IpamAsnsListParams) (*listPage[*models.ASN], error) {
+		res, err := api.Ipam.IpamAsnsList(p, nil)
+		if err != nil {
+			return nil, err
+		}
+		payload := res.GetPayload()
+		return &listPage[*models.ASN]{Count: payload.Count, Next: payload.Next, Results: payload.Results}, nil
+	})
 	if err != nil {
 		return err
 	}
 
-	if *res.GetPayload().Count == int64(0) {
+	if count == int64(0) {
 		return errors.New("no result")
 	}
 
-	filteredAsns := res.GetPayload().Results
-
 	var s []map[string]interface{}
 	for _, v := range filteredAsns {
 		var mapping = make(map[string]interface{})
@@ -117,5 +119,6 @@ func dataSourceNetboxAsnsRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	d.SetId(id.UniqueId())
+	d.Set("total_count", count)
 	return d.Set("asns", s)
 }
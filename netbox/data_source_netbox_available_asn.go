@@ -0,0 +1,78 @@
+package netbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceNetboxAvailableAsn() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNetboxAvailableAsnRead,
+		Description: `:meta:subcategory:IP Address Management (IPAM):`,
+		Schema: map[string]*schema.Schema{
+			"asn_range_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The maximum number of AS numbers to return. Netbox limits the number of AS numbers to its maximum page size.",
+			},
+			"asns_available": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The AS numbers in the ASN range that are not used by an AS Number record, in ascending order.",
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNetboxAvailableAsnRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+
+	rangeID := strconv.Itoa(d.Get("asn_range_id").(int))
+	query := url.Values{}
+	limit, hasLimit := d.GetOk("limit")
+	if hasLimit {
+		query.Set("limit", strconv.Itoa(limit.(int)))
+	}
+
+	res, err := genericAPIRequest(ctx, api, http.MethodGet, genericAPIPath(asnRangesPath, rangeID, "available-asns"), query, nil)
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
+	}
+
+	var payload []struct {
+		Asn int64 `json:"asn"`
+	}
+	if err := json.Unmarshal(res, &payload); err != nil {
+		return diag.FromErr(fmt.Errorf("unexpected response when listing the available AS numbers of ASN range %s: %w", rangeID, err))
+	}
+
+	asns := make([]int64, 0, len(payload))
+	for _, v := range payload {
+		if hasLimit && len(asns) >= limit.(int) {
+			break
+		}
+		asns = append(asns, v.Asn)
+	}
+
+	d.SetId(id.UniqueId())
+	d.Set("asns_available", asns)
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package netbox
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNetboxAvailableAsnDataSource_basic(t *testing.T) {
+	testSlug := "avail_asn_ds_basic"
+	testName := testAccGetTestName(testSlug)
+	resource.ParallelTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "netbox_rir" "test" {
+  name = "%[1]s"
+}
+
+resource "netbox_asn_range" "test" {
+  name   = "%[1]s"
+  rir_id = netbox_rir.test.id
+  start  = 4200002000
+  end    = 4200002003
+}
+
+resource "netbox_asn" "test" {
+  asn    = 4200002001
+  rir_id = netbox_rir.test.id
+}
+
+data "netbox_available_asn" "all" {
+  asn_range_id = netbox_asn_range.test.id
+  depends_on   = [netbox_asn.test]
+}
+
+data "netbox_available_asn" "limited" {
+  asn_range_id = netbox_asn_range.test.id
+  limit        = 1
+  depends_on   = [netbox_asn.test]
+}`, testName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.netbox_available_asn.all", "asns_available.#", "3"),
+					resource.TestCheckResourceAttr("data.netbox_available_asn.all", "asns_available.0", "4200002000"),
+					resource.TestCheckResourceAttr("data.netbox_available_asn.all", "asns_available.1", "4200002002"),
+					resource.TestCheckResourceAttr("data.netbox_available_asn.all", "asns_available.2", "4200002003"),
+					resource.TestCheckResourceAttr("data.netbox_available_asn.limited", "asns_available.#", "1"),
+					resource.TestCheckResourceAttr("data.netbox_available_asn.limited", "asns_available.0", "4200002000"),
+				),
+			},
+		},
+	})
+}
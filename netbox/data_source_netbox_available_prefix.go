@@ -1,7 +1,6 @@
 package netbox
 
 import (
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -41,7 +40,7 @@ func dataSourceNetboxAvailablePrefix() *schema.Resource {
 }
 
 func dataSourceNetboxAvailablePrefixRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	params := ipam.NewIpamPrefixesAvailablePrefixesListParams()
 
@@ -0,0 +1,51 @@
+package netbox
+
+import (
+	"context"
+
+	"github.com/fbreckle/go-netbox/netbox/client/ipam"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceNetboxAvailableVlan() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNetboxAvailableVlanRead,
+		Description: `:meta:subcategory:IP Address Management (IPAM):`,
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"vids_available": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The VIDs in the VID ranges of the VLAN group that are not used by a VLAN, in ascending order.",
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNetboxAvailableVlanRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+
+	params := ipam.NewIpamVlanGroupsAvailableVlansListParamsWithContext(ctx)
+	params.ID = int64(d.Get("group_id").(int))
+
+	res, err := api.Ipam.IpamVlanGroupsAvailableVlansList(params, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	vids := make([]int64, 0, len(res.GetPayload()))
+	for _, v := range res.GetPayload() {
+		vids = append(vids, v.Vid)
+	}
+
+	d.SetId(id.UniqueId())
+	return diag.FromErr(d.Set("vids_available", vids))
+}
@@ -0,0 +1,43 @@
+package netbox
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNetboxAvailableVlanDataSource_basic(t *testing.T) {
+	testSlug := "avail_vlan_ds_basic"
+	testName := testAccGetTestName(testSlug)
+	resource.ParallelTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "netbox_vlan_group" "test" {
+  name       = "%[1]s"
+  slug       = "%[1]s"
+  vid_ranges = [[10, 13], [20, 20]]
+}
+
+resource "netbox_vlan" "test" {
+  name     = "%[1]s"
+  vid      = 11
+  group_id = netbox_vlan_group.test.id
+}
+
+data "netbox_available_vlan" "test" {
+  group_id = netbox_vlan.test.group_id
+}`, testName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.netbox_available_vlan.test", "vids_available.#", "4"),
+					resource.TestCheckResourceAttr("data.netbox_available_vlan.test", "vids_available.0", "10"),
+					resource.TestCheckResourceAttr("data.netbox_available_vlan.test", "vids_available.1", "12"),
+					resource.TestCheckResourceAttr("data.netbox_available_vlan.test", "vids_available.2", "13"),
+					resource.TestCheckResourceAttr("data.netbox_available_vlan.test", "vids_available.3", "20"),
+				),
+			},
+		},
+	})
+}
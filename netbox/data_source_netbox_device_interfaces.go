@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"regexp"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
@@ -39,6 +38,7 @@ func dataSourceNetboxDeviceInterfaces() *schema.Resource {
 				Optional:     true,
 				ValidateFunc: validation.StringIsValidRegExp,
 			},
+			"total_count": totalCountSchema,
 			"interfaces": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -136,7 +136,7 @@ func dataSourceNetboxDeviceInterfaces() *schema.Resource {
 }
 
 func dataSourceNetboxDeviceInterfaceRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	params := dcim.NewDcimInterfacesListParams()
 
@@ -161,25 +161,32 @@ func dataSourceNetboxDeviceInterfaceRead(d *schema.ResourceData, m interface{})
 		}
 	}
 
-	res, err := api.Dcim.DcimInterfacesList(params, nil)
+	interfaces, count, err := listAll(params, 0, func(p *dcim.DcimInterfacesListParams) (*listPage[*models.Interface], error) {
+		res, err := api.Dcim.DcimInterfacesList(p, nil)
+		if err != nil {
+			return nil, err
+		}
+		payload := res.GetPayload()
+		return &listPage[*models.Interface]{Count: payload.Count, Next: payload.Next, Results: payload.Results}, nil
+	})
 	if err != nil {
 		return err
 	}
 
-	if *res.GetPayload().Count == int64(0) {
+	if count == int64(0) {
 		return errors.New("no result")
 	}
 
 	var filteredInterfaces []*models.Interface
 	if nameRegex, ok := d.GetOk("name_regex"); ok {
 		r := regexp.MustCompile(nameRegex.(string))
-		for _, dcimInterface := range res.GetPayload().Results {
+		for _, dcimInterface := range interfaces {
 			if r.MatchString(*dcimInterface.Name) {
 				filteredInterfaces = append(filteredInterfaces, dcimInterface)
 			}
 		}
 	} else {
-		filteredInterfaces = res.GetPayload().Results
+		filteredInterfaces = interfaces
 	}
 
 	var s []map[string]interface{}
@@ -226,6 +233,7 @@ func dataSourceNetboxDeviceInterfaceRead(d *schema.ResourceData, m interface{})
 	}
 
 	d.SetId(id.UniqueId())
+	d.Set("total_count", count)
 	return d.Set("interfaces", s)
 }
 
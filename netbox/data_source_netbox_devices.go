@@ -4,12 +4,12 @@
 package netbox
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -20,7 +20,7 @@ import (
 
 func dataSourceNetboxDevices() *schema.Resource {
 	return &schema.Resource{
-		Read:        dataSourceNetboxDevicesRead,
+		ReadContext: dataSourceNetboxDevicesRead,
 		Description: ":meta:subcategory:Data Center Inventory Management (DCIM):",
 		Schema: map[string]*schema.Schema{
 			"filter": {
@@ -48,6 +48,7 @@ func dataSourceNetboxDevices() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"total_count": totalCountSchema,
 			"devices": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -157,10 +158,10 @@ func dataSourceNetboxDevices() *schema.Resource {
 	}
 }
 
-func dataSourceNetboxDevicesRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func dataSourceNetboxDevicesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
-	params := dcim.NewDcimDevicesListParams()
+	params := dcim.NewDcimDevicesListParamsWithContext(ctx)
 
 	if filter, ok := d.GetOk("filter"); ok {
 		var filterParams = filter.(*schema.Set)
@@ -205,31 +206,33 @@ func dataSourceNetboxDevicesRead(d *schema.ResourceData, m interface{}) error {
 				var statusString = v.(string)
 				params.Status = &statusString
 			default:
-				return fmt.Errorf("'%s' is not a supported filter parameter", k)
+				return diag.Errorf("'%s' is not a supported filter parameter", k)
 			}
 		}
 	}
 
-	if limit, ok := d.GetOk("limit"); ok {
-		limitInt := int64(limit.(int))
-		params.Limit = &limitInt
-	}
-
-	res, err := api.Dcim.DcimDevicesList(params, nil)
+	devices, count, err := listAll(params, int64(d.Get("limit").(int)), func(p *dcim.DcimDevicesListParams) (*listPage[*models.DeviceWithConfigContext], error) {
+		res, err := api.Dcim.DcimDevicesList(p, nil)
+		if err != nil {
+			return nil, err
+		}
+		payload := res.GetPayload()
+		return &listPage[*models.DeviceWithConfigContext]{Count: payload.Count, Next: payload.Next, Results: payload.Results}, nil
+	})
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	var filteredDevices []*models.DeviceWithConfigContext
 	if nameRegex, ok := d.GetOk("name_regex"); ok {
 		r := regexp.MustCompile(nameRegex.(string))
-		for _, device := range res.GetPayload().Results {
+		for _, device := range devices {
 			if r.MatchString(*device.Name) {
 				filteredDevices = append(filteredDevices, device)
 			}
 		}
 	} else {
-		filteredDevices = res.GetPayload().Results
+		filteredDevices = devices
 	}
 
 	var s []map[string]interface{}
@@ -292,7 +295,7 @@ func dataSourceNetboxDevicesRead(d *schema.ResourceData, m interface{}) error {
 			mapping["status"] = *device.Status.Value
 		}
 		if device.CustomFields != nil {
-			mapping["custom_fields"] = device.CustomFields
+			mapping["custom_fields"] = getCustomFields(ctx, api, device.CustomFields)
 		}
 		if device.Rack != nil {
 			mapping["rack_id"] = device.Rack.ID
@@ -324,5 +327,6 @@ func dataSourceNetboxDevicesRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	d.SetId(id.UniqueId())
-	return d.Set("devices", s)
+	d.Set("total_count", count)
+	return diag.FromErr(d.Set("devices", s))
 }
@@ -0,0 +1,122 @@
+package netbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// graphqlPath is the path of the GraphQL API. It lives next to the REST API
+// instead of below it, so the base path of the client has to be left.
+const graphqlPath = "/../graphql/"
+
+type graphqlRequest struct {
+	Query     string          `json:"query"`
+	Variables json.RawMessage `json:"variables,omitempty"`
+}
+
+type graphqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []graphqlError  `json:"errors"`
+}
+
+type graphqlError struct {
+	Message string        `json:"message"`
+	Path    []interface{} `json:"path"`
+}
+
+func dataSourceNetboxGraphqlQuery() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNetboxGraphqlQueryRead,
+		Description: `:meta:subcategory:Extras:Runs a query against the [GraphQL API](https://docs.netbox.dev/en/stable/integrations/graphql-api/) of Netbox. This allows fetching related objects, like all interfaces of a site together with their IP addresses and connected peers, in a single request.`,
+		Schema: map[string]*schema.Schema{
+			"query": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "The GraphQL query.",
+			},
+			"variables": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsJSON,
+				Description:  "The variables of the query as a JSON object, e.g. `jsonencode({ site = \"fra1\" })`.",
+			},
+			"data": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The `data` returned by the query as a JSON document. Use `jsondecode` to access its fields.",
+			},
+		},
+	}
+}
+
+func dataSourceNetboxGraphqlQueryRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+
+	request := graphqlRequest{
+		Query: d.Get("query").(string),
+	}
+	if variables, ok := d.GetOk("variables"); ok {
+		request.Variables = json.RawMessage(variables.(string))
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := genericAPIRequest(ctx, api, http.MethodPost, graphqlPath, nil, body)
+	if err != nil {
+		// Invalid queries may be rejected with an error status, but the
+		// response still describes the errors
+		errresp, ok := err.(*genericAPIError)
+		if !ok {
+			return diag.FromErr(err)
+		}
+		var response graphqlResponse
+		if json.Unmarshal(errresp.body, &response) != nil || len(response.Errors) == 0 {
+			return diag.FromErr(err)
+		}
+		return graphqlErrorDiagnostics(response.Errors)
+	}
+
+	var response graphqlResponse
+	if err := json.Unmarshal(res, &response); err != nil {
+		return diag.Errorf("unexpected response from the GraphQL API: %s", err)
+	}
+	if len(response.Errors) > 0 {
+		return graphqlErrorDiagnostics(response.Errors)
+	}
+
+	d.SetId(id.UniqueId())
+	d.Set("data", string(response.Data))
+
+	return nil
+}
+
+func graphqlErrorDiagnostics(errors []graphqlError) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, e := range errors {
+		detail := e.Message
+		if len(e.Path) > 0 {
+			path := make([]string, 0, len(e.Path))
+			for _, elem := range e.Path {
+				path = append(path, fmt.Sprint(elem))
+			}
+			detail = fmt.Sprintf("%s (at %s)", e.Message, strings.Join(path, "."))
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  "GraphQL query returned an error",
+			Detail:   detail,
+		})
+	}
+	return diags
+}
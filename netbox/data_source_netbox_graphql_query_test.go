@@ -0,0 +1,89 @@
+package netbox
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetboxGraphqlQueryRead(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/netbox/graphql/", r.URL.Path)
+		assert.Equal(t, "Token 07b12b765127747e4afd56cb531b7bf9c61f3c30", r.Header.Get("Authorization"))
+
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		if strings.TrimSpace(string(body)) == `{"query":"{ site_list { name } }"}` {
+			fmt.Fprint(w, `{"data": {"site_list": [{"name": "fra1"}]}}`)
+			return
+		}
+		assert.JSONEq(t, `{"query": "query($id: ID!) { site(id: $id) { nme } }", "variables": {"id": 1}}`, string(body))
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"data": null, "errors": [{"message": "Cannot query field 'nme' on type 'SiteType'.", "path": ["site", 0]}]}`)
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: ts.URL + "/netbox",
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+	api := newProviderState(client)
+
+	d := dataSourceNetboxGraphqlQuery().TestResourceData()
+	d.Set("query", "{ site_list { name } }")
+	diags := dataSourceNetboxGraphqlQueryRead(context.Background(), d, api)
+	assert.False(t, diags.HasError())
+	assert.JSONEq(t, `{"site_list": [{"name": "fra1"}]}`, d.Get("data").(string))
+
+	d = dataSourceNetboxGraphqlQuery().TestResourceData()
+	d.Set("query", "query($id: ID!) { site(id: $id) { nme } }")
+	d.Set("variables", `{"id": 1}`)
+	diags = dataSourceNetboxGraphqlQueryRead(context.Background(), d, api)
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, "GraphQL query returned an error", diags[0].Summary)
+		assert.Equal(t, "Cannot query field 'nme' on type 'SiteType'. (at site.0)", diags[0].Detail)
+	}
+}
+
+func TestAccNetboxGraphqlQueryDataSource_basic(t *testing.T) {
+	testSlug := "graphql_ds_basic"
+	testName := testAccGetTestName(testSlug)
+	resource.ParallelTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "netbox_site" "test" {
+  name = "%[1]s"
+}
+
+data "netbox_graphql_query" "test" {
+  query     = "query($name: [String!]) { site_list(filters: {name: {in_list: $name}}) { name } }"
+  variables = jsonencode({ name = [netbox_site.test.name] })
+}`, testName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.netbox_graphql_query.test", "data", fmt.Sprintf(`{"site_list":[{"name":"%s"}]}`, testName)),
+				),
+			},
+			{
+				Config: `
+data "netbox_graphql_query" "test" {
+  query = "{ site_list { nonexistent_field } }"
+}`,
+				ExpectError: regexp.MustCompile("GraphQL query returned an error"),
+			},
+		},
+	})
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"regexp"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/virtualization"
 	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
@@ -41,6 +40,7 @@ func dataSourceNetboxInterfaces() *schema.Resource {
 				Default:          0,
 				Description:      "The limit of objects to return from the API lookup.",
 			},
+			"total_count": totalCountSchema,
 			"name_regex": {
 				Type:         schema.TypeString,
 				Optional:     true,
@@ -143,12 +143,10 @@ func dataSourceNetboxInterfaces() *schema.Resource {
 }
 
 func dataSourceNetboxInterfaceRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	params := virtualization.NewVirtualizationInterfacesListParams()
 
-	params.Limit = getOptionalInt(d, "limit")
-
 	if filter, ok := d.GetOk("filter"); ok {
 		var filterParams = filter.(*schema.Set)
 		for _, f := range filterParams.List() {
@@ -172,25 +170,32 @@ func dataSourceNetboxInterfaceRead(d *schema.ResourceData, m interface{}) error
 		}
 	}
 
-	res, err := api.Virtualization.VirtualizationInterfacesList(params, nil)
+	interfaces, count, err := listAll(params, int64(d.Get("limit").(int)), func(p *virtualization.VirtualizationInterfacesListParams) (*listPage[*models.VMInterface], error) {
+		res, err := api.Virtualization.VirtualizationInterfacesList(p, nil)
+		if err != nil {
+			return nil, err
+		}
+		payload := res.GetPayload()
+		return &listPage[*models.VMInterface]{Count: payload.Count, Next: payload.Next, Results: payload.Results}, nil
+	})
 	if err != nil {
 		return err
 	}
 
-	if *res.GetPayload().Count == int64(0) {
+	if count == int64(0) {
 		return errors.New("no result")
 	}
 
 	var filteredInterfaces []*models.VMInterface
 	if nameRegex, ok := d.GetOk("name_regex"); ok {
 		r := regexp.MustCompile(nameRegex.(string))
-		for _, vmInterface := range res.GetPayload().Results {
+		for _, vmInterface := range interfaces {
 			if r.MatchString(*vmInterface.Name) {
 				filteredInterfaces = append(filteredInterfaces, vmInterface)
 			}
 		}
 	} else {
-		filteredInterfaces = res.GetPayload().Results
+		filteredInterfaces = interfaces
 	}
 
 	var s []map[string]interface{}
@@ -237,6 +242,7 @@ func dataSourceNetboxInterfaceRead(d *schema.ResourceData, m interface{}) error
 	}
 
 	d.SetId(id.UniqueId())
+	d.Set("total_count", count)
 	return d.Set("interfaces", s)
 }
 
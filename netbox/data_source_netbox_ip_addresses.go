@@ -1,12 +1,11 @@
 package netbox
 
 import (
-	"errors"
-	"fmt"
+	"context"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -14,7 +13,7 @@ import (
 
 func dataSourceNetboxIPAddresses() *schema.Resource {
 	return &schema.Resource{
-		Read:        dataSourceNetboxIPAddressesRead,
+		ReadContext: dataSourceNetboxIPAddressesRead,
 		Description: `:meta:subcategory:IP Address Management (IPAM):`,
 		Schema: map[string]*schema.Schema{
 			"filter": {
@@ -37,8 +36,9 @@ func dataSourceNetboxIPAddresses() *schema.Resource {
 				Type:             schema.TypeInt,
 				Optional:         true,
 				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
-				Default:          1000,
+				Default:          0,
 			},
+			"total_count": totalCountSchema,
 			"ip_addresses": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -136,14 +136,10 @@ func dataSourceNetboxIPAddresses() *schema.Resource {
 	}
 }
 
-func dataSourceNetboxIPAddressesRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func dataSourceNetboxIPAddressesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
-	params := ipam.NewIpamIPAddressesListParams()
-
-	if limitValue, ok := d.GetOk("limit"); ok {
-		params.Limit = int64ToPtr(int64(limitValue.(int)))
-	}
+	params := ipam.NewIpamIPAddressesListParamsWithContext(ctx)
 
 	if filter, ok := d.GetOk("filter"); ok {
 		var filterParams = filter.(*schema.Set)
@@ -177,22 +173,27 @@ func dataSourceNetboxIPAddressesRead(d *schema.ResourceData, m interface{}) erro
 				tags = append(tags, vString)
 				params.Tag = tags
 			default:
-				return fmt.Errorf("'%s' is not a supported filter parameter", k)
+				return diag.Errorf("'%s' is not a supported filter parameter", k)
 			}
 		}
 	}
 
-	res, err := api.Ipam.IpamIPAddressesList(params, nil)
+	filteredIPAddresses, count, err := listAll(params, int64(d.Get("limit").(int)), func(p *ipam.IpamIPAddressesListParams) (*listPage[*models.IPAddress], error) {
+		res, err := api.Ipam.IpamIPAddressesList(p, nil)
+		if err != nil {
+			return nil, err
+		}
+		payload := res.GetPayload()
+		return &listPage[*models.IPAddress]{Count: payload.Count, Next: payload.Next, Results: payload.Results}, nil
+	})
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	if *res.GetPayload().Count == int64(0) {
-		return errors.New("no result")
+	if count == int64(0) {
+		return diag.Errorf("no result")
 	}
 
-	filteredIPAddresses := res.GetPayload().Results
-
 	var s []map[string]interface{}
 	for _, v := range filteredIPAddresses {
 		var mapping = make(map[string]interface{})
@@ -201,7 +202,7 @@ func dataSourceNetboxIPAddressesRead(d *schema.ResourceData, m interface{}) erro
 		mapping["description"] = v.Description
 		mapping["created"] = v.Created.String()
 		mapping["last_updated"] = v.LastUpdated.String()
-		mapping["custom_fields"] = v.CustomFields
+		mapping["custom_fields"] = getCustomFields(ctx, api, v.CustomFields)
 
 		mapping["ip_address"] = v.Address
 		mapping["address_family"] = v.Family.Label
@@ -226,7 +227,8 @@ func dataSourceNetboxIPAddressesRead(d *schema.ResourceData, m interface{}) erro
 	}
 
 	d.SetId(id.UniqueId())
-	return d.Set("ip_addresses", s)
+	d.Set("total_count", count)
+	return diag.FromErr(d.Set("ip_addresses", s))
 }
 
 func flattenTenant(tenant *models.NestedTenant) []map[string]interface{} {
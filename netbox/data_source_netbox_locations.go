@@ -4,8 +4,8 @@ import (
 	"fmt"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
+	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -50,6 +50,7 @@ func dataSourceNetboxLocations() *schema.Resource {
 				Default:          0,
 				Description:      "The limit of objects to return from the API lookup.",
 			},
+			"total_count": totalCountSchema,
 			"locations": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -95,13 +96,9 @@ func dataSourceNetboxLocations() *schema.Resource {
 }
 
 func dataSourceNetboxLocationsRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 	params := dcim.NewDcimLocationsListParams()
 
-	if limitValue, ok := d.GetOk("limit"); ok {
-		params.Limit = int64ToPtr(int64(limitValue.(int)))
-	}
-
 	if filter, ok := d.GetOk("filter"); ok {
 		var filterParams = filter.(*schema.Set)
 		for _, f := range filterParams.List() {
@@ -137,14 +134,19 @@ func dataSourceNetboxLocationsRead(d *schema.ResourceData, m interface{}) error
 			params.Tag = append(params.Tag, tagV)
 		}
 	}
-	res, err := api.Dcim.DcimLocationsList(params, nil)
+	filteredLocations, count, err := listAll(params, int64(d.Get("limit").(int)), func(p *dcim.DcimLocationsListParams) (*listPage[*models.Location], error) {
+		res, err := api.Dcim.DcimLocationsList(p, nil)
+		if err != nil {
+			return nil, err
+		}
+		payload := res.GetPayload()
+		return &listPage[*models.Location]{Count: payload.Count, Next: payload.Next, Results: payload.Results}, nil
+	})
 
 	if err != nil {
 		return err
 	}
 
-	filteredLocations := res.GetPayload().Results
-
 	var s []map[string]any
 	for _, v := range filteredLocations {
 		var mapping = make(map[string]any)
@@ -171,5 +173,6 @@ func dataSourceNetboxLocationsRead(d *schema.ResourceData, m interface{}) error
 	}
 
 	d.SetId(id.UniqueId())
+	d.Set("total_count", count)
 	return d.Set("locations", s)
 }
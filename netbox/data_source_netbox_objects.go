@@ -0,0 +1,109 @@
+package netbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceNetboxObjects() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNetboxObjectsRead,
+		Description: `:meta:subcategory:Extras:Lists arbitrary objects through the Netbox REST API. This can be used to filter on any field Netbox can filter on, and for object types that have no dedicated data source in this provider, including the object types of Netbox plugins.`,
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "The API path of the object type, relative to `/api/`, e.g. `dcim/devices` or `plugins/dns/zones`.",
+			},
+			"filter": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Query parameters passed to Netbox as they are. Lookup expressions like `name__ic` or `vid__gte` are supported. Filters with the same name are combined, which Netbox usually treats as a logical OR.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringNotInSlice([]string{"limit", "offset"}, false),
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"limit": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
+				Description:      "The maximum number of objects to return. By default, all matching objects are returned.",
+			},
+			"total_count": totalCountSchema,
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "The IDs of the returned objects.",
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The returned objects as a JSON list, as returned by Netbox. Use `jsondecode` to access their fields.",
+			},
+		},
+	}
+}
+
+func dataSourceNetboxObjectsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+
+	query := url.Values{}
+	if filter, ok := d.GetOk("filter"); ok {
+		for _, f := range filter.(*schema.Set).List() {
+			k := f.(map[string]interface{})["name"].(string)
+			v := f.(map[string]interface{})["value"].(string)
+			query.Add(k, v)
+		}
+	}
+
+	path := genericAPIPath(d.Get("path").(string))
+	objects, count, err := genericListAll(ctx, api, path, query, int64(d.Get("limit").(int)))
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
+	}
+
+	ids := make([]int64, 0, len(objects))
+	for _, object := range objects {
+		var o struct {
+			ID *int64 `json:"id"`
+		}
+		if err := json.Unmarshal(object, &o); err != nil || o.ID == nil {
+			return diag.FromErr(fmt.Errorf("unexpected object without an ID returned by %s: %s", path, object))
+		}
+		ids = append(ids, *o.ID)
+	}
+
+	if objects == nil {
+		objects = []json.RawMessage{}
+	}
+	objectsJSON, err := json.Marshal(objects)
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
+	}
+
+	d.SetId(id.UniqueId())
+	d.Set("total_count", count)
+	d.Set("ids", ids)
+	d.Set("json", string(objectsJSON))
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package netbox
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNetboxObjectsDataSource_basic(t *testing.T) {
+	testSlug := "objects_ds_basic"
+	testName := testAccGetTestName(testSlug)
+	resource.ParallelTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "netbox_manufacturer" "test_1" {
+  name = "%[1]s_1"
+}
+
+resource "netbox_manufacturer" "test_2" {
+  name = "%[1]s_2"
+}
+
+resource "netbox_manufacturer" "test_3" {
+  name = "other_%[1]s"
+}
+
+data "netbox_objects" "test" {
+  depends_on = [netbox_manufacturer.test_1, netbox_manufacturer.test_2, netbox_manufacturer.test_3]
+
+  path = "dcim/manufacturers"
+  filter {
+    name  = "name__isw"
+    value = "%[1]s"
+  }
+  filter {
+    name  = "name__n"
+    value = "%[1]s_2"
+  }
+}`, testName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.netbox_objects.test", "total_count", "1"),
+					resource.TestCheckResourceAttr("data.netbox_objects.test", "ids.#", "1"),
+					resource.TestCheckResourceAttrPair("data.netbox_objects.test", "ids.0", "netbox_manufacturer.test_1", "id"),
+					resource.TestCheckResourceAttrWith("data.netbox_objects.test", "json", func(value string) error {
+						if value == "" || value == "[]" {
+							return fmt.Errorf("expected JSON list of objects, got %q", value)
+						}
+						return nil
+					}),
+				),
+			},
+		},
+	})
+}
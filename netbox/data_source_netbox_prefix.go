@@ -1,18 +1,18 @@
 package netbox
 
 import (
-	"errors"
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataSourceNetboxPrefix() *schema.Resource {
 	return &schema.Resource{
-		Read:        dataSourceNetboxPrefixRead,
+		ReadContext: dataSourceNetboxPrefixRead,
 		Description: `:meta:subcategory:IP Address Management (IPAM):`,
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -105,10 +105,10 @@ for more information on available lookup expressions.`,
 	}
 }
 
-func dataSourceNetboxPrefixRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func dataSourceNetboxPrefixRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
-	params := ipam.NewIpamPrefixesListParams()
+	params := ipam.NewIpamPrefixesListParamsWithContext(ctx)
 
 	limit := int64(2) // Limit of 2 is enough
 	params.Limit = &limit
@@ -172,14 +172,14 @@ func dataSourceNetboxPrefixRead(d *schema.ResourceData, m interface{}) error {
 
 	res, err := api.Ipam.IpamPrefixesList(params, nil)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if *res.GetPayload().Count > int64(1) {
-		return errors.New("more than prefix returned, specify a more narrow filter")
+		return diag.Errorf("more than prefix returned, specify a more narrow filter")
 	}
 	if *res.GetPayload().Count == int64(0) {
-		return errors.New("no prefix found matching filter")
+		return diag.Errorf("no prefix found matching filter")
 	}
 
 	result := res.GetPayload().Results[0]
@@ -191,7 +191,7 @@ func dataSourceNetboxPrefixRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("family", int(*result.Family.Value))
 	d.Set("tags", getTagListFromNestedTagList(result.Tags))
 
-	cf := getCustomFields(result.CustomFields)
+	cf := getCustomFields(ctx, api, result.CustomFields)
 	if cf != nil {
 		d.Set(customFieldsKey, cf)
 	}
@@ -4,8 +4,8 @@ import (
 	"fmt"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
+	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -42,6 +42,7 @@ func dataSourceNetboxPrefixes() *schema.Resource {
 				Default:          0,
 				Description:      "The limit of objects to return from the API lookup.",
 			},
+			"total_count": totalCountSchema,
 			"prefixes": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -92,14 +93,10 @@ func dataSourceNetboxPrefixes() *schema.Resource {
 }
 
 func dataSourceNetboxPrefixesRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	params := ipam.NewIpamPrefixesListParams()
 
-	if limitValue, ok := d.GetOk("limit"); ok {
-		params.Limit = int64ToPtr(int64(limitValue.(int)))
-	}
-
 	if filter, ok := d.GetOk("filter"); ok {
 		var filterParams = filter.(*schema.Set)
 		for _, f := range filterParams.List() {
@@ -135,13 +132,18 @@ func dataSourceNetboxPrefixesRead(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
-	res, err := api.Ipam.IpamPrefixesList(params, nil)
+	filteredPrefixes, count, err := listAll(params, int64(d.Get("limit").(int)), func(p *ipam.IpamPrefixesListParams) (*listPage[*models.Prefix], error) {
+		res, err := api.Ipam.IpamPrefixesList(p, nil)
+		if err != nil {
+			return nil, err
+		}
+		payload := res.GetPayload()
+		return &listPage[*models.Prefix]{Count: payload.Count, Next: payload.Next, Results: payload.Results}, nil
+	})
 	if err != nil {
 		return err
 	}
 
-	filteredPrefixes := res.GetPayload().Results
-
 	var s []map[string]interface{}
 	for _, v := range filteredPrefixes {
 		var mapping = make(map[string]interface{})
@@ -169,5 +171,6 @@ func dataSourceNetboxPrefixesRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	d.SetId(id.UniqueId())
+	d.Set("total_count", count)
 	return d.Set("prefixes", s)
 }
@@ -1,11 +1,11 @@
 package netbox
 
 import (
-	"errors"
-	"fmt"
+	"context"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
+	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -13,7 +13,7 @@ import (
 
 func dataSourceNetboxRacks() *schema.Resource {
 	return &schema.Resource{
-		Read:        dataSourceNetboxRacksRead,
+		ReadContext: dataSourceNetboxRacksRead,
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):`,
 		Schema: map[string]*schema.Schema{
 			"filter": {
@@ -38,6 +38,7 @@ func dataSourceNetboxRacks() *schema.Resource {
 				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
 				Default:          0,
 			},
+			"total_count": totalCountSchema,
 			"racks": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -147,14 +148,10 @@ func dataSourceNetboxRacks() *schema.Resource {
 	}
 }
 
-func dataSourceNetboxRacksRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func dataSourceNetboxRacksRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
-	params := dcim.NewDcimRacksListParams()
-
-	if limitValue, ok := d.GetOk("limit"); ok {
-		params.Limit = int64ToPtr(int64(limitValue.(int)))
-	}
+	params := dcim.NewDcimRacksListParamsWithContext(ctx)
 
 	if filter, ok := d.GetOk("filter"); ok {
 		var filterParams = filter.(*schema.Set)
@@ -214,22 +211,27 @@ func dataSourceNetboxRacksRead(d *schema.ResourceData, m interface{}) error {
 			case "width":
 				params.Width = &vString
 			default:
-				return fmt.Errorf("'%s' is not a supported filter parameter", k)
+				return diag.Errorf("'%s' is not a supported filter parameter", k)
 			}
 		}
 	}
 
-	res, err := api.Dcim.DcimRacksList(params, nil)
+	filteredRacks, count, err := listAll(params, int64(d.Get("limit").(int)), func(p *dcim.DcimRacksListParams) (*listPage[*models.Rack], error) {
+		res, err := api.Dcim.DcimRacksList(p, nil)
+		if err != nil {
+			return nil, err
+		}
+		payload := res.GetPayload()
+		return &listPage[*models.Rack]{Count: payload.Count, Next: payload.Next, Results: payload.Results}, nil
+	})
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	if *res.GetPayload().Count == int64(0) {
-		return errors.New("no result")
+	if count == int64(0) {
+		return diag.Errorf("no result")
 	}
 
-	filteredRacks := res.GetPayload().Results
-
 	var s []map[string]interface{}
 	for _, v := range filteredRacks {
 		var mapping = make(map[string]interface{})
@@ -271,11 +273,12 @@ func dataSourceNetboxRacksRead(d *schema.ResourceData, m interface{}) error {
 		mapping["mounting_depth"] = v.MountingDepth
 		mapping["description"] = v.Description
 		mapping["comments"] = v.Comments
-		mapping["custom_fields"] = getCustomFields(v.CustomFields)
+		mapping["custom_fields"] = getCustomFields(ctx, api, v.CustomFields)
 
 		s = append(s, mapping)
 	}
 
 	d.SetId(id.UniqueId())
-	return d.Set("racks", s)
+	d.Set("total_count", count)
+	return diag.FromErr(d.Set("racks", s))
 }
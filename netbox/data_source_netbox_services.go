@@ -1,18 +1,18 @@
 package netbox
 
 import (
-	"errors"
-	"fmt"
+	"context"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
+	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceNetboxServices() *schema.Resource {
 	return &schema.Resource{
-		Read:        dataSourceNetboxServicesListRead,
+		ReadContext: dataSourceNetboxServicesListRead,
 		Description: `:meta:subcategory:IP Address Management (IPAM):From the [official documentation](https://docs.netbox.dev/en/stable/features/services/#services)`,
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -39,6 +39,7 @@ func dataSourceNetboxServices() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"total_count": totalCountSchema,
 			"services": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -115,19 +116,14 @@ func dataSourceNetboxServices() *schema.Resource {
 	}
 }
 
-func dataSourceNetboxServicesListRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func dataSourceNetboxServicesListRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
-	params := ipam.NewIpamServicesListParams()
+	params := ipam.NewIpamServicesListParamsWithContext(ctx)
 
 	name := d.Get("name").(string)
 	params.Name = &name
 
-	if limit, ok := d.GetOk("limit"); ok {
-		limitInt := int64(limit.(int))
-		params.Limit = &limitInt
-	}
-	
 	if filter, ok := d.GetOk("filter"); ok {
 		var filterParams = filter.(*schema.Set)
 		var tags []string
@@ -142,12 +138,19 @@ func dataSourceNetboxServicesListRead(d *schema.ResourceData, m interface{}) err
 				tags = append(tags, vString)
 				params.Tag = tags
 			default:
-				return fmt.Errorf("'%s' is not a supported filter parameter", k)
+				return diag.Errorf("'%s' is not a supported filter parameter", k)
 			}
 		}
 	}
 
-	res, err := api.Ipam.IpamServicesList(params, nil)
+	results, count, err := listAll(params, int64(d.Get("limit").(int)), func(p *ipam.IpamServicesListParams) (*listPage[*models.Service], error) {
+		res, err := api.Ipam.IpamServicesList(p, nil)
+		if err != nil {
+			return nil, err
+		}
+		payload := res.GetPayload()
+		return &listPage[*models.Service]{Count: payload.Count, Next: payload.Next, Results: payload.Results}, nil
+	})
 	if err != nil {
 		if errresp, ok := err.(*ipam.IpamServicesListDefault); ok {
 			errorcode := errresp.Code()
@@ -157,15 +160,15 @@ func dataSourceNetboxServicesListRead(d *schema.ResourceData, m interface{}) err
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
-	if *res.GetPayload().Count == int64(0) {
-		return errors.New("no service found matching filter")
+	if count == int64(0) {
+		return diag.Errorf("no service found matching filter")
 	}
 
 	var services []map[string]interface{}
-	for _, v := range res.GetPayload().Results {
+	for _, v := range results {
 		var s = make(map[string]interface{})
 
 		s["id"] = v.ID
@@ -200,7 +203,7 @@ func dataSourceNetboxServicesListRead(d *schema.ResourceData, m interface{}) err
 		}
 		s["ip_addresses"] = ip_addresses
 	
-		cf := getCustomFields(v.CustomFields)
+		cf := getCustomFields(ctx, api, v.CustomFields)
 		if cf != nil {
 			s[customFieldsKey] = cf
 		}
@@ -209,5 +212,6 @@ func dataSourceNetboxServicesListRead(d *schema.ResourceData, m interface{}) err
 	}
 
 	d.SetId(id.UniqueId())
-	return d.Set("services", services)
+	d.Set("total_count", count)
+	return diag.FromErr(d.Set("services", services))
 }
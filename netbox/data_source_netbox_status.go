@@ -0,0 +1,95 @@
+package netbox
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/fbreckle/go-netbox/netbox/client/status"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceNetboxStatus() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNetboxStatusRead,
+		Description: `:meta:subcategory:Extras:Returns the status of Netbox, including its version and the installed plugins. This allows modules to depend on the availability of a plugin, or to fail early if a required plugin is missing.`,
+		Schema: map[string]*schema.Schema{
+			"netbox_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"django_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"python_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"installed_apps": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The installed Django apps, mapped to their versions.",
+			},
+			"plugins": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The installed Netbox plugins, mapped to their versions.",
+			},
+			"rq_workers_running": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of running RQ workers, which run background jobs.",
+			},
+		},
+	}
+}
+
+func dataSourceNetboxStatusRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+
+	params := status.NewStatusListParamsWithContext(ctx)
+	res, err := api.Status.StatusList(params, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	payload, ok := res.GetPayload().(map[string]interface{})
+	if !ok {
+		return diag.Errorf("unexpected status of Netbox: %v", res.GetPayload())
+	}
+
+	d.SetId("status")
+	d.Set("netbox_version", statusString(payload["netbox-version"]))
+	d.Set("django_version", statusString(payload["django-version"]))
+	d.Set("python_version", statusString(payload["python-version"]))
+	d.Set("installed_apps", statusVersions(payload["installed-apps"]))
+	d.Set("plugins", statusVersions(payload["plugins"]))
+
+	workers, _ := strconv.Atoi(statusString(payload["rq-workers-running"]))
+	d.Set("rq_workers_running", workers)
+
+	return nil
+}
+
+// statusString returns a value of the status as a string. Versions of
+// installed apps are null if the app has no version.
+func statusString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
+
+func statusVersions(value interface{}) map[string]interface{} {
+	versions := make(map[string]interface{})
+	if apps, ok := value.(map[string]interface{}); ok {
+		for name, version := range apps {
+			versions[name] = statusString(version)
+		}
+	}
+	return versions
+}
@@ -0,0 +1,26 @@
+package netbox
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNetboxStatusDataSource_basic(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "netbox_status" "test" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.netbox_status.test", "netbox_version", regexp.MustCompile(`^\d+\.\d+\.\d+`)),
+					resource.TestMatchResourceAttr("data.netbox_status.test", "django_version", regexp.MustCompile(`^\d+\.\d+`)),
+					resource.TestMatchResourceAttr("data.netbox_status.test", "python_version", regexp.MustCompile(`^3\.\d+`)),
+					resource.TestCheckResourceAttrSet("data.netbox_status.test", "installed_apps.django_filters"),
+					resource.TestCheckResourceAttrSet("data.netbox_status.test", "rq_workers_running"),
+				),
+			},
+		},
+	})
+}
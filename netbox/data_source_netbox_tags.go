@@ -4,8 +4,8 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/extras"
+	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -38,6 +38,7 @@ func dataSourceNetboxTags() *schema.Resource {
 				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
 				Default:          0,
 			},
+			"total_count": totalCountSchema,
 			"tags": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -71,14 +72,10 @@ func dataSourceNetboxTags() *schema.Resource {
 }
 
 func dataSourceNetboxTagsRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	params := extras.NewExtrasTagsListParams()
 
-	if limitValue, ok := d.GetOk("limit"); ok {
-		params.Limit = int64ToPtr(int64(limitValue.(int)))
-	}
-
 	if filter, ok := d.GetOk("filter"); ok {
 		filterParams := filter.(*schema.Set)
 		for _, f := range filterParams.List() {
@@ -126,17 +123,23 @@ func dataSourceNetboxTagsRead(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
-	res, err := api.Extras.ExtrasTagsList(params, nil)
+	results, count, err := listAll(params, int64(d.Get("limit").(int)), func(p *extras.ExtrasTagsListParams) (*listPage[*models.Tag], error) {
+		res, err := api.Extras.ExtrasTagsList(p, nil)
+		if err != nil {
+			return nil, err
+		}
+		payload := res.GetPayload()
+		return &listPage[*models.Tag]{Count: payload.Count, Next: payload.Next, Results: payload.Results}, nil
+	})
 	if err != nil {
 		return err
 	}
 
-	if *res.GetPayload().Count == int64(0) {
+	if count == int64(0) {
 		return errors.New("no result")
 	}
 
 	var s []map[string]interface{}
-	results := res.GetPayload().Results
 	for _, v := range results {
 		mapping := make(map[string]interface{})
 
@@ -150,5 +153,6 @@ func dataSourceNetboxTagsRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	d.SetId(id.UniqueId())
+	d.Set("total_count", count)
 	return d.Set("tags", s)
 }
@@ -1,12 +1,11 @@
 package netbox
 
 import (
-	"errors"
-	"fmt"
+	"context"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/tenancy"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -14,7 +13,7 @@ import (
 
 func dataSourceNetboxTenants() *schema.Resource {
 	return &schema.Resource{
-		Read:        dataSourceNetboxTenantsRead,
+		ReadContext: dataSourceNetboxTenantsRead,
 		Description: `:meta:subcategory:Tenancy:`,
 		Schema: map[string]*schema.Schema{
 			"filter": {
@@ -37,8 +36,9 @@ func dataSourceNetboxTenants() *schema.Resource {
 				Type:             schema.TypeInt,
 				Optional:         true,
 				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
-				Default:          1000,
+				Default:          0,
 			},
+			"total_count": totalCountSchema,
 			"tenants": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -148,14 +148,10 @@ func dataSourceNetboxTenants() *schema.Resource {
 	}
 }
 
-func dataSourceNetboxTenantsRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func dataSourceNetboxTenantsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
-	params := tenancy.NewTenancyTenantsListParams()
-
-	if limitValue, ok := d.GetOk("limit"); ok {
-		params.Limit = int64ToPtr(int64(limitValue.(int)))
-	}
+	params := tenancy.NewTenancyTenantsListParamsWithContext(ctx)
 
 	if filter, ok := d.GetOk("filter"); ok {
 		var filterParams = filter.(*schema.Set)
@@ -169,22 +165,27 @@ func dataSourceNetboxTenantsRead(d *schema.ResourceData, m interface{}) error {
 			case "slug":
 				params.Slug = &vString
 			default:
-				return fmt.Errorf("'%s' is not a supported filter parameter", k)
+				return diag.Errorf("'%s' is not a supported filter parameter", k)
 			}
 		}
 	}
 
-	res, err := api.Tenancy.TenancyTenantsList(params, nil)
+	filteredTenants, count, err := listAll(params, int64(d.Get("limit").(int)), func(p *tenancy.TenancyTenantsListParams) (*listPage[*models.Tenant], error) {
+		res, err := api.Tenancy.TenancyTenantsList(p, nil)
+		if err != nil {
+			return nil, err
+		}
+		payload := res.GetPayload()
+		return &listPage[*models.Tenant]{Count: payload.Count, Next: payload.Next, Results: payload.Results}, nil
+	})
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	if *res.GetPayload().Count == int64(0) {
-		return errors.New("no result")
+	if count == int64(0) {
+		return diag.Errorf("no result")
 	}
 
-	filteredTenants := res.GetPayload().Results
-
 	var s []map[string]interface{}
 	for _, v := range filteredTenants {
 		var mapping = make(map[string]interface{})
@@ -196,7 +197,7 @@ func dataSourceNetboxTenantsRead(d *schema.ResourceData, m interface{}) error {
 		mapping["created"] = v.Created.String()
 		mapping["last_updated"] = v.LastUpdated.String()
 		mapping["comments"] = v.Comments
-		mapping["custom_fields"] = v.CustomFields
+		mapping["custom_fields"] = getCustomFields(ctx, api, v.CustomFields)
 
 		mapping["site_count"] = v.SiteCount
 		mapping["rack_count"] = v.RackCount
@@ -214,7 +215,8 @@ func dataSourceNetboxTenantsRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	d.SetId(id.UniqueId())
-	return d.Set("tenants", s)
+	d.Set("total_count", count)
+	return diag.FromErr(d.Set("tenants", s))
 }
 
 func flattenTenantGroup(group *models.NestedTenantGroup) []map[string]interface{} {
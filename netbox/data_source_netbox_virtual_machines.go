@@ -1,14 +1,13 @@
 package netbox
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
-	"fmt"
 	"regexp"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/virtualization"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -16,7 +15,7 @@ import (
 
 func dataSourceNetboxVirtualMachine() *schema.Resource {
 	return &schema.Resource{
-		Read:        dataSourceNetboxVirtualMachineRead,
+		ReadContext: dataSourceNetboxVirtualMachineRead,
 		Description: `:meta:subcategory:Virtualization:`,
 		Schema: map[string]*schema.Schema{
 			"filter": {
@@ -44,6 +43,7 @@ func dataSourceNetboxVirtualMachine() *schema.Resource {
 				Type:     schema.TypeInt,
 				Optional: true,
 			},
+			"total_count": totalCountSchema,
 			"vms": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -151,10 +151,10 @@ func dataSourceNetboxVirtualMachine() *schema.Resource {
 	}
 }
 
-func dataSourceNetboxVirtualMachineRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func dataSourceNetboxVirtualMachineRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
-	params := virtualization.NewVirtualizationVirtualMachinesListParams()
+	params := virtualization.NewVirtualizationVirtualMachinesListParamsWithContext(ctx)
 
 	if filter, ok := d.GetOk("filter"); ok {
 		var filterParams = filter.(*schema.Set)
@@ -188,35 +188,37 @@ func dataSourceNetboxVirtualMachineRead(d *schema.ResourceData, m interface{}) e
 			case "status":
 				params.Status = &vString
 			default:
-				return fmt.Errorf("'%s' is not a supported filter parameter", k)
+				return diag.Errorf("'%s' is not a supported filter parameter", k)
 			}
 		}
 	}
 
-	if limit, ok := d.GetOk("limit"); ok {
-		limitInt := int64(limit.(int))
-		params.Limit = &limitInt
-	}
-
-	res, err := api.Virtualization.VirtualizationVirtualMachinesList(params, nil)
+	vms, count, err := listAll(params, int64(d.Get("limit").(int)), func(p *virtualization.VirtualizationVirtualMachinesListParams) (*listPage[*models.VirtualMachineWithConfigContext], error) {
+		res, err := api.Virtualization.VirtualizationVirtualMachinesList(p, nil)
+		if err != nil {
+			return nil, err
+		}
+		payload := res.GetPayload()
+		return &listPage[*models.VirtualMachineWithConfigContext]{Count: payload.Count, Next: payload.Next, Results: payload.Results}, nil
+	})
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	if *res.GetPayload().Count == int64(0) {
-		return errors.New("no result")
+	if count == int64(0) {
+		return diag.Errorf("no result")
 	}
 
 	var filteredVms []*models.VirtualMachineWithConfigContext
 	if nameRegex, ok := d.GetOk("name_regex"); ok {
 		r := regexp.MustCompile(nameRegex.(string))
-		for _, vm := range res.GetPayload().Results {
+		for _, vm := range vms {
 			if r.MatchString(*vm.Name) {
 				filteredVms = append(filteredVms, vm)
 			}
 		}
 	} else {
-		filteredVms = res.GetPayload().Results
+		filteredVms = vms
 	}
 
 	var s []map[string]interface{}
@@ -241,7 +243,7 @@ func dataSourceNetboxVirtualMachineRead(d *schema.ResourceData, m interface{}) e
 			}
 		}
 		if v.CustomFields != nil {
-			mapping["custom_fields"] = v.CustomFields
+			mapping["custom_fields"] = getCustomFields(ctx, api, v.CustomFields)
 		}
 		if v.Disk != nil {
 			mapping["disk_size_mb"] = *v.Disk
@@ -299,5 +301,6 @@ func dataSourceNetboxVirtualMachineRead(d *schema.ResourceData, m interface{}) e
 	}
 
 	d.SetId(id.UniqueId())
-	return d.Set("vms", s)
+	d.Set("total_count", count)
+	return diag.FromErr(d.Set("vms", s))
 }
@@ -4,8 +4,8 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
+	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -38,6 +38,7 @@ func dataSourceNetboxVlans() *schema.Resource {
 				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(1)),
 				Default:          0,
 			},
+			"total_count": totalCountSchema,
 			"vlans": {
 				Type:     schema.TypeList,
 				Computed: true,
@@ -90,14 +91,10 @@ func dataSourceNetboxVlans() *schema.Resource {
 }
 
 func dataSourceNetboxVlansRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	params := ipam.NewIpamVlansListParams()
 
-	if limitValue, ok := d.GetOk("limit"); ok {
-		params.Limit = int64ToPtr(int64(limitValue.(int)))
-	}
-
 	if filter, ok := d.GetOk("filter"); ok {
 		var filterParams = filter.(*schema.Set)
 		var tags []string
@@ -157,17 +154,22 @@ func dataSourceNetboxVlansRead(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
-	res, err := api.Ipam.IpamVlansList(params, nil)
+	filteredVlans, count, err := listAll(params, int64(d.Get("limit").(int)), func(p *ipam.IpamVlansListParams) (*listPage[*models.VLAN], error) {
+		res, err := api.Ipam.IpamVlansList(p, nil)
+		if err != nil {
+			return nil, err
+		}
+		payload := res.GetPayload()
+		return &listPage[*models.VLAN]{Count: payload.Count, Next: payload.Next, Results: payload.Results}, nil
+	})
 	if err != nil {
 		return err
 	}
 
-	if *res.GetPayload().Count == int64(0) {
+	if count == int64(0) {
 		return errors.New("no result")
 	}
 
-	filteredVlans := res.GetPayload().Results
-
 	var s []map[string]interface{}
 	for _, v := range filteredVlans {
 		var mapping = make(map[string]interface{})
@@ -200,5 +202,6 @@ func dataSourceNetboxVlansRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	d.SetId(id.UniqueId())
+	d.Set("total_count", count)
 	return d.Set("vlans", s)
 }
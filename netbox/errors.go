@@ -0,0 +1,425 @@
+package netbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	netboxclient "github.com/fbreckle/go-netbox/netbox/client"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/exp/slices"
+)
+
+// netboxAPIError is implemented by the error responses of the generated
+// client as well as by genericAPIError.
+type netboxAPIError interface {
+	error
+	Code() int
+	GetPayload() interface{}
+}
+
+// GetPayload returns the decoded body of the error response, or the raw body
+// if it is not valid JSON.
+func (e *genericAPIError) GetPayload() interface{} {
+	payload, err := decodeJSON(e.body)
+	if err != nil {
+		return string(e.body)
+	}
+	return payload
+}
+
+// apiErrorOperationRegexp matches the operation at the start of the messages
+// of API errors, e.g. `[POST /dcim/devices/][400]`.
+var apiErrorOperationRegexp = regexp.MustCompile(`^\[([A-Z]+) /([^\]]*)\]\[\d+\]`)
+
+// apiErrorCustomFieldRegexp matches the name of the custom field in the
+// validation errors of custom field data.
+var apiErrorCustomFieldRegexp = regexp.MustCompile(`field (?:name )?'([^']+)'`)
+
+// nonFieldErrorKeys are the keys Netbox uses for validation errors that do
+// not belong to a single field.
+var nonFieldErrorKeys = []string{"non_field_errors", "__all__", "detail"}
+
+// apiErrorDiagnostics translates an error returned by the Netbox API into
+// diagnostics. Validation errors are split up by field, with the attribute
+// path pointing at the attribute of the resource the field is set by.
+// Permission errors and missing objects name the object type and ID. Other
+// errors are returned as they are.
+func apiErrorDiagnostics(d *schema.ResourceData, err error) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+
+	var readOnlyErr *readOnlyError
+	if errors.As(err, &readOnlyErr) {
+		return readOnlyDiagnostics(d, readOnlyErr)
+	}
+
+	var exhaustedErr *exhaustedError
+	if errors.As(err, &exhaustedErr) {
+		return exhaustedDiagnostics(d, exhaustedErr)
+	}
+
+	var selectorErr *selectorExhaustedError
+	if errors.As(err, &selectorErr) {
+		return selectorExhaustedDiagnostics(selectorErr)
+	}
+
+	var apiErr netboxAPIError
+	if !errors.As(err, &apiErr) {
+		return diag.FromErr(err)
+	}
+
+	method, objectType := "", ""
+	if match := apiErrorOperationRegexp.FindStringSubmatch(apiErr.Error()); match != nil {
+		method = match[1]
+		objectType = apiObjectType(match[2])
+	}
+	object := describeObject(d, objectType)
+
+	switch apiErr.Code() {
+	case http.StatusBadRequest:
+		if diags := validationErrorDiagnostics(d, apiErr.GetPayload()); diags != nil {
+			return diags
+		}
+	case http.StatusForbidden:
+		detail := apiErrorDetail(apiErr.GetPayload())
+		if objectType != "" {
+			detail += fmt.Sprintf("\n\nMake sure the API token used by the provider has the `%s` permission.", apiPermission(method, objectType))
+		}
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Permission denied to %s Netbox %s", apiAction(method), object),
+			Detail:   strings.TrimSpace(detail),
+		}}
+	case http.StatusNotFound:
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Netbox %s not found", object),
+			Detail:   "The object may have been deleted outside of Terraform, or the API token used by the provider may not be allowed to view it.",
+		}}
+	}
+
+	return diag.FromErr(err)
+}
+
+// readOnlyDiagnostics explains that a request was refused because the
+// provider is read-only. Terraform shows the address of the resource along
+// with the diagnostic.
+func readOnlyDiagnostics(d *schema.ResourceData, err *readOnlyError) diag.Diagnostics {
+	path := err.path
+	if _, after, found := strings.Cut(path, netboxclient.DefaultBasePath+"/"); found {
+		path = after
+	}
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Cannot %s Netbox %s, the provider is read-only", apiAction(err.method), describeObject(d, apiObjectType(path))),
+		Detail:   fmt.Sprintf("The `read_only` provider argument is set, so the %s %s request was not sent to Netbox.", err.method, err.path),
+	}}
+}
+
+// exhaustedDiagnostics explains that an allocation failed because the parent
+// object has no objects left to allocate, pointing at the attribute the parent
+// is set by.
+func exhaustedDiagnostics(d *schema.ResourceData, err *exhaustedError) diag.Diagnostics {
+	diagnostic := diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Netbox %s with ID %d is exhausted", err.parentType, err.parentID),
+		Detail:   fmt.Sprintf("The %s %s. Netbox responded: %s\n\nFree up %s in the %s or allocate from a different one.", err.parentType, err.availability(), err.detail, err.childType, err.parentType),
+	}
+	// The parent is set by e.g. `prefix_id`, `parent_prefix_id` if the
+	// allocated objects are of the same type, or `group_id` for a VLAN group
+	words := strings.Fields(strings.ToLower(err.parentType))
+	field := strings.Join(words, "_")
+	attributes := configuredAttributes(d)
+	for _, candidate := range []string{"parent_" + field, field, words[len(words)-1]} {
+		if attribute := attributeForField(attributes, candidate); attribute != "" {
+			diagnostic.AttributePath = cty.GetAttrPath(attribute)
+			break
+		}
+	}
+	return diag.Diagnostics{diagnostic}
+}
+
+// selectorExhaustedDiagnostics explains that none of the prefixes selected by
+// a prefix_selector could be allocated from.
+func selectorExhaustedDiagnostics(err *selectorExhaustedError) diag.Diagnostics {
+	diagnostic := diag.Diagnostic{
+		Severity:      diag.Error,
+		Summary:       "No Netbox prefix matches the prefix_selector",
+		Detail:        "Make sure the prefixes to allocate from match all of the criteria of the prefix_selector.",
+		AttributePath: cty.GetAttrPath("prefix_selector"),
+	}
+	if len(err.candidates) > 0 {
+		diagnostic.Summary = "All Netbox prefixes matching the prefix_selector are exhausted"
+		available := "any"
+		if err.requested > 1 {
+			available = strconv.Itoa(err.requested)
+		}
+		diagnostic.Detail = fmt.Sprintf("None of the matching prefixes has %s available %s. Tried %s.\n\nFree up %s in one of the prefixes or add a prefix matching the prefix_selector.", available, err.childType, strings.Join(err.candidates, ", "), err.childType)
+	}
+	return diag.Diagnostics{diagnostic}
+}
+
+// describeObject names the object a request was sent for by its type and, if
+// known, its ID.
+func describeObject(d *schema.ResourceData, objectType string) string {
+	object := objectType
+	if object == "" {
+		object = "object"
+	}
+	if d != nil && d.Id() != "" {
+		object = fmt.Sprintf("%s with ID %s", object, d.Id())
+	}
+	return object
+}
+
+// validationErrorDiagnostics turns the field-keyed validation errors returned
+// by Netbox into one diagnostic per field. It returns nil if the payload is not
+// made up of validation errors.
+func validationErrorDiagnostics(d *schema.ResourceData, payload interface{}) diag.Diagnostics {
+	fields, ok := payload.(map[string]interface{})
+	if !ok || len(fields) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	attributes := configuredAttributes(d)
+
+	var diags diag.Diagnostics
+	for _, name := range names {
+		messages := apiErrorMessages(fields[name])
+		if len(messages) == 0 {
+			continue
+		}
+
+		if slices.Contains(nonFieldErrorKeys, name) {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  "Netbox rejected the request",
+				Detail:   strings.Join(messages, "\n"),
+			})
+			continue
+		}
+
+		if name == customFieldsKey {
+			for _, message := range messages {
+				diagnostic := diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  "Invalid custom field",
+					Detail:   message,
+				}
+				if match := apiErrorCustomFieldRegexp.FindStringSubmatch(message); match != nil && slices.Contains(attributes, customFieldsKey) {
+					diagnostic.Summary = fmt.Sprintf("Invalid value for custom field %s", match[1])
+					diagnostic.AttributePath = cty.GetAttrPath(customFieldsKey).IndexString(match[1])
+				}
+				diags = append(diags, diagnostic)
+			}
+			continue
+		}
+
+		diagnostic := diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Invalid value for %s", name),
+			Detail:   strings.Join(messages, "\n"),
+		}
+		if attribute := attributeForField(attributes, name); attribute != "" {
+			diagnostic.Summary = fmt.Sprintf("Invalid value for %s", attribute)
+			diagnostic.AttributePath = cty.GetAttrPath(attribute)
+		}
+		diags = append(diags, diagnostic)
+	}
+	return diags
+}
+
+// configuredAttributes returns the names of the top-level attributes of the
+// resource. The type of the raw config is known even if the config itself is
+// not.
+func configuredAttributes(d *schema.ResourceData) []string {
+	if d == nil {
+		return nil
+	}
+	configType := d.GetRawConfig().Type()
+	if !configType.IsObjectType() {
+		return nil
+	}
+
+	var attributes []string
+	for name := range configType.AttributeTypes() {
+		attributes = append(attributes, name)
+	}
+	sort.Strings(attributes)
+	return attributes
+}
+
+// attributeForField finds the attribute a field of a Netbox object is set by.
+// Related objects are usually set by their ID, e.g. `role` by `role_id`, and
+// some fields are prefixed, e.g. `position` by `rack_position`.
+func attributeForField(attributes []string, field string) string {
+	for _, candidate := range []string{field, field + "_id", field + "_ids"} {
+		if slices.Contains(attributes, candidate) {
+			return candidate
+		}
+	}
+	for _, attribute := range attributes {
+		if strings.HasSuffix(attribute, "_"+field) {
+			return attribute
+		}
+	}
+	return ""
+}
+
+// apiErrorMessages flattens the error messages of a single field, which may
+// be nested for fields holding objects or lists.
+func apiErrorMessages(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var messages []string
+		for i, item := range v {
+			nested := apiErrorMessages(item)
+			if _, ok := item.(map[string]interface{}); ok {
+				for j := range nested {
+					nested[j] = fmt.Sprintf("item %d: %s", i, nested[j])
+				}
+			}
+			messages = append(messages, nested...)
+		}
+		return messages
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var messages []string
+		for _, key := range keys {
+			for _, message := range apiErrorMessages(v[key]) {
+				messages = append(messages, fmt.Sprintf("%s: %s", key, message))
+			}
+		}
+		return messages
+	case nil:
+		return nil
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
+
+// apiErrorDetail returns the `detail` message Netbox sends along with most
+// errors, or the whole payload if there is none.
+func apiErrorDetail(payload interface{}) string {
+	if fields, ok := payload.(map[string]interface{}); ok {
+		if detail, ok := fields["detail"].(string); ok {
+			return detail
+		}
+	}
+	if payload == nil {
+		return ""
+	}
+	encoded, _ := json.Marshal(payload)
+	return string(encoded)
+}
+
+// apiObjectType derives the Netbox object type, e.g. `dcim.device`, from the
+// path of an API operation, e.g. `dcim/devices/{id}/`.
+func apiObjectType(path string) string {
+	var parts []string
+	for _, part := range strings.Split(strings.Trim(path, "/"), "/") {
+		if part == "" || strings.HasPrefix(part, "{") || strings.Trim(part, "0123456789") == "" {
+			continue
+		}
+		parts = append(parts, part)
+	}
+	if len(parts) < 2 {
+		return ""
+	}
+	if parts[0] == "plugins" && len(parts) >= 3 {
+		parts = parts[1:]
+	}
+	return parts[0] + "." + singularModelName(parts[1])
+}
+
+// singularModelName turns the plural used in an API path, e.g.
+// `ip-addresses`, into the name of the model, e.g. `ipaddress`.
+func singularModelName(name string) string {
+	name = strings.ReplaceAll(name, "-", "")
+	switch {
+	case strings.HasSuffix(name, "chassis"):
+		return name
+	case strings.HasSuffix(name, "sses"), strings.HasSuffix(name, "xes"), strings.HasSuffix(name, "ches"), strings.HasSuffix(name, "shes"):
+		return strings.TrimSuffix(name, "es")
+	case strings.HasSuffix(name, "ies"):
+		return strings.TrimSuffix(name, "ies") + "y"
+	default:
+		return strings.TrimSuffix(name, "s")
+	}
+}
+
+// apiAction describes what a request with the given method does.
+func apiAction(method string) string {
+	switch method {
+	case http.MethodPost:
+		return "create"
+	case http.MethodPut, http.MethodPatch:
+		return "change"
+	case http.MethodDelete:
+		return "delete"
+	default:
+		return "view"
+	}
+}
+
+// apiPermission returns the Netbox permission required for a request, e.g.
+// `dcim.add_device`.
+func apiPermission(method, objectType string) string {
+	app, model, _ := strings.Cut(objectType, ".")
+	action := apiAction(method)
+	if action == "create" {
+		action = "add"
+	}
+	return fmt.Sprintf("%s.%s_%s", app, action, model)
+}
+
+// withAPIErrorDiagnostics makes the read function of a data source that still
+// returns plain errors translate errors of the Netbox API with
+// apiErrorDiagnostics.
+func withAPIErrorDiagnostics(r *schema.Resource) *schema.Resource {
+	wrap := func(f func(*schema.ResourceData, interface{}) error) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return apiErrorDiagnostics(d, f(d, m))
+		}
+	}
+
+	if r.Create != nil {
+		r.CreateContext = wrap(r.Create)
+		r.Create = nil
+	}
+	if r.Read != nil {
+		r.ReadContext = wrap(r.Read)
+		r.Read = nil
+	}
+	if r.Update != nil {
+		r.UpdateContext = wrap(r.Update)
+		r.Update = nil
+	}
+	if r.Delete != nil {
+		r.DeleteContext = wrap(r.Delete)
+		r.Delete = nil
+	}
+	return r
+}
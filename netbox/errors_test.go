@@ -0,0 +1,135 @@
+package netbox
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/fbreckle/go-netbox/netbox/client/dcim"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIErrorDiagnosticsValidation(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceNetboxDevice().Schema, map[string]interface{}{
+		"name":    "leaf01",
+		"role_id": 1,
+	})
+
+	apiErr := dcim.NewDcimDevicesCreateDefault(http.StatusBadRequest)
+	apiErr.Payload = map[string]interface{}{
+		"name":             []interface{}{"A device with this name already exists."},
+		"position":         []interface{}{"U42 is already occupied or does not have sufficient space."},
+		"role":             []interface{}{"This field is required."},
+		"custom_fields":    []interface{}{"Invalid value for custom field 'owner': Value must be a string."},
+		"non_field_errors": []interface{}{"Devices must be assigned to a site."},
+		"unknown":          "Something is wrong.",
+	}
+
+	diags := apiErrorDiagnostics(d, apiErr)
+	assert.Equal(t, diag.Diagnostics{
+		{
+			Severity:      diag.Error,
+			Summary:       "Invalid value for custom field owner",
+			Detail:        "Invalid value for custom field 'owner': Value must be a string.",
+			AttributePath: cty.GetAttrPath("custom_fields").IndexString("owner"),
+		},
+		{
+			Severity:      diag.Error,
+			Summary:       "Invalid value for name",
+			Detail:        "A device with this name already exists.",
+			AttributePath: cty.GetAttrPath("name"),
+		},
+		{
+			Severity: diag.Error,
+			Summary:  "Netbox rejected the request",
+			Detail:   "Devices must be assigned to a site.",
+		},
+		{
+			Severity:      diag.Error,
+			Summary:       "Invalid value for rack_position",
+			Detail:        "U42 is already occupied or does not have sufficient space.",
+			AttributePath: cty.GetAttrPath("rack_position"),
+		},
+		{
+			Severity:      diag.Error,
+			Summary:       "Invalid value for role_id",
+			Detail:        "This field is required.",
+			AttributePath: cty.GetAttrPath("role_id"),
+		},
+		{
+			Severity: diag.Error,
+			Summary:  "Invalid value for unknown",
+			Detail:   "Something is wrong.",
+		},
+	}, diags)
+}
+
+func TestAPIErrorDiagnosticsPermissionDenied(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceNetboxDevice().Schema, map[string]interface{}{})
+	d.SetId("12")
+
+	apiErr := dcim.NewDcimDevicesUpdateDefault(http.StatusForbidden)
+	apiErr.Payload = map[string]interface{}{"detail": "You do not have permission to perform this action."}
+
+	diags := apiErrorDiagnostics(d, apiErr)
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, "Permission denied to change Netbox dcim.device with ID 12", diags[0].Summary)
+		assert.Equal(t, "You do not have permission to perform this action.\n\nMake sure the API token used by the provider has the `dcim.change_device` permission.", diags[0].Detail)
+	}
+}
+
+func TestAPIErrorDiagnosticsNotFound(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+	d.SetId("7")
+
+	err := &genericAPIError{method: http.MethodGet, path: "/ipam/ip-addresses/7/", code: http.StatusNotFound, body: []byte(`{"detail": "Not found."}`)}
+
+	diags := apiErrorDiagnostics(d, err)
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, "Netbox ipam.ipaddress with ID 7 not found", diags[0].Summary)
+	}
+}
+
+func TestAPIErrorDiagnosticsReadOnly(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+	d.SetId("12")
+
+	err := fmt.Errorf("request failed: %w", &readOnlyError{method: http.MethodPatch, path: "/netbox/api/dcim/devices/12/"})
+
+	diags := apiErrorDiagnostics(d, err)
+	if assert.Len(t, diags, 1) {
+		assert.Equal(t, "Cannot change Netbox dcim.device with ID 12, the provider is read-only", diags[0].Summary)
+		assert.Equal(t, "The `read_only` provider argument is set, so the PATCH /netbox/api/dcim/devices/12/ request was not sent to Netbox.", diags[0].Detail)
+	}
+}
+
+func TestAPIErrorDiagnosticsOtherErrors(t *testing.T) {
+	assert.Nil(t, apiErrorDiagnostics(nil, nil))
+	assert.Equal(t, diag.FromErr(errors.New("boom")), apiErrorDiagnostics(nil, errors.New("boom")))
+
+	apiErr := dcim.NewDcimDevicesCreateDefault(http.StatusInternalServerError)
+	assert.Equal(t, diag.FromErr(apiErr), apiErrorDiagnostics(nil, apiErr))
+}
+
+func TestAPIObjectType(t *testing.T) {
+	for path, expected := range map[string]string{
+		"dcim/devices/":                   "dcim.device",
+		"dcim/devices/{id}/":              "dcim.device",
+		"dcim/virtual-chassis/12/":        "dcim.virtualchassis",
+		"ipam/ip-addresses/":              "ipam.ipaddress",
+		"dcim/device-bays/":               "dcim.devicebay",
+		"dcim/mac-addresses/":             "dcim.macaddress",
+		"ipam/prefixes/{id}/":             "ipam.prefix",
+		"tenancy/contact-groups/":         "tenancy.contactgroup",
+		"extras/config-templates/":        "extras.configtemplate",
+		"plugins/branching/branches/":     "branching.branch",
+		"virtualization/virtual-machines": "virtualization.virtualmachine",
+		"status/":                         "",
+	} {
+		assert.Equal(t, expected, apiObjectType(path), path)
+	}
+}
@@ -0,0 +1,211 @@
+package netbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+)
+
+// genericAPIError is returned when Netbox responds to a request to an
+// arbitrary API path with an error.
+type genericAPIError struct {
+	method string
+	path   string
+	code   int
+	body   []byte
+}
+
+func (e *genericAPIError) Error() string {
+	return fmt.Sprintf("[%s %s][%d] %s", e.method, e.path, e.code, e.body)
+}
+
+// Code returns the HTTP status code of the response, like the error types of
+// the generated client do.
+func (e *genericAPIError) Code() int {
+	return e.code
+}
+
+// genericAPIPath builds an API path relative to `/api/` from the path of an
+// object type given by the user and further path elements, e.g. the ID of an
+// object. Netbox requires a trailing slash on all API paths.
+func genericAPIPath(path string, elems ...string) string {
+	parts := []string{strings.Trim(path, "/")}
+	for _, elem := range elems {
+		parts = append(parts, strings.Trim(elem, "/"))
+	}
+	return "/" + strings.Join(parts, "/") + "/"
+}
+
+// genericAPIRequest sends a request to an arbitrary path of the Netbox API.
+// It uses the transport of the generated client, so authentication, retries,
+// throttling and custom headers apply just like for all other requests. The
+// raw body of a successful response is returned.
+func genericAPIRequest(ctx context.Context, api *providerState, method, path string, query url.Values, body json.RawMessage) ([]byte, error) {
+	op := &runtime.ClientOperation{
+		ID:                 "generic_" + strings.ToLower(method),
+		Method:             method,
+		PathPattern:        path,
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params: runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+			for name, values := range query {
+				if err := r.SetQueryParam(name, values...); err != nil {
+					return err
+				}
+			}
+			if body != nil {
+				return r.SetBodyParam(body)
+			}
+			return nil
+		}),
+		Reader: runtime.ClientResponseReaderFunc(func(response runtime.ClientResponse, consumer runtime.Consumer) (interface{}, error) {
+			data, err := io.ReadAll(response.Body())
+			if err != nil {
+				return nil, err
+			}
+			if response.Code() < http.StatusOK || response.Code() >= http.StatusMultipleChoices {
+				return nil, &genericAPIError{
+					method: method,
+					path:   path,
+					code:   response.Code(),
+					body:   bytes.TrimSpace(data),
+				}
+			}
+			return data, nil
+		}),
+		Context: ctx,
+	}
+
+	result, err := api.Transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	return result.([]byte), nil
+}
+
+// genericListParams holds the paging parameters for listing objects of an
+// arbitrary API path with listAll.
+type genericListParams struct {
+	limit  *int64
+	offset *int64
+}
+
+func (p *genericListParams) SetLimit(limit *int64) {
+	p.limit = limit
+}
+
+func (p *genericListParams) SetOffset(offset *int64) {
+	p.offset = offset
+}
+
+// genericListAll pages through the objects of an arbitrary API path matching
+// the given query parameters. It returns the raw JSON of each object and the
+// total count reported by Netbox.
+func genericListAll(ctx context.Context, api *providerState, path string, query url.Values, limit int64) ([]json.RawMessage, int64, error) {
+	return listAll(&genericListParams{}, limit, func(p *genericListParams) (*listPage[json.RawMessage], error) {
+		pageQuery := url.Values{}
+		for name, values := range query {
+			pageQuery[name] = values
+		}
+		pageQuery.Set("limit", strconv.FormatInt(*p.limit, 10))
+		pageQuery.Set("offset", strconv.FormatInt(*p.offset, 10))
+
+		res, err := genericAPIRequest(ctx, api, http.MethodGet, path, pageQuery, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var payload struct {
+			Count   *int64            `json:"count"`
+			Next    *string           `json:"next"`
+			Results []json.RawMessage `json:"results"`
+		}
+		if err := json.Unmarshal(res, &payload); err != nil {
+			return nil, fmt.Errorf("unexpected response from %s, expected a list of objects: %w", path, err)
+		}
+
+		page := &listPage[json.RawMessage]{Count: payload.Count, Results: payload.Results}
+		if payload.Next != nil {
+			next := strfmt.URI(*payload.Next)
+			page.Next = &next
+		}
+		return page, nil
+	})
+}
+
+// decodeJSON decodes a JSON document, keeping numbers as json.Number so that
+// large IDs do not lose precision.
+func decodeJSON(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var decoded interface{}
+	if err := decoder.Decode(&decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// projectJSON reduces an object returned by Netbox to the structure of the
+// configured value, so that only the configured fields are compared. Netbox
+// returns related objects and choices as nested objects, while they are
+// written by their ID or value; those nested objects are reduced to the ID or
+// value again if the configuration contains a scalar. Configured fields that
+// are not returned by Netbox, like secrets, are kept as configured.
+func projectJSON(config, actual interface{}) interface{} {
+	switch c := config.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			return actual
+		}
+		result := make(map[string]interface{}, len(c))
+		for key, value := range c {
+			if actualValue, ok := a[key]; ok {
+				result[key] = projectJSON(value, actualValue)
+			} else {
+				result[key] = value
+			}
+		}
+		return result
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok {
+			return actual
+		}
+		result := make([]interface{}, 0, len(a))
+		for i, value := range a {
+			switch {
+			case i < len(c):
+				result = append(result, projectJSON(c[i], value))
+			case len(c) > 0:
+				result = append(result, projectJSON(c[0], value))
+			default:
+				result = append(result, value)
+			}
+		}
+		return result
+	case nil:
+		return actual
+	default:
+		if a, ok := actual.(map[string]interface{}); ok {
+			if value, ok := a["value"]; ok {
+				return value
+			}
+			if id, ok := a["id"]; ok {
+				return id
+			}
+		}
+		return actual
+	}
+}
@@ -0,0 +1,123 @@
+package netbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenericAPIPath(t *testing.T) {
+	assert.Equal(t, "/wireless/wireless-lans/", genericAPIPath("wireless/wireless-lans"))
+	assert.Equal(t, "/plugins/dns/zones/12/", genericAPIPath("/plugins/dns/zones/", "12"))
+}
+
+func TestGenericAPIRequest(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/api/plugins/dns/zones/":
+			assert.Equal(t, "Token 07b12b765127747e4afd56cb531b7bf9c61f3c30", r.Header.Get("Authorization"))
+			assert.JSONEq(t, `{"name": "example.com"}`, string(body))
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{"id": 1, "name": "example.com"}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/plugins/dns/zones/":
+			assert.Equal(t, "example.com", r.URL.Query().Get("name"))
+			fmt.Fprint(w, `{"count": 1, "results": [{"id": 1, "name": "example.com"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"detail": "Not found."}`)
+		}
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: ts.URL,
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+	api := newProviderState(client)
+
+	res, err := genericAPIRequest(context.Background(), api, http.MethodPost, "/plugins/dns/zones/", nil, json.RawMessage(`{"name": "example.com"}`))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id": 1, "name": "example.com"}`, string(res))
+
+	res, err = genericAPIRequest(context.Background(), api, http.MethodGet, "/plugins/dns/zones/", map[string][]string{"name": {"example.com"}}, nil)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"count": 1, "results": [{"id": 1, "name": "example.com"}]}`, string(res))
+
+	_, err = genericAPIRequest(context.Background(), api, http.MethodGet, "/plugins/dns/zones/2/", nil, nil)
+	if assert.IsType(t, &genericAPIError{}, err) {
+		assert.Equal(t, http.StatusNotFound, err.(*genericAPIError).Code())
+		assert.EqualError(t, err, `[GET /plugins/dns/zones/2/][404] {"detail": "Not found."}`)
+	}
+}
+
+func TestProjectJSON(t *testing.T) {
+	config, _ := decodeJSON([]byte(`{
+		"name": "lan",
+		"status": "active",
+		"group": 3,
+		"tags": [{"name": "foo"}],
+		"auth_psk": "secret",
+		"description": null
+	}`))
+	actual, _ := decodeJSON([]byte(`{
+		"id": 1,
+		"name": "lan",
+		"status": {"value": "active", "label": "Active"},
+		"group": {"id": 3, "name": "group"},
+		"tags": [{"id": 1, "name": "foo", "slug": "foo"}, {"id": 2, "name": "bar", "slug": "bar"}],
+		"description": "",
+		"last_updated": "2024-01-01T00:00:00Z"
+	}`))
+
+	projected, err := json.Marshal(projectJSON(config, actual))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"name": "lan",
+		"status": "active",
+		"group": 3,
+		"tags": [{"name": "foo"}, {"name": "bar"}],
+		"auth_psk": "secret",
+		"description": ""
+	}`, string(projected))
+}
+
+func TestGenericListAll(t *testing.T) {
+	var queries []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("offset") == "0" {
+			fmt.Fprintf(w, `{"count": 3, "next": "%s/api/dcim/devices/?offset=2", "results": [{"id": 1}, {"id": 2}]}`, "http://"+r.Host)
+			return
+		}
+		fmt.Fprint(w, `{"count": 3, "next": null, "results": [{"id": 3}]}`)
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: ts.URL,
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+	api := newProviderState(client)
+
+	objects, count, err := genericListAll(context.Background(), api, "/dcim/devices/", map[string][]string{"name__ic": {"leaf"}, "site": {"a", "b"}}, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	assert.Equal(t, []json.RawMessage{json.RawMessage(`{"id": 1}`), json.RawMessage(`{"id": 2}`), json.RawMessage(`{"id": 3}`)}, objects)
+	assert.Equal(t, []string{
+		"limit=1000&name__ic=leaf&offset=0&site=a&site=b",
+		"limit=1000&name__ic=leaf&offset=2&site=a&site=b",
+	}, queries)
+}
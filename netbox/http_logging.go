@@ -0,0 +1,146 @@
+package netbox
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// httpLogSubsystem is the logging subsystem of the requests sent to Netbox.
+// Its level can be set separately via the TF_LOG_PROVIDER_NETBOX_HTTP
+// environment variable.
+const httpLogSubsystem = "netbox_http"
+
+// loggingTransport is a transport that logs every request sent to Netbox and
+// its response. The values of the Authorization header and of the custom
+// headers are masked wherever they appear in the logs.
+type loggingTransport struct {
+	original http.RoundTripper
+	// ctx carries the logger used for requests whose context has none.
+	ctx       context.Context
+	logBodies bool
+	secrets   []string
+}
+
+// RoundTrip logs the request, sends it and logs the response.
+func (t loggingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	ctx, secrets := t.logContext(r)
+
+	fields := map[string]interface{}{
+		"http.method": r.Method,
+		"http.path":   r.URL.Path,
+	}
+	if r.URL.RawQuery != "" {
+		fields["http.query"] = r.URL.RawQuery
+	}
+
+	if t.logBodies {
+		fields["http.request_headers"] = redactedHeaders(r.Header, secrets)
+		if r.Body != nil && r.Body != http.NoBody {
+			body, err := io.ReadAll(r.Body)
+			r.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			fields["http.request_body"] = string(body)
+		}
+	}
+
+	tflog.SubsystemDebug(ctx, httpLogSubsystem, "Sending request to Netbox", fields)
+
+	start := time.Now()
+	resp, err := t.original.RoundTrip(r)
+	fields["http.duration_ms"] = time.Since(start).Milliseconds()
+	delete(fields, "http.request_headers")
+	delete(fields, "http.request_body")
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.SubsystemDebug(ctx, httpLogSubsystem, "Request to Netbox failed", fields)
+		return resp, err
+	}
+
+	fields["http.status"] = resp.StatusCode
+	if t.logBodies {
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		fields["http.response_body"] = string(body)
+	}
+
+	tflog.SubsystemDebug(ctx, httpLogSubsystem, "Received response from Netbox", fields)
+
+	return resp, nil
+}
+
+// logContext returns the context to log the request with, together with the
+// secrets masked in it. The logger is taken from the context of the request,
+// which carries the fields Terraform adds for the current operation, and from
+// the context of the provider configuration otherwise.
+func (t loggingTransport) logContext(r *http.Request) (context.Context, []string) {
+	ctx := httpLogContext(r.Context(), t.ctx)
+
+	secrets := append([]string{}, t.secrets...)
+	if authorization := r.Header.Get("Authorization"); authorization != "" {
+		secrets = append(secrets, authorization)
+		if _, token, found := strings.Cut(authorization, " "); found {
+			secrets = append(secrets, token)
+		}
+	}
+	if len(secrets) > 0 {
+		ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, httpLogSubsystem, secrets...)
+		ctx = tflog.SubsystemMaskMessageStrings(ctx, httpLogSubsystem, secrets...)
+	}
+	return ctx, secrets
+}
+
+// httpLogContext returns the context to log with in the subsystem of the
+// requests sent to Netbox. The logger is taken from ctx and from fallback if
+// ctx has none.
+func httpLogContext(ctx, fallback context.Context) context.Context {
+	logCtx := tflog.NewSubsystem(ctx, httpLogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_NETBOX", "HTTP"))
+	if logCtx == ctx && fallback != nil {
+		// ctx has no logger
+		logCtx = tflog.NewSubsystem(fallback, httpLogSubsystem, tflog.WithLevelFromEnv("TF_LOG_PROVIDER_NETBOX", "HTTP"))
+	}
+	return logCtx
+}
+
+// redactedHeaders returns the headers of a request for logging. Masking only
+// applies to top-level fields, so values containing secrets are masked here.
+func redactedHeaders(headers http.Header, secrets []string) map[string]interface{} {
+	redacted := make(map[string]interface{}, len(headers))
+	for key, values := range headers {
+		value := strings.Join(values, ", ")
+		for _, secret := range secrets {
+			if strings.Contains(value, secret) {
+				value = "***"
+				break
+			}
+		}
+		redacted[key] = value
+	}
+	return redacted
+}
+
+// headerSecrets returns the values of the custom headers, which may contain
+// credentials, e.g. of a proxy in front of Netbox.
+func headerSecrets(headers map[string]interface{}) []string {
+	var secrets []string
+	for _, value := range headers {
+		if s := fmt.Sprintf("%v", value); s != "" {
+			secrets = append(secrets, s)
+		}
+	}
+	return secrets
+}
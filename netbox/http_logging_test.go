@@ -0,0 +1,103 @@
+package netbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoggingTransportMasksSecrets(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": 1, "name": "example.com"}`)
+	}))
+	defer ts.Close()
+
+	var output bytes.Buffer
+	config := Config{
+		APIToken:      "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL:     ts.URL,
+		Headers:       map[string]interface{}{"X-Proxy-Authorization": "proxy-secret"},
+		LogHTTPBodies: true,
+		LogContext:    tflogtest.RootLogger(context.Background(), &output),
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+
+	_, err = genericAPIRequest(context.Background(), newProviderState(client), http.MethodPost, "/plugins/dns/zones/", nil, json.RawMessage(`{"name": "example.com"}`))
+	assert.NoError(t, err)
+
+	assert.NotContains(t, output.String(), "07b12b765127747e4afd56cb531b7bf9c61f3c30")
+	assert.NotContains(t, output.String(), "proxy-secret")
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	assert.NoError(t, err)
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, "Sending request to Netbox", entries[0]["@message"])
+		assert.Equal(t, httpLogSubsystem, entries[0]["@module"].(string)[len("provider."):])
+		assert.Equal(t, http.MethodPost, entries[0]["http.method"])
+		assert.Equal(t, "/api/plugins/dns/zones/", entries[0]["http.path"])
+		assert.JSONEq(t, `{"name": "example.com"}`, entries[0]["http.request_body"].(string))
+		assert.Equal(t, map[string]interface{}{
+			"Accept":                "application/json",
+			"Authorization":         "***",
+			"Content-Type":          "application/json",
+			"X-Proxy-Authorization": "***",
+		}, entries[0]["http.request_headers"])
+
+		assert.Equal(t, "Received response from Netbox", entries[1]["@message"])
+		assert.Equal(t, float64(http.StatusCreated), entries[1]["http.status"])
+		assert.Contains(t, entries[1], "http.duration_ms")
+		assert.JSONEq(t, `{"id": 1, "name": "example.com"}`, entries[1]["http.response_body"].(string))
+	}
+}
+
+func TestRetryIsLoggedInHTTPSubsystem(t *testing.T) {
+	attempts := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"netbox-version": "4.1.11"}`)
+	}))
+	defer ts.Close()
+
+	var output bytes.Buffer
+	config := Config{
+		APIToken:   "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL:  ts.URL,
+		MaxRetries: 1,
+		LogContext: tflogtest.RootLogger(context.Background(), &output),
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+
+	_, err = genericAPIRequest(context.Background(), newProviderState(client), http.MethodGet, "/status/", nil, nil)
+	assert.NoError(t, err)
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	assert.NoError(t, err)
+	var retries []map[string]interface{}
+	for _, entry := range entries {
+		if entry["@message"] == "Retrying request to Netbox after transient failure" {
+			retries = append(retries, entry)
+		}
+	}
+	if assert.Len(t, retries, 1) {
+		assert.Equal(t, "warn", retries[0]["@level"])
+		assert.Equal(t, httpLogSubsystem, retries[0]["@module"].(string)[len("provider."):])
+		assert.Equal(t, "/api/status/", retries[0]["http.path"])
+		assert.Equal(t, float64(http.StatusServiceUnavailable), retries[0]["http.status"])
+	}
+}
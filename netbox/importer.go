@@ -0,0 +1,140 @@
+package netbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+var naturalKeyPartRegexp = regexp.MustCompile(`^([A-Za-z0-9_]+)=(.*)$`)
+
+// importByNaturalKey returns an importer for objects of the given API path,
+// e.g. `dcim/devices`. Besides the numeric ID of an object, the import ID may
+// be a natural key made up of Netbox filter parameters separated by slashes,
+// e.g. `site=fra1/name=leaf01`. Slashes in values are allowed, so
+// `address=10.0.0.1/24` works as expected. For object types with a slug, the
+// slug alone may be given as well. A `vrf` is looked up by its name first and
+// by its route distinguisher second. The natural key must match exactly one
+// object.
+func importByNaturalKey(path string, slugged bool) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		if _, err := strconv.ParseInt(d.Id(), 10, 64); err == nil {
+			return []*schema.ResourceData{d}, nil
+		}
+
+		api := m.(*providerState)
+
+		query, err := parseNaturalKey(d.Id(), slugged)
+		if err != nil {
+			return nil, err
+		}
+		if query.Has("vrf") {
+			vrfID, err := naturalKeyVRFID(ctx, api, query.Get("vrf"))
+			if err != nil {
+				return nil, err
+			}
+			query.Del("vrf")
+			query.Set("vrf_id", vrfID)
+		}
+
+		objects, count, err := genericListAll(ctx, api, genericAPIPath(path), query, 2)
+		if err != nil {
+			return nil, fmt.Errorf("error looking up object %q: %w", d.Id(), err)
+		}
+		switch {
+		case count == 0 || len(objects) == 0:
+			return nil, fmt.Errorf("no object found matching %q", d.Id())
+		case count > 1:
+			return nil, fmt.Errorf("%d objects found matching %q, the import ID must match exactly one object", count, d.Id())
+		}
+
+		id, err := objectID(objects[0])
+		if err != nil {
+			return nil, fmt.Errorf("unexpected object without an ID found matching %q: %s", d.Id(), objects[0])
+		}
+
+		d.SetId(id)
+
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// parseNaturalKey parses an import ID like `vrf=65000:1/address=10.0.0.1/24`
+// into Netbox filter parameters. A part without a `key=` prefix belongs to the
+// value of the previous part.
+func parseNaturalKey(importID string, slugged bool) (url.Values, error) {
+	if !strings.Contains(importID, "=") {
+		if slugged && importID != "" {
+			return url.Values{"slug": {importID}}, nil
+		}
+		return nil, fmt.Errorf("invalid import ID %q, expected the numeric ID of the object or filters like `name=foo` separated by slashes", importID)
+	}
+
+	var keys []string
+	var values []string
+	for _, part := range strings.Split(importID, "/") {
+		if match := naturalKeyPartRegexp.FindStringSubmatch(part); match != nil {
+			keys = append(keys, match[1])
+			values = append(values, match[2])
+			continue
+		}
+		if len(values) == 0 {
+			return nil, fmt.Errorf("invalid import ID %q, expected filters like `name=foo` separated by slashes", importID)
+		}
+		values[len(values)-1] += "/" + part
+	}
+
+	query := url.Values{}
+	for i, key := range keys {
+		query.Add(key, values[i])
+	}
+	return query, nil
+}
+
+// naturalKeyVRFID returns the ID of the VRF given as `vrf` in a natural key,
+// which is its name or its route distinguisher. The Netbox `vrf` filter only
+// matches route distinguishers, which makes `vrf=prod` fail surprisingly
+// otherwise. `null` stands for the global table.
+func naturalKeyVRFID(ctx context.Context, api *providerState, vrf string) (string, error) {
+	if vrf == "null" {
+		return vrf, nil
+	}
+
+	for _, filter := range []struct{ key, description string }{{"name", "name"}, {"rd", "route distinguisher"}} {
+		vrfs, count, err := genericListAll(ctx, api, genericAPIPath("ipam/vrfs"), url.Values{filter.key: {vrf}}, 2)
+		if err != nil {
+			return "", fmt.Errorf("error looking up VRF %q: %w", vrf, err)
+		}
+		switch {
+		case count > 1:
+			return "", fmt.Errorf("%d VRFs with the %s %q found, use `vrf_id=<ID>` instead", count, filter.description, vrf)
+		case count == 1 && len(vrfs) == 1:
+			id, err := objectID(vrfs[0])
+			if err != nil {
+				return "", fmt.Errorf("unexpected VRF without an ID found matching %q: %s", vrf, vrfs[0])
+			}
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("no VRF with the name or route distinguisher %q found, expected `vrf=<name or route distinguisher>`", vrf)
+}
+
+// objectID returns the ID of an object returned by the Netbox API.
+func objectID(object json.RawMessage) (string, error) {
+	var value struct {
+		ID *int64 `json:"id"`
+	}
+	if err := json.Unmarshal(object, &value); err != nil {
+		return "", err
+	}
+	if value.ID == nil {
+		return "", fmt.Errorf("object without an ID")
+	}
+	return strconv.FormatInt(*value.ID, 10), nil
+}
@@ -0,0 +1,142 @@
+package netbox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNaturalKey(t *testing.T) {
+	for importID, expected := range map[string]url.Values{
+		"site=fra1/name=leaf01":           {"site": {"fra1"}, "name": {"leaf01"}},
+		"device=leaf01/name=eth0":         {"device": {"leaf01"}, "name": {"eth0"}},
+		"vrf=prod/address=10.0.0.1/24":    {"vrf": {"prod"}, "address": {"10.0.0.1/24"}},
+		"address=2001:db8::1/64/vrf=prod": {"address": {"2001:db8::1/64"}, "vrf": {"prod"}},
+		"tag=a/tag=b":                     {"tag": {"a", "b"}},
+		"fra1":                            {"slug": {"fra1"}},
+	} {
+		query, err := parseNaturalKey(importID, true)
+		assert.NoError(t, err, importID)
+		assert.Equal(t, expected, query, importID)
+	}
+
+	for _, importID := range []string{"", "10.0.0.1/24/vrf=prod"} {
+		_, err := parseNaturalKey(importID, true)
+		assert.Error(t, err, importID)
+	}
+
+	_, err := parseNaturalKey("leaf01", false)
+	assert.Error(t, err)
+}
+
+func TestImportByNaturalKey(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/dcim/devices/", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("name") {
+		case "leaf01":
+			fmt.Fprint(w, `{"count": 1, "results": [{"id": 42, "name": "leaf01"}]}`)
+		case "leaf02":
+			fmt.Fprint(w, `{"count": 2, "results": [{"id": 43, "name": "leaf02"}, {"id": 44, "name": "leaf02"}]}`)
+		default:
+			fmt.Fprint(w, `{"count": 0, "results": []}`)
+		}
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: ts.URL,
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+	api := newProviderState(client)
+
+	importer := importByNaturalKey("dcim/devices", false)
+
+	for importID, expected := range map[string]string{
+		"12":                    "12",
+		"site=fra1/name=leaf01": "42",
+	} {
+		d := resourceNetboxDevice().TestResourceData()
+		d.SetId(importID)
+		result, err := importer(context.Background(), d, api)
+		if assert.NoError(t, err, importID) {
+			assert.Equal(t, expected, result[0].Id(), importID)
+		}
+	}
+
+	for importID, expected := range map[string]string{
+		"site=fra1/name=leaf02": `2 objects found matching "site=fra1/name=leaf02", the import ID must match exactly one object`,
+		"site=fra1/name=leaf03": `no object found matching "site=fra1/name=leaf03"`,
+	} {
+		d := resourceNetboxDevice().TestResourceData()
+		d.SetId(importID)
+		_, err := importer(context.Background(), d, api)
+		assert.EqualError(t, err, expected, importID)
+	}
+}
+
+func TestImportByNaturalKeyVRF(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/api/ipam/vrfs/":
+			switch {
+			case query.Get("name") == "prod":
+				fmt.Fprint(w, `{"count": 1, "results": [{"id": 3, "name": "prod", "rd": "65000:1"}]}`)
+			case query.Get("rd") == "65000:2":
+				fmt.Fprint(w, `{"count": 1, "results": [{"id": 4, "name": "dev", "rd": "65000:2"}]}`)
+			case query.Get("name") == "shared":
+				fmt.Fprint(w, `{"count": 2, "results": [{"id": 5, "name": "shared"}, {"id": 6, "name": "shared"}]}`)
+			default:
+				fmt.Fprint(w, `{"count": 0, "results": []}`)
+			}
+		case "/api/ipam/ip-addresses/":
+			assert.False(t, query.Has("vrf"))
+			assert.Equal(t, "10.0.0.1/24", query.Get("address"))
+			fmt.Fprintf(w, `{"count": 1, "results": [{"id": 1%s}]}`, query.Get("vrf_id"))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: ts.URL,
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+	api := newProviderState(client)
+
+	importer := importByNaturalKey("ipam/ip-addresses", false)
+
+	for importID, expected := range map[string]string{
+		"vrf=prod/address=10.0.0.1/24":    "13",
+		"vrf=65000:2/address=10.0.0.1/24": "14",
+	} {
+		d := resourceNetboxIPAddress().TestResourceData()
+		d.SetId(importID)
+		result, err := importer(context.Background(), d, api)
+		if assert.NoError(t, err, importID) {
+			assert.Equal(t, expected, result[0].Id(), importID)
+		}
+	}
+
+	for importID, expected := range map[string]string{
+		"vrf=shared/address=10.0.0.1/24": "2 VRFs with the name \"shared\" found, use `vrf_id=<ID>` instead",
+		"vrf=test/address=10.0.0.1/24":   "no VRF with the name or route distinguisher \"test\" found, expected `vrf=<name or route distinguisher>`",
+	} {
+		d := resourceNetboxIPAddress().TestResourceData()
+		d.SetId(importID)
+		_, err := importer(context.Background(), d, api)
+		assert.EqualError(t, err, expected, importID)
+	}
+}
@@ -29,5 +29,5 @@ func sharedClientForRegion(region string) (interface{}, error) {
 	transport.DefaultAuthentication = httptransport.APIKeyAuth("Authorization", "header", "Token "+apiToken)
 	c := client.New(transport, nil)
 
-	return c, nil
+	return newProviderState(c), nil
 }
@@ -0,0 +1,64 @@
+package netbox
+
+import (
+	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// pageSize is the number of objects requested per page when paging through
+// list endpoints. Netbox caps this at its MAX_PAGE_SIZE setting, so pages
+// may be smaller than this.
+const pageSize = int64(1000)
+
+// totalCountSchema reports how many objects matched the filters in Netbox,
+// regardless of how many were actually returned.
+var totalCountSchema = &schema.Schema{
+	Type:        schema.TypeInt,
+	Computed:    true,
+	Description: "The total number of objects matching the given filters in Netbox. This can be larger than the number of returned objects if `limit` is set or results are filtered on the client side.",
+}
+
+// paginatedParams is implemented by the parameters of every list endpoint.
+type paginatedParams interface {
+	SetLimit(*int64)
+	SetOffset(*int64)
+}
+
+// listPage is a single page of results as returned by a list endpoint.
+type listPage[T any] struct {
+	Count   *int64
+	Next    *strfmt.URI
+	Results []T
+}
+
+// listAll pages through a list endpoint until all objects are fetched or
+// limit objects have been collected. A limit of 0 fetches all objects.
+// It returns the collected objects and the total count reported by Netbox.
+func listAll[P paginatedParams, T any](params P, limit int64, list func(P) (*listPage[T], error)) ([]T, int64, error) {
+	var results []T
+	var count int64
+
+	for {
+		requested := pageSize
+		if remaining := limit - int64(len(results)); limit > 0 && remaining < requested {
+			requested = remaining
+		}
+		offset := int64(len(results))
+		params.SetLimit(&requested)
+		params.SetOffset(&offset)
+
+		page, err := list(params)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if page.Count != nil {
+			count = *page.Count
+		}
+		results = append(results, page.Results...)
+
+		if page.Next == nil || len(page.Results) == 0 || (limit > 0 && int64(len(results)) >= limit) {
+			return results, count, nil
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package netbox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeListParams struct {
+	Limit  *int64
+	Offset *int64
+}
+
+func (p *fakeListParams) SetLimit(limit *int64) {
+	p.Limit = limit
+}
+
+func (p *fakeListParams) SetOffset(offset *int64) {
+	p.Offset = offset
+}
+
+// fakeList emulates a list endpoint over total objects that returns at most
+// maxPageSize objects per page, just like Netbox does with MAX_PAGE_SIZE.
+func fakeList(total, maxPageSize int64, calls *int) func(*fakeListParams) (*listPage[int64], error) {
+	return func(p *fakeListParams) (*listPage[int64], error) {
+		*calls++
+		size := min(*p.Limit, maxPageSize)
+		var results []int64
+		for i := *p.Offset; i < total && i < *p.Offset+size; i++ {
+			results = append(results, i)
+		}
+		var next *strfmt.URI
+		if *p.Offset+int64(len(results)) < total {
+			uri := strfmt.URI("https://netbox.example.com/api/next/")
+			next = &uri
+		}
+		return &listPage[int64]{Count: &total, Next: next, Results: results}, nil
+	}
+}
+
+func TestListAllFetchesAllPages(t *testing.T) {
+	calls := 0
+	results, count, err := listAll(&fakeListParams{}, 0, fakeList(2500, 1000, &calls))
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2500), count)
+	assert.Len(t, results, 2500)
+	assert.Equal(t, int64(2499), results[2499])
+	assert.Equal(t, 3, calls)
+}
+
+func TestListAllSmallMaxPageSize(t *testing.T) {
+	calls := 0
+	results, count, err := listAll(&fakeListParams{}, 0, fakeList(120, 50, &calls))
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(120), count)
+	assert.Len(t, results, 120)
+	assert.Equal(t, 3, calls)
+}
+
+func TestListAllStopsAtLimit(t *testing.T) {
+	calls := 0
+	results, count, err := listAll(&fakeListParams{}, 1500, fakeList(2500, 1000, &calls))
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2500), count)
+	assert.Len(t, results, 1500)
+	assert.Equal(t, 2, calls)
+}
+
+func TestListAllEmpty(t *testing.T) {
+	calls := 0
+	results, count, err := listAll(&fakeListParams{}, 0, fakeList(0, 1000, &calls))
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+	assert.Empty(t, results)
+	assert.Equal(t, 1, calls)
+}
+
+func TestListAllError(t *testing.T) {
+	_, _, err := listAll(&fakeListParams{}, 0, func(p *fakeListParams) (*listPage[int64], error) {
+		return nil, errors.New("boom")
+	})
+
+	assert.EqualError(t, err, "boom")
+}
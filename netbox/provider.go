@@ -4,12 +4,15 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
+	netboxclient "github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/status"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"golang.org/x/exp/slices"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // This makes the description contain the default value, particularly useful for the docs
@@ -69,6 +72,36 @@ func init() {
 	}
 }
 
+// providerState is the meta object passed to all resources and data sources.
+// It embeds the Netbox API client and carries state that is shared for the
+// lifetime of the provider.
+type providerState struct {
+	*netboxclient.NetBoxAPI
+	tags         *tagCache
+	customFields *customFieldCache
+
+	// defaultTags and defaultCustomFields are added to every object that
+	// supports tags or custom fields, respectively.
+	defaultTags         []string
+	defaultCustomFields map[string]interface{}
+
+	// version is the version of Netbox, or nil if the version check was
+	// skipped.
+	version *netboxVersion
+}
+
+func newProviderState(api *netboxclient.NetBoxAPI) *providerState {
+	return &providerState{
+		NetBoxAPI:    api,
+		tags:         newTagCache(),
+		customFields: newCustomFieldCache(),
+	}
+}
+
+// defaultResourceTimeout is the default deadline of a resource operation.
+// Single requests are additionally limited by request_timeout.
+const defaultResourceTimeout = 20 * time.Minute
+
 // Provider returns a schema.Provider for Netbox.
 func Provider() *schema.Provider {
 	provider := &schema.Provider{
@@ -150,6 +183,11 @@ func Provider() *schema.Provider {
 			"netbox_vpn_tunnel":                 resourceNetboxVpnTunnel(),
 			"netbox_vpn_tunnel_termination":     resourceNetboxVpnTunnelTermination(),
 			"netbox_config_context":             resourceNetboxConfigContext(),
+			"netbox_object":                     resourceNetboxObject(),
+			"netbox_branch":                     resourceNetboxBranch(),
+			"netbox_available_vlan":             resourceNetboxAvailableVlan(),
+			"netbox_asn_range":                  resourceNetboxAsnRange(),
+			"netbox_available_asn":              resourceNetboxAvailableAsn(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"netbox_asn":               dataSourceNetboxAsn(),
@@ -193,6 +231,11 @@ func Provider() *schema.Provider {
 			"netbox_racks":             dataSourceNetboxRacks(),
 			"netbox_rack_role":         dataSourceNetboxRackRole(),
 			"netbox_config_context":    dataSourceNetboxConfigContext(),
+			"netbox_objects":           dataSourceNetboxObjects(),
+			"netbox_graphql_query":     dataSourceNetboxGraphqlQuery(),
+			"netbox_status":            dataSourceNetboxStatus(),
+			"netbox_available_vlan":    dataSourceNetboxAvailableVlan(),
+			"netbox_available_asn":     dataSourceNetboxAvailableAsn(),
 		},
 		Schema: map[string]*schema.Schema{
 			"server_url": {
@@ -203,9 +246,28 @@ func Provider() *schema.Provider {
 			},
 			"api_token": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("NETBOX_API_TOKEN", nil),
-				Description: "Netbox API authentication token. Can be set via the `NETBOX_API_TOKEN` environment variable.",
+				Description: "Netbox API authentication token. Either this or `token_command` is required. Can be set via the `NETBOX_API_TOKEN` environment variable.",
+			},
+			"api_token_scheme": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("NETBOX_API_TOKEN_SCHEME", apiTokenSchemeAuto),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.StringInSlice([]string{apiTokenSchemeAuto, apiTokenSchemeToken, apiTokenSchemeBearer}, false)),
+				Description:      "The scheme the API token is sent with. Netbox 4.4 and earlier only support `Token`, the v2 tokens of Netbox 4.5 and later, which start with `nbt_`, are sent as `Bearer` tokens. With `auto`, the scheme is chosen by the format of the token. Can be set via the `NETBOX_API_TOKEN_SCHEME` environment variable. Defaults to `auto`.",
+			},
+			"log_http_bodies": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NETBOX_LOG_HTTP_BODIES", false),
+				Description: "If true, the headers and bodies of requests to Netbox and of their responses are logged as well. Requests are logged at the `DEBUG` level in the `netbox_http` subsystem, whose level can be set separately via the `TF_LOG_PROVIDER_NETBOX_HTTP` environment variable. The API token and the values of `headers` are masked in the logs. Can be set via the `NETBOX_LOG_HTTP_BODIES` environment variable. Defaults to `false`.",
+			},
+			"token_command": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "A command that prints a Netbox API token to stdout, given as the program and its arguments, e.g. `[\"netbox-token-helper\", \"--ttl\", \"1h\"]`. The output must be a JSON object like `{\"token\": \"...\", \"expiry\": \"2006-01-02T15:04:05Z\"}`, where the RFC 3339 `expiry` is optional. The command is run again shortly before the token expires and whenever Netbox rejects the token. Conflicts with `api_token`, but takes precedence over the `NETBOX_API_TOKEN` environment variable.",
 			},
 			"allow_insecure_https": {
 				Type:        schema.TypeBool,
@@ -213,6 +275,49 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("NETBOX_ALLOW_INSECURE_HTTPS", false),
 				Description: "Flag to set whether to allow https with invalid certificates. Can be set via the `NETBOX_ALLOW_INSECURE_HTTPS` environment variable. Defaults to `false`.",
 			},
+			"ca_cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NETBOX_CA_CERT_FILE", nil),
+				Description: "Path to a PEM encoded file with the certificates of the certificate authorities used to verify the certificate of Netbox, instead of the certificate authorities trusted by the system. Conflicts with `ca_cert_pem`. Can be set via the `NETBOX_CA_CERT_FILE` environment variable.",
+			},
+			"ca_cert_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NETBOX_CA_CERT_PEM", nil),
+				Description: "PEM encoded certificates of the certificate authorities used to verify the certificate of Netbox, instead of the certificate authorities trusted by the system. Conflicts with `ca_cert_file`. Can be set via the `NETBOX_CA_CERT_PEM` environment variable.",
+			},
+			"client_cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NETBOX_CLIENT_CERT_FILE", nil),
+				Description: "Path to a PEM encoded client certificate presented to Netbox or a proxy in front of it for mutual TLS. Requires `client_key_file`. Can be set via the `NETBOX_CLIENT_CERT_FILE` environment variable.",
+			},
+			"client_key_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NETBOX_CLIENT_KEY_FILE", nil),
+				Description: "Path to the PEM encoded private key of `client_cert_file`. Can be set via the `NETBOX_CLIENT_KEY_FILE` environment variable.",
+			},
+			"client_cert_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NETBOX_CLIENT_CERT_PEM", nil),
+				Description: "PEM encoded client certificate presented to Netbox or a proxy in front of it for mutual TLS. Requires `client_key_pem` and conflicts with `client_cert_file`. Can be set via the `NETBOX_CLIENT_CERT_PEM` environment variable.",
+			},
+			"client_key_pem": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("NETBOX_CLIENT_KEY_PEM", nil),
+				Description: "PEM encoded private key of `client_cert_pem`. RSA, ECDSA and Ed25519 keys in PKCS #1, SEC 1 or PKCS #8 form are supported. Can be set via the `NETBOX_CLIENT_KEY_PEM` environment variable.",
+			},
+			"tls_server_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NETBOX_TLS_SERVER_NAME", nil),
+				Description: "Server name used to verify the certificate of Netbox, if it differs from the host of `server_url`. Setting this enables verification of the certificate even if `allow_insecure_https` is set. Can be set via the `NETBOX_TLS_SERVER_NAME` environment variable.",
+			},
 			"headers": {
 				Type:        schema.TypeMap,
 				Optional:    true,
@@ -229,7 +334,7 @@ func Provider() *schema.Provider {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("NETBOX_SKIP_VERSION_CHECK", false),
-				Description: "If true, do not try to determine the running Netbox version at provider startup. Disables warnings about possibly unsupported Netbox version. Also useful for local testing on terraform plans. Can be set via the `NETBOX_SKIP_VERSION_CHECK` environment variable. Defaults to `false`.",
+				Description: "If true, do not try to determine the running Netbox version at provider startup. Disables warnings about possibly unsupported Netbox version as well as the checks of resources and attributes that require a specific Netbox version. Also useful for local testing on terraform plans. Can be set via the `NETBOX_SKIP_VERSION_CHECK` environment variable. Defaults to `false`.",
 			},
 			"request_timeout": {
 				Type:        schema.TypeInt,
@@ -237,21 +342,144 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("NETBOX_REQUEST_TIMEOUT", 10),
 				Description: "Netbox API HTTP request timeout in seconds. Can be set via the `NETBOX_REQUEST_TIMEOUT` environment variable.",
 			},
+			"max_retries": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("NETBOX_MAX_RETRIES", 3),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "Maximum number of times an idempotent request (`GET`, `HEAD`, `OPTIONS`, `PUT` and `DELETE`) is retried when Netbox responds with a transient error (HTTP 429, 502, 503 or 504) or cannot be reached. Set to `0` to disable retries. Can be set via the `NETBOX_MAX_RETRIES` environment variable. Defaults to `3`.",
+			},
+			"retry_wait_min": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("NETBOX_RETRY_WAIT_MIN", 1),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "Time in seconds to wait before the first retry. The wait time doubles with every further retry, starting from 100 milliseconds if this is `0`. Can be set via the `NETBOX_RETRY_WAIT_MIN` environment variable. Defaults to `1`.",
+			},
+			"retry_wait_max": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("NETBOX_RETRY_WAIT_MAX", 30),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "Maximum time in seconds to wait between retries. This also caps the wait time requested by Netbox via the `Retry-After` header. Can be set via the `NETBOX_RETRY_WAIT_MAX` environment variable. Defaults to `30`.",
+			},
+			"max_requests_per_second": {
+				Type:             schema.TypeFloat,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("NETBOX_MAX_REQUESTS_PER_SECOND", 0.0),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.FloatAtLeast(0)),
+				Description:      "Maximum number of requests per second sent to Netbox by this provider instance. Every retry of a request counts as a separate request. Set to `0` for no limit. Can be set via the `NETBOX_MAX_REQUESTS_PER_SECOND` environment variable. Defaults to `0`.",
+			},
+			"max_concurrent_requests": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				DefaultFunc:      schema.EnvDefaultFunc("NETBOX_MAX_CONCURRENT_REQUESTS", 0),
+				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
+				Description:      "Maximum number of requests this provider instance sends to Netbox at the same time, independent of Terraform's `-parallelism`. Set to `0` for no limit. Can be set via the `NETBOX_MAX_CONCURRENT_REQUESTS` environment variable. Defaults to `0`.",
+			},
+			"branch": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NETBOX_BRANCH", nil),
+				Description: "Name or schema ID of a branch of the [netbox-branching](https://docs.netboxlabs.com/netbox-extensions/branching/) plugin. If set, all changes are made in this branch instead of the main schema. The branch must exist and be ready when the provider is configured. Can be set via the `NETBOX_BRANCH` environment variable.",
+			},
+			"read_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("NETBOX_READ_ONLY", false),
+				Description: "If true, the provider refuses to send any request to Netbox that might change data, i.e. anything but `GET`, `HEAD` and `OPTIONS` requests. Queries of the GraphQL API are allowed as well. Creating, updating or deleting a resource fails with an error instead. This makes it safe to run `terraform plan` against a production Netbox. Can be set via the `NETBOX_READ_ONLY` environment variable. Defaults to `false`.",
+			},
+			"default_tags": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Names of tags that are added to every object managed by resources with a `tags` attribute. Default tags do not show up in the `tags` attribute of the resources unless they are also set there. Objects are only tagged when they are created or updated by Terraform.",
+			},
+			"default_custom_fields": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Custom field values that are set on every object managed by resources with a `custom_fields` attribute, if the custom field is assigned to the object's type. Values set in a resource take precedence. Default custom fields do not show up in the `custom_fields` attribute of the resources unless they are also set there. Default custom fields are only set when an object is created or updated by Terraform.",
+			},
 		},
 		ConfigureContextFunc: providerConfigure,
 	}
+
+	for _, r := range provider.ResourcesMap {
+		withTimeouts(r)
+	}
+	for _, r := range provider.DataSourcesMap {
+		withAPIErrorDiagnostics(r)
+	}
+
 	return provider
 }
 
+// withTimeouts adds a `timeouts` block to a resource, so every operation can
+// be given a longer deadline than the default, e.g. for bulk allocations.
+// Timeouts that a resource already sets are kept.
+func withTimeouts(r *schema.Resource) *schema.Resource {
+	if r.Timeouts == nil {
+		r.Timeouts = &schema.ResourceTimeout{}
+	}
+	if r.CreateContext != nil && r.Timeouts.Create == nil {
+		r.Timeouts.Create = schema.DefaultTimeout(defaultResourceTimeout)
+	}
+	if r.ReadContext != nil && r.Timeouts.Read == nil {
+		r.Timeouts.Read = schema.DefaultTimeout(defaultResourceTimeout)
+	}
+	if r.UpdateContext != nil && r.Timeouts.Update == nil {
+		r.Timeouts.Update = schema.DefaultTimeout(defaultResourceTimeout)
+	}
+	if r.DeleteContext != nil && r.Timeouts.Delete == nil {
+		r.Timeouts.Delete = schema.DefaultTimeout(defaultResourceTimeout)
+	}
+	return r
+}
+
 func providerConfigure(ctx context.Context, data *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
+	var tokenCommand []string
+	for _, arg := range data.Get("token_command").([]interface{}) {
+		tokenCommand = append(tokenCommand, arg.(string))
+	}
+
+	// The token from the NETBOX_API_TOKEN environment variable does not
+	// conflict with a configured token_command, which takes precedence
+	apiToken := data.Get("api_token").(string)
+	if rawConfig := data.GetRawConfig(); len(tokenCommand) > 0 && !rawConfig.IsNull() && rawConfig.GetAttr("api_token").IsNull() {
+		apiToken = ""
+	}
+
 	config := Config{
-		APIToken:                    data.Get("api_token").(string),
+		APIToken:                    apiToken,
+		TokenCommand:                tokenCommand,
+		APITokenScheme:              data.Get("api_token_scheme").(string),
 		AllowInsecureHTTPS:          data.Get("allow_insecure_https").(bool),
+		CACertFile:                  data.Get("ca_cert_file").(string),
+		CACertPEM:                   data.Get("ca_cert_pem").(string),
+		ClientCertFile:              data.Get("client_cert_file").(string),
+		ClientKeyFile:               data.Get("client_key_file").(string),
+		ClientCertPEM:               data.Get("client_cert_pem").(string),
+		ClientKeyPEM:                data.Get("client_key_pem").(string),
+		TLSServerName:               data.Get("tls_server_name").(string),
 		Headers:                     data.Get("headers").(map[string]interface{}),
 		RequestTimeout:              data.Get("request_timeout").(int),
 		StripTrailingSlashesFromURL: data.Get("strip_trailing_slashes_from_url").(bool),
+		MaxRetries:                  data.Get("max_retries").(int),
+		RetryWaitMin:                data.Get("retry_wait_min").(int),
+		RetryWaitMax:                data.Get("retry_wait_max").(int),
+		MaxRequestsPerSecond:        data.Get("max_requests_per_second").(float64),
+		MaxConcurrentRequests:       data.Get("max_concurrent_requests").(int),
+		ReadOnly:                    data.Get("read_only").(bool),
+		LogHTTPBodies:               data.Get("log_http_bodies").(bool),
+		LogContext:                  ctx,
+	}
+
+	if config.RetryWaitMin > config.RetryWaitMax {
+		return nil, diag.Errorf("`retry_wait_min` (%d) must not be greater than `retry_wait_max` (%d)", config.RetryWaitMin, config.RetryWaitMax)
 	}
 
 	serverURL := data.Get("server_url").(string)
@@ -289,6 +517,7 @@ func providerConfigure(ctx context.Context, data *schema.ResourceData) (interfac
 	// so we can determine compatibility of the provider with the used Netbox
 	skipVersionCheck := data.Get("skip_version_check").(bool)
 
+	var version *netboxVersion
 	if !skipVersionCheck {
 		req := status.NewStatusListParams()
 		res, err := netboxClient.Status.StatusList(req, nil)
@@ -296,19 +525,48 @@ func providerConfigure(ctx context.Context, data *schema.ResourceData) (interfac
 			return nil, diag.FromErr(err)
 		}
 
-		netboxVersion := res.GetPayload().(map[string]interface{})["netbox-version"].(string)
-
-		supportedVersions := []string{"4.1.0", "4.1.1", "4.1.2", "4.1.3", "4.1.4", "4.1.5", "4.1.6", "4.1.7", "4.1.8", "4.1.10", "4.1.11"}
+		versionString := res.GetPayload().(map[string]interface{})["netbox-version"].(string)
 
-		if !slices.Contains(supportedVersions, netboxVersion) {
+		version, err = parseNetboxVersion(versionString)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Unknown Netbox version",
+				Detail:   fmt.Sprintf("The version of Netbox could not be determined: %s. Resources and attributes that require a specific Netbox version are not checked.", err),
+			})
+		} else if version.compare(testedVersionMin) < 0 || version.compare(testedVersionMax) >= 0 {
 			// Currently, there is no way to test these warnings. There is an issue to track this: https://github.com/hashicorp/terraform-plugin-sdk/issues/864
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Warning,
 				Summary:  "Possibly unsupported Netbox version",
-				Detail:   fmt.Sprintf("Your Netbox version is v%v. The provider was successfully tested against the following versions:\n\n  %v\n\nUnexpected errors may occur.", netboxVersion, strings.Join(supportedVersions, ", ")),
+				Detail:   fmt.Sprintf("Your Netbox version is v%v. The provider was successfully tested against versions from v%v up to, but not including, v%v.\n\nUnexpected errors may occur.", version, testedVersionMin, testedVersionMax),
 			})
 		}
 	}
 
-	return netboxClient, diags
+	if branchName := data.Get("branch").(string); branchName != "" {
+		branch, err := findBranch(ctx, newProviderState(netboxClient), branchName)
+		if err != nil {
+			return nil, diag.Errorf("error looking up branch %q: %s", branchName, err)
+		}
+		if branch.Status.Value != branchStatusReady {
+			return nil, diag.Errorf("branch %q is not ready, its status is %q", branchName, branch.Status.Value)
+		}
+
+		config.BranchSchemaID = branch.SchemaID
+		netboxClient, clientError = config.Client()
+		if clientError != nil {
+			return nil, diag.FromErr(clientError)
+		}
+	}
+
+	state := newProviderState(netboxClient)
+	for _, tag := range data.Get("default_tags").(*schema.Set).List() {
+		state.defaultTags = append(state.defaultTags, tag.(string))
+	}
+	sort.Strings(state.defaultTags)
+	state.defaultCustomFields = data.Get("default_custom_fields").(map[string]interface{})
+	state.version = version
+
+	return state, diags
 }
@@ -64,7 +64,7 @@ func providerInvalidConfigure() schema.ConfigureContextFunc {
 			return nil, diag.FromErr(clientError)
 		}
 
-		return netboxClient, diags
+		return newProviderState(netboxClient), diags
 	}
 }
 
@@ -87,3 +87,17 @@ func TestAccNetboxProviderConfigure_failure(t *testing.T) {
 		},
 	})
 }
+
+func TestProviderResourcesAreContextAware(t *testing.T) {
+	for name, r := range Provider().ResourcesMap {
+		if r.Create != nil || r.Read != nil || r.Update != nil || r.Delete != nil {
+			t.Errorf("%s: CRUD functions must be the context-aware variants", name)
+		}
+		if r.Timeouts == nil || r.Timeouts.Read == nil || r.Timeouts.Delete == nil {
+			t.Errorf("%s: missing timeouts", name)
+		}
+		if r.UpdateContext != nil && r.Timeouts.Update == nil {
+			t.Errorf("%s: missing update timeout", name)
+		}
+	}
+}
@@ -1,20 +1,21 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceNetboxAsn() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxAsnCreate,
-		Read:   resourceNetboxAsnRead,
-		Update: resourceNetboxAsnUpdate,
-		Delete: resourceNetboxAsnDelete,
+		CreateContext: resourceNetboxAsnCreate,
+		ReadContext:   resourceNetboxAsnRead,
+		UpdateContext: resourceNetboxAsnUpdate,
+		DeleteContext: resourceNetboxAsnDelete,
 
 		Description: `:meta:subcategory:IP Address Management (IPAM):From the [official documentation](https://docs.netbox.dev/en/stable/features/ipam/#asn):
 > ASN is short for Autonomous System Number. This identifier is used in the BGP protocol to identify which "autonomous system" a particular prefix is originating and transiting through.
@@ -45,13 +46,13 @@ func resourceNetboxAsn() *schema.Resource {
 			tagsKey: tagsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("ipam/asns", false),
 		},
 	}
 }
 
-func resourceNetboxAsnCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxAsnCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableASN{}
 
@@ -63,24 +64,24 @@ func resourceNetboxAsnCreate(d *schema.ResourceData, m interface{}) error {
 
 	data.Description = d.Get("description").(string)
 	data.Comments = d.Get("comments").(string)
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	params := ipam.NewIpamAsnsCreateParams().WithData(&data)
+	params := ipam.NewIpamAsnsCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Ipam.IpamAsnsCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxAsnRead(d, m)
+	return resourceNetboxAsnRead(ctx, d, m)
 }
 
-func resourceNetboxAsnRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxAsnRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamAsnsReadParams().WithID(id)
+	params := ipam.NewIpamAsnsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Ipam.IpamAsnsRead(params, nil)
 
@@ -93,7 +94,7 @@ func resourceNetboxAsnRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	asn := res.GetPayload()
@@ -101,13 +102,13 @@ func resourceNetboxAsnRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("rir_id", asn.Rir.ID)
 	d.Set("description", asn.Description)
 	d.Set("comments", asn.Comments)
-	d.Set(tagsKey, getTagListFromNestedTagList(asn.Tags))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(asn.Tags)))
 
 	return nil
 }
 
-func resourceNetboxAsnUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxAsnUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableASN{}
@@ -120,23 +121,23 @@ func resourceNetboxAsnUpdate(d *schema.ResourceData, m interface{}) error {
 
 	data.Description = d.Get("description").(string)
 	data.Comments = d.Get("comments").(string)
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	params := ipam.NewIpamAsnsUpdateParams().WithID(id).WithData(&data)
+	params := ipam.NewIpamAsnsUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Ipam.IpamAsnsUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxAsnRead(d, m)
+	return resourceNetboxAsnRead(ctx, d, m)
 }
 
-func resourceNetboxAsnDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxAsnDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamAsnsDeleteParams().WithID(id)
+	params := ipam.NewIpamAsnsDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Ipam.IpamAsnsDelete(params, nil)
 	if err != nil {
@@ -146,7 +147,7 @@ func resourceNetboxAsnDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
@@ -0,0 +1,208 @@
+package netbox
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// asnRangesPath is the API path of ASN ranges, which are not covered by the
+// generated client.
+const asnRangesPath = "ipam/asn-ranges"
+
+// asnRange is an ASN range as returned by Netbox.
+type asnRange struct {
+	ID          int64                `json:"id"`
+	Name        string               `json:"name"`
+	Slug        string               `json:"slug"`
+	Rir         *models.NestedRIR    `json:"rir"`
+	Start       int64                `json:"start"`
+	End         int64                `json:"end"`
+	Tenant      *models.NestedTenant `json:"tenant"`
+	Description string               `json:"description"`
+	Tags        []*models.NestedTag  `json:"tags"`
+}
+
+// writableASNRange is an ASN range as written to Netbox.
+type writableASNRange struct {
+	Name        string              `json:"name"`
+	Slug        string              `json:"slug"`
+	Rir         int64               `json:"rir"`
+	Start       int64               `json:"start"`
+	End         int64               `json:"end"`
+	Tenant      *int64              `json:"tenant"`
+	Description string              `json:"description"`
+	Tags        []*models.NestedTag `json:"tags"`
+}
+
+func resourceNetboxAsnRange() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetboxAsnRangeCreate,
+		ReadContext:   resourceNetboxAsnRangeRead,
+		UpdateContext: resourceNetboxAsnRangeUpdate,
+		DeleteContext: resourceNetboxAsnRangeDelete,
+
+		Description: `:meta:subcategory:IP Address Management (IPAM):From the [official documentation](https://docs.netbox.dev/en/stable/models/ipam/asnrange/):
+
+> Ranges can be defined to group AS numbers numerically and to facilitate their automatic provisioning. Each range must be assigned to a RIR.`,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"slug": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringLenBetween(1, 100),
+			},
+			"rir_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+			},
+			"start": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(1, 4294967295),
+				Description:  "The first AS number of the range.",
+			},
+			"end": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(1, 4294967295),
+				Description:  "The last AS number of the range.",
+			},
+			"tenant_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			tagsKey: tagsSchema,
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importByNaturalKey(asnRangesPath, true),
+		},
+	}
+}
+
+func getASNRangeFromResourceData(ctx context.Context, api *providerState, d *schema.ResourceData) (json.RawMessage, error) {
+	data := writableASNRange{}
+
+	data.Name = d.Get("name").(string)
+	data.Slug = getSlug(data.Name)
+	if slug, ok := d.GetOk("slug"); ok {
+		data.Slug = slug.(string)
+	}
+	data.Rir = int64(d.Get("rir_id").(int))
+	data.Start = int64(d.Get("start").(int))
+	data.End = int64(d.Get("end").(int))
+	data.Tenant = getOptionalInt(d, "tenant_id")
+	data.Description = d.Get("description").(string)
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
+
+	return json.Marshal(&data)
+}
+
+func resourceNetboxAsnRangeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+
+	data, err := getASNRangeFromResourceData(ctx, api, d)
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
+	}
+
+	res, err := genericAPIRequest(ctx, api, http.MethodPost, genericAPIPath(asnRangesPath), nil, data)
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
+	}
+
+	var created asnRange
+	if err := json.Unmarshal(res, &created); err != nil {
+		return diag.Errorf("unexpected response when creating the ASN range: %s", err)
+	}
+	d.SetId(strconv.FormatInt(created.ID, 10))
+
+	return resourceNetboxAsnRangeRead(ctx, d, m)
+}
+
+func resourceNetboxAsnRangeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+
+	res, err := genericAPIRequest(ctx, api, http.MethodGet, genericAPIPath(asnRangesPath, d.Id()), nil, nil)
+	if err != nil {
+		if errresp, ok := err.(*genericAPIError); ok {
+			if errresp.Code() == 404 {
+				// If the ID is updated to blank, this tells Terraform the resource no longer exists (maybe it was destroyed out of band). Just like the destroy callback, the Read function should gracefully handle this case. https://www.terraform.io/docs/extend/writing-custom-providers.html
+				d.SetId("")
+				return nil
+			}
+		}
+		return apiErrorDiagnostics(d, err)
+	}
+
+	var payload asnRange
+	if err := json.Unmarshal(res, &payload); err != nil {
+		return diag.Errorf("unexpected response when reading the ASN range: %s", err)
+	}
+
+	d.Set("name", payload.Name)
+	d.Set("slug", payload.Slug)
+	d.Set("start", payload.Start)
+	d.Set("end", payload.End)
+	d.Set("description", payload.Description)
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(payload.Tags)))
+
+	if payload.Rir != nil {
+		d.Set("rir_id", payload.Rir.ID)
+	}
+	if payload.Tenant != nil {
+		d.Set("tenant_id", payload.Tenant.ID)
+	} else {
+		d.Set("tenant_id", nil)
+	}
+
+	return nil
+}
+
+func resourceNetboxAsnRangeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+
+	data, err := getASNRangeFromResourceData(ctx, api, d)
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
+	}
+
+	_, err = genericAPIRequest(ctx, api, http.MethodPut, genericAPIPath(asnRangesPath, d.Id()), nil, data)
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
+	}
+
+	return resourceNetboxAsnRangeRead(ctx, d, m)
+}
+
+func resourceNetboxAsnRangeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+
+	_, err := genericAPIRequest(ctx, api, http.MethodDelete, genericAPIPath(asnRangesPath, d.Id()), nil, nil)
+	if err != nil {
+		if errresp, ok := err.(*genericAPIError); ok {
+			if errresp.Code() == 404 {
+				d.SetId("")
+				return nil
+			}
+		}
+		return apiErrorDiagnostics(d, err)
+	}
+
+	return nil
+}
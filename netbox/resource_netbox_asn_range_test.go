@@ -0,0 +1,121 @@
+package netbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNetboxAsnRange_basic(t *testing.T) {
+	testSlug := "asn_range_basic"
+	testName := testAccGetTestName(testSlug)
+	resource.ParallelTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "netbox_tag" "test" {
+  name = "%[1]s"
+}
+
+resource "netbox_rir" "test" {
+  name = "%[1]s"
+}
+
+resource "netbox_tenant" "test" {
+  name = "%[1]s"
+}
+
+resource "netbox_asn_range" "test" {
+  name        = "%[1]s"
+  rir_id      = netbox_rir.test.id
+  start       = 4200000000
+  end         = 4200000099
+  tenant_id   = netbox_tenant.test.id
+  description = "test"
+  tags        = [netbox_tag.test.name]
+}`, testName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_asn_range.test", "name", testName),
+					resource.TestCheckResourceAttr("netbox_asn_range.test", "slug", getSlug(testName)),
+					resource.TestCheckResourceAttr("netbox_asn_range.test", "start", "4200000000"),
+					resource.TestCheckResourceAttr("netbox_asn_range.test", "end", "4200000099"),
+					resource.TestCheckResourceAttr("netbox_asn_range.test", "description", "test"),
+					resource.TestCheckResourceAttr("netbox_asn_range.test", "tags.#", "1"),
+					resource.TestCheckResourceAttr("netbox_asn_range.test", "tags.0", testName),
+					resource.TestCheckResourceAttrPair("netbox_asn_range.test", "rir_id", "netbox_rir.test", "id"),
+					resource.TestCheckResourceAttrPair("netbox_asn_range.test", "tenant_id", "netbox_tenant.test", "id"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "netbox_rir" "test" {
+  name = "%[1]s"
+}
+
+resource "netbox_asn_range" "test" {
+  name   = "%[1]s"
+  slug   = "%[2]s"
+  rir_id = netbox_rir.test.id
+  start  = 64512
+  end    = 64520
+}`, testName, testSlug),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_asn_range.test", "slug", testSlug),
+					resource.TestCheckResourceAttr("netbox_asn_range.test", "start", "64512"),
+					resource.TestCheckResourceAttr("netbox_asn_range.test", "end", "64520"),
+					resource.TestCheckResourceAttr("netbox_asn_range.test", "description", ""),
+					resource.TestCheckResourceAttr("netbox_asn_range.test", "tenant_id", "0"),
+					resource.TestCheckResourceAttr("netbox_asn_range.test", "tags.#", "0"),
+				),
+			},
+			{
+				ResourceName:      "netbox_asn_range.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func init() {
+	resource.AddTestSweepers("netbox_asn_range", &resource.Sweeper{
+		Name:         "netbox_asn_range",
+		Dependencies: []string{"netbox_asn"},
+		F: func(region string) error {
+			m, err := sharedClientForRegion(region)
+			if err != nil {
+				return fmt.Errorf("Error getting client: %s", err)
+			}
+			api := m.(*providerState)
+			ctx := context.Background()
+			objects, _, err := genericListAll(ctx, api, genericAPIPath(asnRangesPath), url.Values{}, 0)
+			if err != nil {
+				return err
+			}
+			for _, object := range objects {
+				var payload asnRange
+				if err := json.Unmarshal(object, &payload); err != nil {
+					return err
+				}
+				if strings.HasPrefix(payload.Name, testPrefix) {
+					_, err := genericAPIRequest(ctx, api, http.MethodDelete, genericAPIPath(asnRangesPath, strconv.FormatInt(payload.ID, 10)), nil, nil)
+					if err != nil {
+						return err
+					}
+					log.Print("[DEBUG] Deleted an asn range")
+				}
+			}
+			return nil
+		},
+	})
+}
@@ -0,0 +1,120 @@
+package netbox
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+
+	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceNetboxAvailableAsn() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetboxAvailableAsnCreate,
+		ReadContext:   resourceNetboxAvailableAsnRead,
+		UpdateContext: resourceNetboxAsnUpdate,
+		DeleteContext: resourceNetboxAsnDelete,
+
+		Description: `:meta:subcategory:IP Address Management (IPAM):From the [official documentation](https://docs.netbox.dev/en/stable/features/ipam/#asn):
+> ASN is short for Autonomous System Number. This identifier is used in the BGP protocol to identify which "autonomous system" a particular prefix is originating and transiting through.
+>
+> The AS number model within NetBox allows you to model some of this real-world relationship.
+
+This resource will create an AS Number record with the next available AS number of a given ASN range. If the chosen AS number is taken by a concurrent allocation, the allocation is retried.`,
+
+		Schema: map[string]*schema.Schema{
+			"asn_range_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the ASN range to allocate the AS number from",
+			},
+			"asn": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Value for the AS Number record",
+			},
+			"rir_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "ID for the RIR for the AS Number record, taken from the ASN range",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description field for the AS Number record",
+			},
+			"comments": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Comments field for the AS Number record",
+			},
+			tagsKey: tagsSchema,
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importByNaturalKey("ipam/asns", false),
+		},
+	}
+}
+
+func resourceNetboxAvailableAsnCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+
+	data := models.WritableASN{}
+
+	rangeID := int64(d.Get("asn_range_id").(int))
+
+	data.Description = d.Get("description").(string)
+	data.Comments = d.Get("comments").(string)
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
+
+	asn, err := allocateAvailableASN(ctx, api, d.Timeout(schema.TimeoutCreate), rangeID, &data)
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
+	}
+
+	d.SetId(strconv.FormatInt(asn.ID, 10))
+
+	return resourceNetboxAvailableAsnRead(ctx, d, m)
+}
+
+func resourceNetboxAvailableAsnRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	diags := resourceNetboxAsnRead(ctx, d, m)
+	if diags.HasError() || d.Id() == "" {
+		return diags
+	}
+
+	api := m.(*providerState)
+
+	// Netbox does not remember the range an AS number was allocated from, so
+	// it is looked up among the ASN ranges of its RIR
+	asn := int64(d.Get("asn").(int))
+	query := url.Values{}
+	query.Set("rir_id", strconv.Itoa(d.Get("rir_id").(int)))
+	query.Set("start__lte", strconv.FormatInt(asn, 10))
+	query.Set("end__gte", strconv.FormatInt(asn, 10))
+	ranges, _, err := genericListAll(ctx, api, genericAPIPath(asnRangesPath), query, 0)
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
+	}
+
+	// Ranges may overlap, so the current range is kept as long as it still
+	// contains the AS number
+	currentID := int64(d.Get("asn_range_id").(int))
+	var rangeID *int64
+	for _, raw := range ranges {
+		var candidate asnRange
+		if err := json.Unmarshal(raw, &candidate); err != nil {
+			return diag.Errorf("unexpected response when listing the ASN ranges: %s", err)
+		}
+		if candidate.Start <= asn && asn <= candidate.End && (rangeID == nil || candidate.ID == currentID) {
+			rangeID = int64ToPtr(candidate.ID)
+		}
+	}
+	d.Set("asn_range_id", rangeID)
+
+	return diags
+}
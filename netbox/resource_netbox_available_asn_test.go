@@ -0,0 +1,155 @@
+package netbox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func testAccNetboxAvailableAsnFullDependencies(testName string, start, end int) string {
+	return fmt.Sprintf(`
+resource "netbox_tag" "test" {
+  name = "%[1]s"
+}
+
+resource "netbox_rir" "test" {
+  name = "%[1]s"
+}
+
+resource "netbox_asn_range" "test" {
+  name   = "%[1]s"
+  rir_id = netbox_rir.test.id
+  start  = %[2]d
+  end    = %[3]d
+}
+
+resource "netbox_asn" "taken" {
+  asn    = %[2]d
+  rir_id = netbox_rir.test.id
+}
+`, testName, start, end)
+}
+
+func TestAccNetboxAvailableAsn_basic(t *testing.T) {
+	testSlug := "avail_asn_basic"
+	testName := testAccGetTestName(testSlug)
+	resource.ParallelTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetboxAvailableAsnFullDependencies(testName, 4200001000, 4200001009) + `
+resource "netbox_available_asn" "test" {
+  asn_range_id = netbox_asn_range.test.id
+  description  = "test"
+  comments     = "test"
+  tags         = [netbox_tag.test.name]
+  depends_on   = [netbox_asn.taken]
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_available_asn.test", "asn", "4200001001"),
+					resource.TestCheckResourceAttr("netbox_available_asn.test", "description", "test"),
+					resource.TestCheckResourceAttr("netbox_available_asn.test", "comments", "test"),
+					resource.TestCheckResourceAttr("netbox_available_asn.test", "tags.#", "1"),
+					resource.TestCheckResourceAttr("netbox_available_asn.test", "tags.0", testName),
+					resource.TestCheckResourceAttrPair("netbox_available_asn.test", "rir_id", "netbox_rir.test", "id"),
+				),
+			},
+			{
+				Config: testAccNetboxAvailableAsnFullDependencies(testName, 4200001000, 4200001009) + `
+resource "netbox_available_asn" "test" {
+  asn_range_id = netbox_asn_range.test.id
+  description  = "updated"
+  depends_on   = [netbox_asn.taken]
+}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_available_asn.test", "asn", "4200001001"),
+					resource.TestCheckResourceAttr("netbox_available_asn.test", "description", "updated"),
+					resource.TestCheckResourceAttr("netbox_available_asn.test", "comments", ""),
+					resource.TestCheckResourceAttr("netbox_available_asn.test", "tags.#", "0"),
+				),
+			},
+			{
+				ResourceName:      "netbox_available_asn.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccNetboxAvailableAsn_exhausted(t *testing.T) {
+	testSlug := "avail_asn_exh"
+	testName := testAccGetTestName(testSlug)
+	resource.ParallelTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetboxAvailableAsnFullDependencies(testName, 4200001100, 4200001101) + `
+resource "netbox_available_asn" "test1" {
+  asn_range_id = netbox_asn_range.test.id
+  depends_on   = [netbox_asn.taken]
+}
+
+resource "netbox_available_asn" "test2" {
+  asn_range_id = netbox_asn_range.test.id
+  depends_on   = [netbox_available_asn.test1]
+}`,
+				ExpectError: regexp.MustCompile("Netbox ASN range with ID [0-9]+ is exhausted"),
+			},
+		},
+	})
+}
+
+func TestAvailableAsnReadSetsRange(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/ipam/asns/21/":
+			fmt.Fprint(w, `{"id": 21, "asn": 4200000005, "rir": {"id": 2}}`)
+		case "/api/ipam/asn-ranges/":
+			assert.Equal(t, "2", r.URL.Query().Get("rir_id"))
+			assert.Equal(t, "4200000005", r.URL.Query().Get("start__lte"))
+			assert.Equal(t, "4200000005", r.URL.Query().Get("end__gte"))
+			fmt.Fprint(w, `{"count": 3, "results": [
+				{"id": 3, "start": 4200000010, "end": 4200000019},
+				{"id": 4, "start": 4200000000, "end": 4200000009},
+				{"id": 5, "start": 4200000005, "end": 4200000099}
+			]}`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL.Path)
+		}
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: ts.URL,
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+	api := newProviderState(client)
+
+	// On import, the first range containing the AS number is used
+	d := schema.TestResourceDataRaw(t, resourceNetboxAvailableAsn().Schema, map[string]interface{}{})
+	d.SetId("21")
+	assert.False(t, resourceNetboxAvailableAsnRead(context.Background(), d, api).HasError())
+	assert.Equal(t, 4, d.Get("asn_range_id"))
+	assert.Equal(t, 2, d.Get("rir_id"))
+
+	// Otherwise, the configured range is kept while it contains the AS number
+	d = schema.TestResourceDataRaw(t, resourceNetboxAvailableAsn().Schema, map[string]interface{}{
+		"asn_range_id": 5,
+	})
+	d.SetId("21")
+	assert.False(t, resourceNetboxAvailableAsnRead(context.Background(), d, api).HasError())
+	assert.Equal(t, 5, d.Get("asn_range_id"))
+}
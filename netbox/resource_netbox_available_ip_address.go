@@ -1,21 +1,23 @@
 package netbox
 
 import (
+	"context"
+	"net/netip"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxAvailableIPAddress() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxAvailableIPAddressCreate,
-		Read:   resourceNetboxAvailableIPAddressRead,
-		Update: resourceNetboxAvailableIPAddressUpdate,
-		Delete: resourceNetboxAvailableIPAddressDelete,
+		CreateContext: resourceNetboxAvailableIPAddressCreate,
+		ReadContext:   resourceNetboxAvailableIPAddressRead,
+		UpdateContext: resourceNetboxAvailableIPAddressUpdate,
+		DeleteContext: resourceNetboxAvailableIPAddressDelete,
 
 		Description: `:meta:subcategory:IP Address Management (IPAM):Per [the docs](https://netbox.readthedocs.io/en/stable/models/ipam/ipaddress/):
 
@@ -29,18 +31,24 @@ func resourceNetboxAvailableIPAddress() *schema.Resource {
 > * DHCP
 > * SLAAC (IPv6 Stateless Address Autoconfiguration)
 
-This resource will retrieve the next available IP address from a given prefix or IP range (specified by ID)`,
+This resource will retrieve the next available IP address from a given prefix or IP range (specified by ID), or from the first prefix matching a prefix selector that has space. If the chosen addresses are taken by a concurrent allocation, the allocation is retried.`,
 
 		Schema: map[string]*schema.Schema{
 			"prefix_id": {
 				Type:         schema.TypeInt,
 				Optional:     true,
-				ExactlyOneOf: []string{"prefix_id", "ip_range_id"},
+				ExactlyOneOf: []string{"prefix_id", "ip_range_id", "prefix_selector"},
 			},
 			"ip_range_id": {
 				Type:         schema.TypeInt,
 				Optional:     true,
-				ExactlyOneOf: []string{"prefix_id", "ip_range_id"},
+				ExactlyOneOf: []string{"prefix_id", "ip_range_id", "prefix_selector"},
+			},
+			"prefix_selector": prefixSelectorSchema([]string{"prefix_id", "ip_range_id", "prefix_selector"}),
+			"selected_prefix_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The ID of the prefix the IP address was allocated from.",
 			},
 			"ip_address": {
 				Type:     schema.TypeString,
@@ -101,13 +109,13 @@ This resource will retrieve the next available IP address from a given prefix or
 			customFieldsKey: customFieldsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("ipam/ip-addresses", false),
 		},
 	}
 }
 
-func resourceNetboxAvailableIPAddressCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxAvailableIPAddressCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	prefixID := int64(d.Get("prefix_id").(int))
 	vrfID := int64(int64(d.Get("vrf_id").(int)))
 	rangeID := int64(d.Get("ip_range_id").(int))
@@ -117,27 +125,33 @@ func resourceNetboxAvailableIPAddressCreate(d *schema.ResourceData, m interface{
 	data := models.AvailableIP{
 		Vrf: &nestedvrf,
 	}
-	if prefixID != 0 {
-		params := ipam.NewIpamPrefixesAvailableIpsCreateParams().WithID(prefixID).WithData([]*models.AvailableIP{&data})
-		res, _ := api.Ipam.IpamPrefixesAvailableIpsCreate(params, nil)
-		// Since we generated the ip_address, set that now
-		d.SetId(strconv.FormatInt(res.Payload[0].ID, 10))
-		d.Set("ip_address", *res.Payload[0].Address)
+	var res []*models.IPAddress
+	var err error
+	if selectors := d.Get("prefix_selector").([]interface{}); len(selectors) > 0 {
+		selector, _ := selectors[0].(map[string]interface{})
+		prefixID, err = allocateFromPrefixSelector(ctx, api, selector, "IP addresses", 1, func(candidateID int64) error {
+			res, err = allocateAvailableIPs(ctx, api, d.Timeout(schema.TimeoutCreate), candidateID, 0, []*models.AvailableIP{&data})
+			return err
+		})
+	} else {
+		res, err = allocateAvailableIPs(ctx, api, d.Timeout(schema.TimeoutCreate), prefixID, rangeID, []*models.AvailableIP{&data})
 	}
-	if rangeID != 0 {
-		params := ipam.NewIpamIPRangesAvailableIpsCreateParams().WithID(rangeID).WithData([]*models.AvailableIP{&data})
-		res, _ := api.Ipam.IpamIPRangesAvailableIpsCreate(params, nil)
-		// Since we generated the ip_address, set that now
-		d.SetId(strconv.FormatInt(res.Payload[0].ID, 10))
-		d.Set("ip_address", *res.Payload[0].Address)
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
+	}
+	if prefixID != 0 {
+		d.Set("selected_prefix_id", prefixID)
 	}
-	return resourceNetboxAvailableIPAddressUpdate(d, m)
+	// Since we generated the ip_address, set that now
+	d.SetId(strconv.FormatInt(res[0].ID, 10))
+	d.Set("ip_address", *res[0].Address)
+	return resourceNetboxAvailableIPAddressUpdate(ctx, d, m)
 }
 
-func resourceNetboxAvailableIPAddressRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxAvailableIPAddressRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamIPAddressesReadParams().WithID(id)
+	params := ipam.NewIpamIPAddressesReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Ipam.IpamIPAddressesRead(params, nil)
 	if err != nil {
@@ -149,7 +163,7 @@ func resourceNetboxAvailableIPAddressRead(d *schema.ResourceData, m interface{})
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	ipAddress := res.GetPayload()
@@ -192,18 +206,36 @@ func resourceNetboxAvailableIPAddressRead(d *schema.ResourceData, m interface{})
 	d.Set("ip_address", ipAddress.Address)
 	d.Set("description", ipAddress.Description)
 	d.Set("status", ipAddress.Status.Value)
-	d.Set(tagsKey, getTagListFromNestedTagList(ipAddress.Tags))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(ipAddress.Tags)))
 
-	cf := getCustomFields(ipAddress.CustomFields)
-	if cf != nil {
-		d.Set(customFieldsKey, cf)
+	d.Set(customFieldsKey, withoutDefaultCustomFields(api, d, getCustomFields(ctx, api, ipAddress.CustomFields)))
+
+	// IP addresses allocated from an IP range do not have a selected prefix
+	if _, ok := d.GetOk("ip_range_id"); !ok && ipAddress.Address != nil {
+		address := *ipAddress.Address
+		if network, err := netip.ParsePrefix(address); err == nil {
+			address = network.Addr().String()
+		}
+		var vrfID int64
+		if ipAddress.Vrf != nil {
+			vrfID = ipAddress.Vrf.ID
+		}
+		currentID := int64(d.Get("selected_prefix_id").(int))
+		if currentID == 0 {
+			currentID = int64(d.Get("prefix_id").(int))
+		}
+		prefixID, err := allocatedFromPrefixID(ctx, api, address, vrfID, 0, currentID)
+		if err != nil {
+			return apiErrorDiagnostics(d, err)
+		}
+		d.Set("selected_prefix_id", prefixID)
 	}
 
 	return nil
 }
 
-func resourceNetboxAvailableIPAddressUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxAvailableIPAddressUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableIPAddress{}
@@ -244,27 +276,28 @@ func resourceNetboxAvailableIPAddressUpdate(d *schema.ResourceData, m interface{
 		data.AssignedObjectID = nil
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "ipam.ipaddress")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := ipam.NewIpamIPAddressesUpdateParams().WithID(id).WithData(&data)
+	params := ipam.NewIpamIPAddressesUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Ipam.IpamIPAddressesUpdate(params, nil)
+	_, err = api.Ipam.IpamIPAddressesUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
-	return resourceNetboxAvailableIPAddressRead(d, m)
+	return resourceNetboxAvailableIPAddressRead(ctx, d, m)
 }
 
-func resourceNetboxAvailableIPAddressDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxAvailableIPAddressDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamIPAddressesDeleteParams().WithID(id)
+	params := ipam.NewIpamIPAddressesDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Ipam.IpamIPAddressesDelete(params, nil)
 	if err != nil {
@@ -274,7 +307,7 @@ func resourceNetboxAvailableIPAddressDelete(d *schema.ResourceData, m interface{
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
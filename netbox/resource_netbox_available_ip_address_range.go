@@ -1,21 +1,25 @@
 package netbox
 
 import (
+	"context"
+	"fmt"
+	"reflect"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"golang.org/x/exp/slices"
 )
 
 func resourceNetboxAvailableIPAddressRange() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxAvailableIPAddressRangeCreate,
-		Read:   resourceNetboxAvailableIPAddressRangeRead,
-		Update: resourceNetboxAvailableIPAddressRangeUpdate,
-		Delete: resourceNetboxAvailableIPAddressRangeDelete,
+		CreateContext: resourceNetboxAvailableIPAddressRangeCreate,
+		ReadContext:   resourceNetboxAvailableIPAddressRangeRead,
+		UpdateContext: resourceNetboxAvailableIPAddressRangeUpdate,
+		DeleteContext: resourceNetboxAvailableIPAddressRangeDelete,
 
 		Description: `:meta:subcategory:IP Address Management (IPAM):Per [the docs](https://netbox.readthedocs.io/en/stable/models/ipam/ipaddress/):
 
@@ -29,7 +33,7 @@ func resourceNetboxAvailableIPAddressRange() *schema.Resource {
 > * DHCP
 > * SLAAC (IPv6 Stateless Address Autoconfiguration)
 
-This resource will retrieve the next available IP addresses from a given prefix or IP range (specified by ID)`,
+This resource will retrieve the next available IP addresses from a given prefix or IP range (specified by ID). If the chosen addresses are taken by a concurrent allocation, the allocation is retried.`,
 
 		Schema: map[string]*schema.Schema{
 			"prefix_id": {
@@ -56,6 +60,51 @@ This resource will retrieve the next available IP addresses from a given prefix
 				ValidateFunc: validation.IntAtLeast(1),
 				Description:  "The number of IP addresses to allocate",
 			},
+			"address": {
+				Type:          schema.TypeSet,
+				Optional:      true,
+				ConflictsWith: []string{"address_count", "dns_name", "description", "interface_id", "object_type", "virtual_machine_interface_id", "device_interface_id"},
+				Description:   "Allocates one IP address per key instead of `address_count` anonymous ones. Adding or removing a key allocates or releases only the IP address of that key, the IP addresses of the other keys never change. Switching between `address_count` and `address` recreates the resource.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "A stable key identifying the IP address, e.g. a hostname.",
+						},
+						"dns_name": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"device_interface_id": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "Conflicts with `virtual_machine_interface_id`.",
+						},
+						"virtual_machine_interface_id": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Description: "Conflicts with `device_interface_id`.",
+						},
+					},
+				},
+			},
+			"ip_addresses_by_key": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The allocated IP addresses by the key of their `address` block.",
+			},
+			"ip_address_ids_by_key": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "The IDs of the allocated IP addresses by the key of their `address` block.",
+			},
 			"interface_id": {
 				Type:         schema.TypeInt,
 				Optional:     true,
@@ -111,13 +160,18 @@ This resource will retrieve the next available IP addresses from a given prefix
 			customFieldsKey: customFieldsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("ipam/ip-addresses", false),
 		},
+		CustomizeDiff: resourceNetboxAvailableIPAddressRangeCustomizeDiff,
 	}
 }
 
-func resourceNetboxAvailableIPAddressRangeCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxAvailableIPAddressRangeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isKeyedIPAddressAllocation(d) {
+		return resourceNetboxAvailableIPAddressRangeKeyedCreate(ctx, d, m)
+	}
+
+	api := m.(*providerState)
 	prefixID := int64(d.Get("prefix_id").(int))
 	vrfID := int64(int64(d.Get("vrf_id").(int)))
 	rangeID := int64(d.Get("ip_range_id").(int))
@@ -142,36 +196,17 @@ func resourceNetboxAvailableIPAddressRangeCreate(d *schema.ResourceData, m inter
 	var ipAddresses []string
 	var firstIPID int64
 	
-	if prefixID != 0 {
-		params := ipam.NewIpamPrefixesAvailableIpsCreateParams().WithID(prefixID).WithData(availableIPs)
-		res, err := api.Ipam.IpamPrefixesAvailableIpsCreate(params, nil)
-		if err != nil {
-			return err
-		}
-		
-		// Store the first IP ID as the resource ID
-		firstIPID = res.Payload[0].ID
-		
-		// Store all IP addresses
-		for _, ip := range res.Payload {
-			ipAddresses = append(ipAddresses, *ip.Address)
-		}
+	res, err := allocateAvailableIPs(ctx, api, d.Timeout(schema.TimeoutCreate), prefixID, rangeID, availableIPs)
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
 	
-	if rangeID != 0 {
-		params := ipam.NewIpamIPRangesAvailableIpsCreateParams().WithID(rangeID).WithData(availableIPs)
-		res, err := api.Ipam.IpamIPRangesAvailableIpsCreate(params, nil)
-		if err != nil {
-			return err
-		}
-		
-		// Store the first IP ID as the resource ID
-		firstIPID = res.Payload[0].ID
-		
-		// Store all IP addresses
-		for _, ip := range res.Payload {
-			ipAddresses = append(ipAddresses, *ip.Address)
-		}
+	// Store the first IP ID as the resource ID
+	firstIPID = res[0].ID
+	
+	// Store all IP addresses
+	for _, ip := range res {
+		ipAddresses = append(ipAddresses, *ip.Address)
 	}
 	
 	// Set the ID to the first IP address ID
@@ -185,15 +220,19 @@ func resourceNetboxAvailableIPAddressRangeCreate(d *schema.ResourceData, m inter
 		d.Set("ip_address", ipAddresses[0])
 	}
 	
-	return resourceNetboxAvailableIPAddressRangeUpdate(d, m)
+	return resourceNetboxAvailableIPAddressRangeUpdate(ctx, d, m)
 }
 
-func resourceNetboxAvailableIPAddressRangeRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxAvailableIPAddressRangeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isKeyedIPAddressAllocation(d) {
+		return resourceNetboxAvailableIPAddressRangeKeyedRead(ctx, d, m)
+	}
+
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	
 	// For the primary IP (the one stored in the resource ID)
-	params := ipam.NewIpamIPAddressesReadParams().WithID(id)
+	params := ipam.NewIpamIPAddressesReadParamsWithContext(ctx).WithID(id)
 	res, err := api.Ipam.IpamIPAddressesRead(params, nil)
 	if err != nil {
 		if errresp, ok := err.(*ipam.IpamIPAddressesReadDefault); ok {
@@ -204,7 +243,7 @@ func resourceNetboxAvailableIPAddressRangeRead(d *schema.ResourceData, m interfa
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	ipAddress := res.GetPayload()
@@ -277,19 +316,20 @@ func resourceNetboxAvailableIPAddressRangeRead(d *schema.ResourceData, m interfa
 	// Set other attributes
 	d.Set("description", ipAddress.Description)
 	d.Set("status", ipAddress.Status.Value)
-	d.Set(tagsKey, getTagListFromNestedTagList(ipAddress.Tags))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(ipAddress.Tags)))
 
 	// Handle custom fields
-	cf := getCustomFields(ipAddress.CustomFields)
-	if cf != nil {
-		d.Set(customFieldsKey, cf)
-	}
+	d.Set(customFieldsKey, withoutDefaultCustomFields(api, d, getCustomFields(ctx, api, ipAddress.CustomFields)))
 
 	return nil
 }
 
-func resourceNetboxAvailableIPAddressRangeUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxAvailableIPAddressRangeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isKeyedIPAddressAllocation(d) {
+		return resourceNetboxAvailableIPAddressRangeKeyedUpdate(ctx, d, m)
+	}
+
+	api := m.(*providerState)
 
 	// The resource ID corresponds to the first IP address
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
@@ -326,9 +366,12 @@ func resourceNetboxAvailableIPAddressRangeUpdate(d *schema.ResourceData, m inter
 	}
 	
 	// Get tags and custom fields
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, _ := getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 	
-	ct, hasCF := d.GetOk(customFieldsKey)
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "ipam.ipaddress")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
+	}
 	
 	// Create update params for the primary IP (the one whose ID is stored in d.Id())
 	data := models.WritableIPAddress{}
@@ -352,15 +395,13 @@ func resourceNetboxAvailableIPAddressRangeUpdate(d *schema.ResourceData, m inter
 	data.AssignedObjectType = assignedObjectType
 	data.AssignedObjectID = assignedObjectID
 	data.Tags = tags
-	if hasCF {
-		data.CustomFields = ct
-	}
+	data.CustomFields = cf
 	
 	// Update the primary IP
-	params := ipam.NewIpamIPAddressesUpdateParams().WithID(id).WithData(&data)
-	_, err := api.Ipam.IpamIPAddressesUpdate(params, nil)
+	params := ipam.NewIpamIPAddressesUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
+	_, err = api.Ipam.IpamIPAddressesUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	
 	// Note: Additional IPs in the ip_addresses list beyond the first one
@@ -376,15 +417,19 @@ func resourceNetboxAvailableIPAddressRangeUpdate(d *schema.ResourceData, m inter
 	// However, this is complex and outside the scope of this resource,
 	// which is primarily designed to allocate available IPs from a range.
 	
-	return resourceNetboxAvailableIPAddressRangeRead(d, m)
+	return resourceNetboxAvailableIPAddressRangeRead(ctx, d, m)
 }
 
-func resourceNetboxAvailableIPAddressRangeDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxAvailableIPAddressRangeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	if isKeyedIPAddressAllocation(d) {
+		return resourceNetboxAvailableIPAddressRangeKeyedDelete(ctx, d, m)
+	}
+
+	api := m.(*providerState)
 
 	// Delete the primary IP (the one whose ID is stored in the resource)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamIPAddressesDeleteParams().WithID(id)
+	params := ipam.NewIpamIPAddressesDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Ipam.IpamIPAddressesDelete(params, nil)
 	if err != nil {
@@ -394,7 +439,7 @@ func resourceNetboxAvailableIPAddressRangeDelete(d *schema.ResourceData, m inter
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	
 	// Note: Additional IPs in the ip_addresses list beyond the first one
@@ -410,4 +455,325 @@ func resourceNetboxAvailableIPAddressRangeDelete(d *schema.ResourceData, m inter
 	// deleting them might not be a critical concern in this specific use case.
 	
 	return nil
-}
\ No newline at end of file
+}
+
+// isKeyedIPAddressAllocation reports whether the IP addresses are allocated
+// by the keys of `address` blocks rather than by `address_count`.
+func isKeyedIPAddressAllocation(d *schema.ResourceData) bool {
+	return d.Get("address").(*schema.Set).Len() > 0 || len(d.Get("ip_address_ids_by_key").(map[string]interface{})) > 0
+}
+
+// keyedIPAddresses returns the `address` blocks by their key.
+func keyedIPAddresses(set interface{}) map[string]map[string]interface{} {
+	addresses := make(map[string]map[string]interface{})
+	for _, item := range set.(*schema.Set).List() {
+		address := item.(map[string]interface{})
+		addresses[address["key"].(string)] = address
+	}
+	return addresses
+}
+
+func resourceNetboxAvailableIPAddressRangeCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	// Keyed IP addresses keep the prefix or IP range and the VRF they were
+	// allocated in
+	if d.Id() != "" && len(d.Get("ip_address_ids_by_key").(map[string]interface{})) > 0 {
+		for _, key := range []string{"prefix_id", "ip_range_id", "vrf_id"} {
+			if d.HasChange(key) {
+				if err := d.ForceNew(key); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if !d.HasChange("address") {
+		return nil
+	}
+	if !d.NewValueKnown("address") {
+		for _, key := range []string{"ip_addresses_by_key", "ip_address_ids_by_key", "ip_addresses"} {
+			d.SetNewComputed(key)
+		}
+		return nil
+	}
+
+	o, n := d.GetChange("address")
+	oldAddresses, newAddresses := keyedIPAddresses(o), keyedIPAddresses(n)
+	if len(newAddresses) != n.(*schema.Set).Len() {
+		return fmt.Errorf("the keys of the address blocks must be unique")
+	}
+	for key, address := range newAddresses {
+		if address["device_interface_id"].(int) != 0 && address["virtual_machine_interface_id"].(int) != 0 {
+			return fmt.Errorf("address %q: only one of device_interface_id and virtual_machine_interface_id can be set", key)
+		}
+	}
+
+	if d.Id() == "" {
+		return nil
+	}
+	if (len(oldAddresses) == 0) != (len(newAddresses) == 0) {
+		// IP addresses allocated by count have no keys to keep them by
+		return d.ForceNew("address")
+	}
+	ids := d.Get("ip_address_ids_by_key").(map[string]interface{})
+	ipAddresses := d.Get("ip_addresses_by_key").(map[string]interface{})
+	for key := range newAddresses {
+		if _, ok := ids[key]; !ok {
+			// New keys are only known after allocation
+			for _, key := range []string{"ip_addresses_by_key", "ip_address_ids_by_key", "ip_addresses"} {
+				d.SetNewComputed(key)
+			}
+			return nil
+		}
+	}
+
+	newIDs := make(map[string]interface{})
+	newIPAddresses := make(map[string]interface{})
+	var ipAddressList []interface{}
+	for _, key := range sortedKeys(newAddresses) {
+		newIDs[key] = ids[key]
+		newIPAddresses[key] = ipAddresses[key]
+		ipAddressList = append(ipAddressList, ipAddresses[key])
+	}
+	if len(newIDs) == len(ids) {
+		return nil
+	}
+	for key, value := range map[string]interface{}{
+		"ip_address_ids_by_key": newIDs,
+		"ip_addresses_by_key":   newIPAddresses,
+		"ip_addresses":          ipAddressList,
+	} {
+		if err := d.SetNew(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceNetboxAvailableIPAddressRangeKeyedCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return resourceNetboxAvailableIPAddressRangeKeyedUpdate(ctx, d, m)
+}
+
+func resourceNetboxAvailableIPAddressRangeKeyedRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+
+	ids := d.Get("ip_address_ids_by_key").(map[string]interface{})
+	ipAddresses := make(map[string]interface{})
+	var addresses []interface{}
+	var ipAddressList []string
+	var primary *models.IPAddress
+
+	for _, key := range sortedKeys(ids) {
+		params := ipam.NewIpamIPAddressesReadParamsWithContext(ctx).WithID(int64(ids[key].(int)))
+		res, err := api.Ipam.IpamIPAddressesRead(params, nil)
+		if err != nil {
+			if errresp, ok := err.(*ipam.IpamIPAddressesReadDefault); ok && errresp.Code() == 404 {
+				// The IP address was deleted outside of Terraform, so the
+				// key gets a new one on the next apply
+				delete(ids, key)
+				continue
+			}
+			return apiErrorDiagnostics(d, err)
+		}
+
+		ipAddress := res.GetPayload()
+		if primary == nil {
+			primary = ipAddress
+		}
+		ipAddresses[key] = *ipAddress.Address
+		ipAddressList = append(ipAddressList, *ipAddress.Address)
+
+		address := map[string]interface{}{
+			"key":         key,
+			"dns_name":    ipAddress.DNSName,
+			"description": ipAddress.Description,
+		}
+		if ipAddress.AssignedObjectID != nil && ipAddress.AssignedObjectType != nil {
+			switch *ipAddress.AssignedObjectType {
+			case "dcim.interface":
+				address["device_interface_id"] = *ipAddress.AssignedObjectID
+			case "virtualization.vminterface":
+				address["virtual_machine_interface_id"] = *ipAddress.AssignedObjectID
+			}
+		}
+		addresses = append(addresses, address)
+	}
+
+	if len(ids) == 0 {
+		// All IP addresses were deleted outside of Terraform
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("ip_address_ids_by_key", ids)
+	d.Set("ip_addresses_by_key", ipAddresses)
+	d.Set("address", addresses)
+	d.Set("ip_addresses", ipAddressList)
+
+	// The attributes shared by all IP addresses are read from the first one
+	if primary.Vrf != nil {
+		d.Set("vrf_id", primary.Vrf.ID)
+	} else {
+		d.Set("vrf_id", nil)
+	}
+	if primary.Tenant != nil {
+		d.Set("tenant_id", primary.Tenant.ID)
+	} else {
+		d.Set("tenant_id", nil)
+	}
+	if primary.Role != nil {
+		d.Set("role", primary.Role.Value)
+	} else {
+		d.Set("role", nil)
+	}
+	d.Set("status", primary.Status.Value)
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(primary.Tags)))
+	d.Set(customFieldsKey, withoutDefaultCustomFields(api, d, getCustomFields(ctx, api, primary.CustomFields)))
+
+	return nil
+}
+
+func resourceNetboxAvailableIPAddressRangeKeyedUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+
+	o, n := d.GetChange("address")
+	oldAddresses, addresses := keyedIPAddresses(o), keyedIPAddresses(n)
+	// The allocations are only known once they are made, so they are taken
+	// from the prior state
+	oldIDs, _ := d.GetChange("ip_address_ids_by_key")
+	oldIPAddresses, _ := d.GetChange("ip_addresses_by_key")
+	ids := oldIDs.(map[string]interface{})
+	ipAddresses := oldIPAddresses.(map[string]interface{})
+
+	// The state keeps track of the allocated IP addresses even if a later
+	// step fails
+	saveAllocations := func() {
+		d.Set("ip_address_ids_by_key", ids)
+		d.Set("ip_addresses_by_key", ipAddresses)
+	}
+
+	// Release the IP addresses of removed keys
+	for _, key := range sortedKeys(ids) {
+		if _, ok := addresses[key]; ok {
+			continue
+		}
+		params := ipam.NewIpamIPAddressesDeleteParamsWithContext(ctx).WithID(int64(ids[key].(int)))
+		if _, err := api.Ipam.IpamIPAddressesDelete(params, nil); err != nil {
+			if errresp, ok := err.(*ipam.IpamIPAddressesDeleteDefault); !ok || errresp.Code() != 404 {
+				saveAllocations()
+				return apiErrorDiagnostics(d, err)
+			}
+		}
+		delete(ids, key)
+		delete(ipAddresses, key)
+	}
+	saveAllocations()
+
+	// Allocate IP addresses for new keys in a single request
+	var newKeys []string
+	for _, key := range sortedKeys(addresses) {
+		if _, ok := ids[key]; !ok {
+			newKeys = append(newKeys, key)
+		}
+	}
+	if len(newKeys) > 0 {
+		var availableIPs []*models.AvailableIP
+		for range newKeys {
+			data := models.AvailableIP{}
+			if vrfID := int64(d.Get("vrf_id").(int)); vrfID != 0 {
+				data.Vrf = &models.NestedVRF{ID: vrfID}
+			}
+			availableIPs = append(availableIPs, &data)
+		}
+
+		timeout := d.Timeout(schema.TimeoutUpdate)
+		if d.IsNewResource() {
+			timeout = d.Timeout(schema.TimeoutCreate)
+		}
+		res, err := allocateAvailableIPs(ctx, api, timeout, int64(d.Get("prefix_id").(int)), int64(d.Get("ip_range_id").(int)), availableIPs)
+		if err != nil {
+			return apiErrorDiagnostics(d, err)
+		}
+		for i, key := range newKeys {
+			ids[key] = int(res[i].ID)
+			ipAddresses[key] = *res[i].Address
+		}
+		saveAllocations()
+	}
+
+	if d.Id() == "" {
+		// The resource ID is the ID of the first IP address at creation
+		d.SetId(strconv.Itoa(ids[sortedKeys(ids)[0]].(int)))
+	}
+
+	tags, diags := getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
+	if diags != nil {
+		return diags
+	}
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "ipam.ipaddress")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
+	}
+
+	// Only IP addresses of new or changed keys are updated, unless the
+	// attributes shared by all of them changed
+	updateAll := d.IsNewResource() || d.HasChanges("status", "role", "tenant_id", tagsKey, customFieldsKey)
+	for _, key := range sortedKeys(addresses) {
+		address := addresses[key]
+		if !updateAll && !slices.Contains(newKeys, key) && reflect.DeepEqual(oldAddresses[key], address) {
+			continue
+		}
+
+		// Setting a space string clears a value that was removed
+		optionalStr := func(field string) string {
+			if value := address[field].(string); value != "" || oldAddresses[key][field] == nil || oldAddresses[key][field] == "" {
+				return value
+			}
+			return " "
+		}
+
+		data := models.WritableIPAddress{
+			Address:            strToPtr(ipAddresses[key].(string)),
+			Status:             d.Get("status").(string),
+			Role:               getOptionalStr(d, "role", false),
+			Vrf:                getOptionalInt(d, "vrf_id"),
+			Tenant:             getOptionalInt(d, "tenant_id"),
+			DNSName:            optionalStr("dns_name"),
+			Description:        optionalStr("description"),
+			AssignedObjectType: strToPtr(""),
+			Tags:               tags,
+			CustomFields:       cf,
+		}
+		if id := address["device_interface_id"].(int); id != 0 {
+			data.AssignedObjectType = strToPtr("dcim.interface")
+			data.AssignedObjectID = int64ToPtr(int64(id))
+		}
+		if id := address["virtual_machine_interface_id"].(int); id != 0 {
+			data.AssignedObjectType = strToPtr("virtualization.vminterface")
+			data.AssignedObjectID = int64ToPtr(int64(id))
+		}
+
+		params := ipam.NewIpamIPAddressesUpdateParamsWithContext(ctx).WithID(int64(ids[key].(int))).WithData(&data)
+		if _, err := api.Ipam.IpamIPAddressesUpdate(params, nil); err != nil {
+			return apiErrorDiagnostics(d, err)
+		}
+	}
+
+	return resourceNetboxAvailableIPAddressRangeKeyedRead(ctx, d, m)
+}
+
+func resourceNetboxAvailableIPAddressRangeKeyedDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+
+	ids := d.Get("ip_address_ids_by_key").(map[string]interface{})
+	for _, key := range sortedKeys(ids) {
+		params := ipam.NewIpamIPAddressesDeleteParamsWithContext(ctx).WithID(int64(ids[key].(int)))
+		if _, err := api.Ipam.IpamIPAddressesDelete(params, nil); err != nil {
+			if errresp, ok := err.(*ipam.IpamIPAddressesDeleteDefault); !ok || errresp.Code() != 404 {
+				return apiErrorDiagnostics(d, err)
+			}
+		}
+		delete(ids, key)
+		d.Set("ip_address_ids_by_key", ids)
+	}
+	return nil
+}
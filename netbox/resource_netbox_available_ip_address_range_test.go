@@ -1,14 +1,21 @@
 package netbox
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccNetboxAvailableIPAddressRange_basic_prefix(t *testing.T) {
@@ -225,7 +232,7 @@ func init() {
 			if err != nil {
 				return fmt.Errorf("Error getting client: %s", err)
 			}
-			api := m.(*client.NetBoxAPI)
+			api := m.(*providerState)
 			params := ipam.NewIpamIPAddressesListParams()
 			res, err := api.Ipam.IpamIPAddressesList(params, nil)
 			if err != nil {
@@ -244,4 +251,214 @@ func init() {
 			return nil
 		},
 	})
-}
\ No newline at end of file
+}
+
+func TestAccNetboxAvailableIPAddressRange_keyed(t *testing.T) {
+	testPrefix := "2.2.7.0/24"
+	config := func(keys string) string {
+		return fmt.Sprintf(`
+resource "netbox_prefix" "test" {
+  prefix = "%s"
+  status = "active"
+}
+resource "netbox_available_ip_addresses" "test" {
+  prefix_id = netbox_prefix.test.id
+  status = "active"
+
+  dynamic "address" {
+    for_each = toset(%s)
+    content {
+      key = address.value
+      dns_name = "${address.value}.mydomain.local"
+    }
+  }
+}`, testPrefix, keys)
+	}
+	var before map[string]string
+	resource.ParallelTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`["web01", "web02", "web03"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_available_ip_addresses.test", "ip_addresses_by_key.%", "3"),
+					resource.TestCheckResourceAttr("netbox_available_ip_addresses.test", "ip_addresses.#", "3"),
+					func(s *terraform.State) error {
+						before = s.RootModule().Resources["netbox_available_ip_addresses.test"].Primary.Attributes
+						return nil
+					},
+				),
+			},
+			{
+				Config: config(`["web01", "web03", "web04"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_available_ip_addresses.test", "ip_addresses_by_key.%", "3"),
+					resource.TestCheckResourceAttrSet("netbox_available_ip_addresses.test", "ip_addresses_by_key.web04"),
+					resource.TestCheckNoResourceAttr("netbox_available_ip_addresses.test", "ip_addresses_by_key.web02"),
+					func(s *terraform.State) error {
+						after := s.RootModule().Resources["netbox_available_ip_addresses.test"].Primary.Attributes
+						for _, key := range []string{"web01", "web03"} {
+							if before["ip_addresses_by_key."+key] != after["ip_addresses_by_key."+key] {
+								return fmt.Errorf("IP address of %s changed from %s to %s", key, before["ip_addresses_by_key."+key], after["ip_addresses_by_key."+key])
+							}
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// fakeIPAddressServer is a minimal Netbox serving the endpoints used by keyed
+// IP address allocation, counting the requests sent per method.
+type fakeIPAddressServer struct {
+	addresses map[int64]map[string]interface{}
+	requests  map[string]int
+}
+
+func (f *fakeIPAddressServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.requests[r.Method]++
+	w.Header().Set("Content-Type", "application/json")
+
+	if r.URL.Path == "/api/ipam/prefixes/1/available-ips/" {
+		var requested []interface{}
+		json.NewDecoder(r.Body).Decode(&requested)
+		var created []interface{}
+		for id := int64(1); len(created) < len(requested); id++ {
+			if _, ok := f.addresses[id]; !ok {
+				f.addresses[id] = map[string]interface{}{"id": id, "address": fmt.Sprintf("10.0.0.%d/24", id), "status": map[string]interface{}{"value": "active"}}
+				created = append(created, f.addresses[id])
+			}
+		}
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(created)
+		return
+	}
+
+	id, _ := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/ipam/ip-addresses/"), "/"), 10, 64)
+	address, ok := f.addresses[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"detail": "Not found."}`)
+		return
+	}
+	switch r.Method {
+	case http.MethodPut:
+		var data map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&data)
+		for _, field := range []string{"dns_name", "description", "assigned_object_type", "assigned_object_id"} {
+			address[field] = data[field]
+		}
+		address["status"] = map[string]interface{}{"value": data["status"]}
+	case http.MethodDelete:
+		delete(f.addresses, id)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	json.NewEncoder(w).Encode(address)
+}
+
+func TestAvailableIPAddressRangeKeyed(t *testing.T) {
+	fake := &fakeIPAddressServer{addresses: map[int64]map[string]interface{}{}, requests: map[string]int{}}
+	ts := httptest.NewServer(fake)
+	defer ts.Close()
+
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: ts.URL,
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+	api := newProviderState(client)
+
+	r := resourceNetboxAvailableIPAddressRange()
+	apply := func(state *terraform.InstanceState, addresses ...map[string]interface{}) *terraform.InstanceState {
+		var blocks []interface{}
+		for _, address := range addresses {
+			blocks = append(blocks, address)
+		}
+		c := terraform.NewResourceConfigRaw(map[string]interface{}{
+			"prefix_id": 1,
+			"address":   blocks,
+		})
+		diff, err := r.Diff(context.Background(), state, c, api)
+		assert.NoError(t, err)
+		newState, diags := r.Apply(context.Background(), state, diff, api)
+		assert.False(t, diags.HasError(), "%v", diags)
+		return newState
+	}
+
+	state := apply(nil,
+		map[string]interface{}{"key": "web01"},
+		map[string]interface{}{"key": "web02"},
+		map[string]interface{}{"key": "web03"},
+	)
+	assert.Equal(t, "10.0.0.1/24", state.Attributes["ip_addresses_by_key.web01"])
+	assert.Equal(t, "10.0.0.2/24", state.Attributes["ip_addresses_by_key.web02"])
+	assert.Equal(t, "10.0.0.3/24", state.Attributes["ip_addresses_by_key.web03"])
+	assert.Equal(t, "10.0.0.1/24", state.Attributes["ip_addresses.0"])
+	assert.Equal(t, "1", state.ID)
+
+	// Removing a key releases its address and adding one allocates a single
+	// address, leaving the others untouched
+	fake.requests = map[string]int{}
+	state = apply(state,
+		map[string]interface{}{"key": "web01"},
+		map[string]interface{}{"key": "web03"},
+		map[string]interface{}{"key": "web04", "dns_name": "web04.example.com"},
+	)
+	assert.Equal(t, "3", state.Attributes["ip_addresses_by_key.%"])
+	assert.Equal(t, "10.0.0.1/24", state.Attributes["ip_addresses_by_key.web01"])
+	assert.Equal(t, "10.0.0.3/24", state.Attributes["ip_addresses_by_key.web03"])
+	assert.Equal(t, "10.0.0.2/24", state.Attributes["ip_addresses_by_key.web04"])
+	assert.Equal(t, "web04.example.com", fake.addresses[2]["dns_name"])
+	assert.Equal(t, 1, fake.requests[http.MethodDelete])
+	assert.Equal(t, 1, fake.requests[http.MethodPost])
+	assert.Equal(t, 1, fake.requests[http.MethodPut])
+	assert.Len(t, fake.addresses, 3)
+
+	// Changing a key only updates its address
+	fake.requests = map[string]int{}
+	state = apply(state,
+		map[string]interface{}{"key": "web01", "description": "Web server"},
+		map[string]interface{}{"key": "web04", "dns_name": "web04.example.com"},
+	)
+	assert.Equal(t, "2", state.Attributes["ip_addresses_by_key.%"])
+	assert.Equal(t, "10.0.0.1/24", state.Attributes["ip_addresses_by_key.web01"])
+	assert.Equal(t, "10.0.0.2/24", state.Attributes["ip_addresses_by_key.web04"])
+	assert.Equal(t, "Web server", fake.addresses[1]["description"])
+	assert.Equal(t, 1, fake.requests[http.MethodDelete])
+	assert.Equal(t, 0, fake.requests[http.MethodPost])
+	assert.Equal(t, 1, fake.requests[http.MethodPut])
+	assert.Len(t, fake.addresses, 2)
+
+	// Changing only the prefix or the VRF allocates all addresses again
+	for _, changed := range []map[string]interface{}{
+		{"prefix_id": 2},
+		{"prefix_id": 1, "vrf_id": 3},
+	} {
+		changed["address"] = []interface{}{
+			map[string]interface{}{"key": "web01", "description": "Web server"},
+			map[string]interface{}{"key": "web04", "dns_name": "web04.example.com"},
+		}
+		diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(changed), api)
+		if assert.NoError(t, err) && assert.NotNil(t, diff, "%v", changed) {
+			assert.True(t, diff.RequiresNew(), "%v", changed)
+		}
+	}
+
+	// IP addresses allocated by count are not replaced
+	countState := &terraform.InstanceState{ID: "1", Attributes: map[string]string{
+		"id":            "1",
+		"prefix_id":     "1",
+		"address_count": "1",
+	}}
+	diff, err := r.Diff(context.Background(), countState, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"prefix_id":     2,
+		"address_count": 1,
+	}), api)
+	if assert.NoError(t, err) && assert.NotNil(t, diff) {
+		assert.False(t, diff.RequiresNew())
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"regexp"
 	"testing"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -69,6 +68,58 @@ resource "netbox_available_ip_address" "test_range" {
 	})
 }
 
+func TestAccNetboxAvailableIPAddress_prefixSelector(t *testing.T) {
+	testSlug := "avail_ip_sel"
+	testName := testAccGetTestName(testSlug)
+	resource.ParallelTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "netbox_ipam_role" "test" {
+  name = "%[1]s"
+}
+
+resource "netbox_prefix" "full" {
+  prefix = "1.1.7.0/31"
+  status = "active"
+  role_id = netbox_ipam_role.test.id
+}
+
+resource "netbox_ip_address" "taken1" {
+  ip_address = "1.1.7.0/31"
+  status = "active"
+  depends_on = [netbox_prefix.full]
+}
+
+resource "netbox_ip_address" "taken2" {
+  ip_address = "1.1.7.1/31"
+  status = "active"
+  depends_on = [netbox_prefix.full]
+}
+
+resource "netbox_prefix" "free" {
+  prefix = "1.1.8.0/24"
+  status = "active"
+  role_id = netbox_ipam_role.test.id
+}
+
+resource "netbox_available_ip_address" "test" {
+  prefix_selector {
+    role_id = netbox_ipam_role.test.id
+  }
+  status = "active"
+  depends_on = [netbox_ip_address.taken1, netbox_ip_address.taken2, netbox_prefix.free]
+}`, testName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_available_ip_address.test", "ip_address", "1.1.8.1/24"),
+					resource.TestCheckResourceAttrPair("netbox_available_ip_address.test", "selected_prefix_id", "netbox_prefix.free", "id"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccNetboxAvailableIPAddress_multipleIpsParallel(t *testing.T) {
 	testPrefix := "1.1.3.0/24"
 	resource.ParallelTest(t, resource.TestCase{
@@ -294,7 +345,7 @@ func init() {
 			if err != nil {
 				return fmt.Errorf("Error getting client: %s", err)
 			}
-			api := m.(*client.NetBoxAPI)
+			api := m.(*providerState)
 			params := ipam.NewIpamIPAddressesListParams()
 			res, err := api.Ipam.IpamIPAddressesList(params, nil)
 			if err != nil {
@@ -6,27 +6,33 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
-	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxAvailablePrefix() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxAvailablePrefixCreate,
-		Read:   resourceNetboxPrefixRead,
-		Update: resourceNetboxPrefixUpdate,
-		Delete: resourceNetboxPrefixDelete,
+		CreateContext: resourceNetboxAvailablePrefixCreate,
+		ReadContext:   resourceNetboxAvailablePrefixRead,
+		UpdateContext: resourceNetboxAvailablePrefixUpdate,
+		DeleteContext: resourceNetboxPrefixDelete,
 
 		Description: `:meta:subcategory:IP Address Management (IPAM):`,
 
 		Schema: map[string]*schema.Schema{
 			"parent_prefix_id": {
-				Type:     schema.TypeInt,
-				Required: true,
-				ForceNew: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"parent_prefix_id", "prefix_selector"},
+			},
+			"prefix_selector": prefixSelectorSchema([]string{"parent_prefix_id", "prefix_selector"}),
+			"selected_parent_prefix_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The ID of the prefix the prefix was allocated from.",
 			},
 			"prefix_length": {
 				Type:         schema.TypeInt,
@@ -114,24 +120,64 @@ func resourceNetboxAvailablePrefixParseImport(importStr string) (int, string, in
 	return parentID, parts[1], prefixLength, nil
 }
 
-func resourceNetboxAvailablePrefixCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxAvailablePrefixCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	parentPrefixID := int64(d.Get("parent_prefix_id").(int))
 	prefixLength := int64(d.Get("prefix_length").(int))
 	data := models.PrefixLength{
 		PrefixLength: &prefixLength,
 	}
-	params := ipam.NewIpamPrefixesAvailablePrefixesCreateParams().WithID(parentPrefixID).WithData(&data)
 
-	res, err := api.Ipam.IpamPrefixesAvailablePrefixesCreate(params, nil)
+	var payload *models.Prefix
+	var err error
+	if selectors := d.Get("prefix_selector").([]interface{}); len(selectors) > 0 {
+		selector, _ := selectors[0].(map[string]interface{})
+		parentPrefixID, err = allocateFromPrefixSelector(ctx, api, selector, fmt.Sprintf("/%d prefixes", prefixLength), 1, func(candidateID int64) error {
+			payload, err = allocateAvailablePrefix(ctx, api, d.Timeout(schema.TimeoutCreate), candidateID, &data)
+			return err
+		})
+	} else {
+		payload, err = allocateAvailablePrefix(ctx, api, d.Timeout(schema.TimeoutCreate), parentPrefixID, &data)
+	}
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	payload := res.GetPayload()
 	d.SetId(strconv.FormatInt(payload.ID, 10))
+	d.Set("selected_parent_prefix_id", parentPrefixID)
 	d.Set("prefix", payload.Prefix)
 
-	return resourceNetboxPrefixUpdate(d, m)
+	return readSelectedParentPrefix(ctx, d, m, resourceNetboxPrefixUpdate(ctx, d, m))
+}
+
+func resourceNetboxAvailablePrefixRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return readSelectedParentPrefix(ctx, d, m, resourceNetboxPrefixRead(ctx, d, m))
+}
+
+func resourceNetboxAvailablePrefixUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return readSelectedParentPrefix(ctx, d, m, resourceNetboxPrefixUpdate(ctx, d, m))
+}
+
+// readSelectedParentPrefix sets selected_parent_prefix_id to the prefix the
+// prefix lies in, unless diags, the result of reading or updating the prefix
+// itself, already carry an error.
+func readSelectedParentPrefix(ctx context.Context, d *schema.ResourceData, m interface{}, diags diag.Diagnostics) diag.Diagnostics {
+	if diags.HasError() || d.Id() == "" {
+		return diags
+	}
+	api := m.(*providerState)
+
+	id, _ := strconv.ParseInt(d.Id(), 10, 64)
+	currentID := int64(d.Get("selected_parent_prefix_id").(int))
+	if currentID == 0 {
+		currentID = int64(d.Get("parent_prefix_id").(int))
+	}
+	parentPrefixID, err := allocatedFromPrefixID(ctx, api, d.Get("prefix").(string), int64(d.Get("vrf_id").(int)), id, currentID)
+	if err != nil {
+		return append(diags, apiErrorDiagnostics(d, err)...)
+	}
+	d.Set("selected_parent_prefix_id", parentPrefixID)
+
+	return diags
 }
@@ -5,7 +5,6 @@ import (
 	"regexp"
 	"testing"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -166,6 +165,72 @@ resource "netbox_available_prefix" "test3" {
 	})
 }
 
+func TestAccNetboxAvailablePrefix_prefixSelector(t *testing.T) {
+	testSlug := "prefix_sel"
+	testName := testAccGetTestName(testSlug)
+	resource.ParallelTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "netbox_tag" "test" {
+  name = "%[1]s"
+}
+
+resource "netbox_prefix" "parent1" {
+  prefix = "1.1.5.0/24"
+  status = "container"
+  tags = [netbox_tag.test.name]
+}
+
+resource "netbox_prefix" "parent2" {
+  prefix = "1.1.6.0/24"
+  status = "container"
+  tags = [netbox_tag.test.name]
+}
+
+resource "netbox_available_prefix" "test1" {
+  prefix_selector {
+    tags = [netbox_tag.test.name]
+    family = 4
+  }
+  prefix_length = 25
+  status = "active"
+  depends_on = [netbox_prefix.parent1, netbox_prefix.parent2]
+}
+
+resource "netbox_available_prefix" "test2" {
+  prefix_selector {
+    tags = [netbox_tag.test.name]
+    family = 4
+  }
+  prefix_length = 25
+  status = "active"
+  depends_on = [netbox_available_prefix.test1]
+}
+
+resource "netbox_available_prefix" "test3" {
+  prefix_selector {
+    tags = [netbox_tag.test.name]
+    family = 4
+  }
+  prefix_length = 25
+  status = "active"
+  depends_on = [netbox_available_prefix.test2]
+}`, testName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_available_prefix.test1", "prefix", "1.1.5.0/25"),
+					resource.TestCheckResourceAttrPair("netbox_available_prefix.test1", "selected_parent_prefix_id", "netbox_prefix.parent1", "id"),
+					resource.TestCheckResourceAttr("netbox_available_prefix.test2", "prefix", "1.1.5.128/25"),
+					resource.TestCheckResourceAttrPair("netbox_available_prefix.test2", "selected_parent_prefix_id", "netbox_prefix.parent1", "id"),
+					resource.TestCheckResourceAttr("netbox_available_prefix.test3", "prefix", "1.1.6.0/25"),
+					resource.TestCheckResourceAttrPair("netbox_available_prefix.test3", "selected_parent_prefix_id", "netbox_prefix.parent2", "id"),
+				),
+			},
+		},
+	})
+}
+
 func init() {
 	resource.AddTestSweepers("netbox_available_prefix", &resource.Sweeper{
 		Name:         "netbox_available_prefix",
@@ -175,7 +240,7 @@ func init() {
 			if err != nil {
 				return fmt.Errorf("Error getting client: %s", err)
 			}
-			api := m.(*client.NetBoxAPI)
+			api := m.(*providerState)
 			params := ipam.NewIpamPrefixesListParams()
 			res, err := api.Ipam.IpamPrefixesList(params, nil)
 			if err != nil {
@@ -0,0 +1,104 @@
+package netbox
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceNetboxAvailableVlan() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetboxAvailableVlanCreate,
+		ReadContext:   resourceNetboxVlanRead,
+		UpdateContext: resourceNetboxVlanUpdate,
+		DeleteContext: resourceNetboxVlanDelete,
+
+		Description: `:meta:subcategory:IP Address Management (IPAM):From the [official documentation](https://docs.netbox.dev/en/stable/features/vlans/#vlans):
+
+> A VLAN represents an isolated layer two domain, identified by a name and a numeric ID (1-4094) as defined in IEEE 802.1Q. VLANs are arranged into VLAN groups to define scope and to enforce uniqueness.
+
+This resource will create a VLAN with the next available VID of a given VLAN group. If the chosen VID is taken by a concurrent allocation, the allocation is retried.`,
+
+		Schema: map[string]*schema.Schema{
+			"group_id": {
+				Type:     schema.TypeInt,
+				Required: true,
+				ForceNew: true,
+			},
+			"vid": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "active",
+				ValidateFunc: validation.StringInSlice(resourceNetboxVlanStatusOptions, false),
+				Description:  buildValidValueDescription(resourceNetboxVlanStatusOptions),
+			},
+			"tenant_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"role_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"site_id": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "",
+			},
+			tagsKey: tagsSchema,
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importByNaturalKey("ipam/vlans", false),
+		},
+	}
+}
+
+func resourceNetboxAvailableVlanCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+	data := models.WritableCreateAvailableVLAN{}
+
+	groupID := int64(d.Get("group_id").(int))
+	name := d.Get("name").(string)
+
+	data.Name = &name
+	data.Status = d.Get("status").(string)
+	data.Description = d.Get("description").(string)
+
+	if siteID, ok := d.GetOk("site_id"); ok {
+		data.Site = int64ToPtr(int64(siteID.(int)))
+	}
+
+	if tenantID, ok := d.GetOk("tenant_id"); ok {
+		data.Tenant = int64ToPtr(int64(tenantID.(int)))
+	}
+
+	if roleID, ok := d.GetOk("role_id"); ok {
+		data.Role = int64ToPtr(int64(roleID.(int)))
+	}
+
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
+
+	vlan, err := allocateAvailableVLAN(ctx, api, d.Timeout(schema.TimeoutCreate), groupID, &data)
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
+	}
+	d.SetId(strconv.FormatInt(vlan.ID, 10))
+
+	return resourceNetboxVlanRead(ctx, d, m)
+}
@@ -0,0 +1,106 @@
+package netbox
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func testAccNetboxAvailableVlanFullDependencies(testName string) string {
+	return fmt.Sprintf(`
+resource "netbox_tag" "test" {
+  name = "%[1]s"
+}
+
+resource "netbox_vlan_group" "test" {
+  name       = "%[1]s"
+  slug       = "%[1]s"
+  vid_ranges = [[101, 102]]
+}
+
+resource "netbox_vlan" "taken" {
+  name     = "%[1]s_taken"
+  vid      = 101
+  group_id = netbox_vlan_group.test.id
+}
+`, testName)
+}
+
+func TestAccNetboxAvailableVlan_basic(t *testing.T) {
+	testSlug := "avail_vlan_basic"
+	testName := testAccGetTestName(testSlug)
+	resource.ParallelTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetboxAvailableVlanFullDependencies(testName) + fmt.Sprintf(`
+resource "netbox_available_vlan" "test" {
+  name        = "%[1]s"
+  group_id    = netbox_vlan_group.test.id
+  description = "test description"
+  tags        = [netbox_tag.test.name]
+  depends_on  = [netbox_vlan.taken]
+}`, testName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_available_vlan.test", "name", testName),
+					resource.TestCheckResourceAttr("netbox_available_vlan.test", "vid", "102"),
+					resource.TestCheckResourceAttr("netbox_available_vlan.test", "status", "active"),
+					resource.TestCheckResourceAttr("netbox_available_vlan.test", "description", "test description"),
+					resource.TestCheckResourceAttr("netbox_available_vlan.test", "tags.#", "1"),
+					resource.TestCheckResourceAttr("netbox_available_vlan.test", "tags.0", testName),
+					resource.TestCheckResourceAttrPair("netbox_available_vlan.test", "group_id", "netbox_vlan_group.test", "id"),
+				),
+			},
+			{
+				Config: testAccNetboxAvailableVlanFullDependencies(testName) + fmt.Sprintf(`
+resource "netbox_available_vlan" "test" {
+  name        = "%[1]s"
+  group_id    = netbox_vlan_group.test.id
+  status      = "reserved"
+  description = "updated description"
+  depends_on  = [netbox_vlan.taken]
+}`, testName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_available_vlan.test", "vid", "102"),
+					resource.TestCheckResourceAttr("netbox_available_vlan.test", "status", "reserved"),
+					resource.TestCheckResourceAttr("netbox_available_vlan.test", "description", "updated description"),
+					resource.TestCheckResourceAttr("netbox_available_vlan.test", "tags.#", "0"),
+				),
+			},
+			{
+				ResourceName:      "netbox_available_vlan.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccNetboxAvailableVlan_exhausted(t *testing.T) {
+	testSlug := "avail_vlan_exh"
+	testName := testAccGetTestName(testSlug)
+	resource.ParallelTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNetboxAvailableVlanFullDependencies(testName) + fmt.Sprintf(`
+resource "netbox_available_vlan" "test1" {
+  name       = "%[1]s_1"
+  group_id   = netbox_vlan_group.test.id
+  depends_on = [netbox_vlan.taken]
+}
+
+resource "netbox_available_vlan" "test2" {
+  name       = "%[1]s_2"
+  group_id   = netbox_vlan_group.test.id
+  depends_on = [netbox_available_vlan.test1]
+}`, testName),
+				ExpectError: regexp.MustCompile("Netbox VLAN group with ID [0-9]+ is exhausted"),
+			},
+		},
+	})
+}
@@ -0,0 +1,197 @@
+package netbox
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceNetboxBranch() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetboxBranchCreate,
+		ReadContext:   resourceNetboxBranchRead,
+		UpdateContext: resourceNetboxBranchUpdate,
+		DeleteContext: resourceNetboxBranchDelete,
+
+		Description: `:meta:subcategory:Extras:From the [official documentation](https://docs.netboxlabs.com/netbox-extensions/branching/):
+
+> Branching is a NetBox plugin that enables users to create isolated copies of the NetBox database, make changes within them, and merge these changes back into the main database.
+
+This resource requires the netbox-branching plugin. Together with the ` + "`branch`" + ` argument of a second provider configuration, it allows applying changes to a branch first and merging them into main later.`,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"sync_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Changing this value synchronizes the branch with the changes made in main since the branch was created or last synchronized.",
+			},
+			"merge": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If true, the changes of the branch are merged into main. Setting this back to `false` reverts the merge.",
+			},
+			"schema_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The schema ID of the branch, which can be used as the `branch` argument of the provider.",
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: importByNaturalKey(branchesPath, false),
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+		// The netbox-branching plugin supports Netbox 4.1 and later
+		CustomizeDiff: requireNetboxVersion("netbox_branch", "4.1.0", ""),
+	}
+}
+
+func resourceNetboxBranchCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+
+	body, err := json.Marshal(map[string]interface{}{
+		"name":        d.Get("name").(string),
+		"description": d.Get("description").(string),
+	})
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
+	}
+
+	res, err := genericAPIRequest(ctx, api, http.MethodPost, genericAPIPath(branchesPath), nil, body)
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
+	}
+	var branch netboxBranch
+	if err := json.Unmarshal(res, &branch); err != nil {
+		return apiErrorDiagnostics(d, err)
+	}
+
+	d.SetId(strconv.FormatInt(branch.ID, 10))
+
+	_, err = waitForBranchStatus(ctx, api, branch.ID, []string{branchStatusNew, branchStatusProvisioning}, []string{branchStatusReady}, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
+	}
+
+	if d.Get("merge").(bool) {
+		if err := resourceNetboxBranchAction(ctx, d, api, "merge", schema.TimeoutCreate); err != nil {
+			return apiErrorDiagnostics(d, err)
+		}
+	}
+
+	return resourceNetboxBranchRead(ctx, d, m)
+}
+
+func resourceNetboxBranchRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+	id, _ := strconv.ParseInt(d.Id(), 10, 64)
+
+	branch, err := getBranch(ctx, api, id)
+	if err != nil {
+		if errresp, ok := err.(*genericAPIError); ok {
+			if errresp.Code() == 404 {
+				d.SetId("")
+				return nil
+			}
+		}
+		return apiErrorDiagnostics(d, err)
+	}
+
+	d.Set("name", branch.Name)
+	d.Set("description", branch.Description)
+	d.Set("schema_id", branch.SchemaID)
+	d.Set("status", branch.Status.Value)
+	d.Set("merge", branch.Status.Value == branchStatusMerged)
+
+	return nil
+}
+
+func resourceNetboxBranchUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+
+	if d.HasChanges("name", "description") {
+		body, err := json.Marshal(map[string]interface{}{
+			"name":        d.Get("name").(string),
+			"description": d.Get("description").(string),
+		})
+		if err != nil {
+			return apiErrorDiagnostics(d, err)
+		}
+		_, err = genericAPIRequest(ctx, api, http.MethodPatch, genericAPIPath(branchesPath, d.Id()), nil, body)
+		if err != nil {
+			return apiErrorDiagnostics(d, err)
+		}
+	}
+
+	if d.HasChange("sync_trigger") && !d.Get("merge").(bool) {
+		if err := resourceNetboxBranchAction(ctx, d, api, "sync", schema.TimeoutUpdate); err != nil {
+			return apiErrorDiagnostics(d, err)
+		}
+	}
+
+	if d.HasChange("merge") {
+		var err error
+		if d.Get("merge").(bool) {
+			err = resourceNetboxBranchAction(ctx, d, api, "merge", schema.TimeoutUpdate)
+		} else {
+			err = resourceNetboxBranchAction(ctx, d, api, "revert", schema.TimeoutUpdate)
+		}
+		if err != nil {
+			return apiErrorDiagnostics(d, err)
+		}
+	}
+
+	return resourceNetboxBranchRead(ctx, d, m)
+}
+
+func resourceNetboxBranchDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+
+	_, err := genericAPIRequest(ctx, api, http.MethodDelete, genericAPIPath(branchesPath, d.Id()), nil, nil)
+	if err != nil {
+		if errresp, ok := err.(*genericAPIError); ok {
+			if errresp.Code() == 404 {
+				d.SetId("")
+				return nil
+			}
+		}
+		return apiErrorDiagnostics(d, err)
+	}
+	return nil
+}
+
+// resourceNetboxBranchAction runs a sync, merge or revert of the branch and
+// waits for the background job doing it to complete.
+func resourceNetboxBranchAction(ctx context.Context, d *schema.ResourceData, api *providerState, action, timeout string) error {
+	res, err := genericAPIRequest(ctx, api, http.MethodPost, genericAPIPath(branchesPath, d.Id(), action), nil, json.RawMessage(`{"commit": true}`))
+	if err != nil {
+		return err
+	}
+
+	var job netboxJob
+	if err := json.Unmarshal(res, &job); err != nil {
+		return err
+	}
+
+	return waitForJob(ctx, api, job.ID, d.Timeout(timeout))
+}
@@ -0,0 +1,69 @@
+package netbox
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccNetboxBranch_basic(t *testing.T) {
+	// The netbox-branching plugin is not part of the default test setup
+	if os.Getenv("NETBOX_TEST_BRANCHING") == "" {
+		t.Skip("NETBOX_TEST_BRANCHING not set")
+	}
+
+	testSlug := "branch"
+	testName := testAccGetTestName(testSlug)
+	resource.ParallelTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "netbox_branch" "test" {
+  name        = "%s"
+  description = "test"
+}`, testName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_branch.test", "name", testName),
+					resource.TestCheckResourceAttr("netbox_branch.test", "status", "ready"),
+					resource.TestCheckResourceAttr("netbox_branch.test", "merge", "false"),
+					resource.TestCheckResourceAttrSet("netbox_branch.test", "schema_id"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "netbox_branch" "test" {
+  name         = "%s"
+  description  = "test"
+  sync_trigger = "1"
+}`, testName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_branch.test", "status", "ready"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "netbox_branch" "test" {
+  name         = "%s"
+  description  = "test"
+  sync_trigger = "1"
+  merge        = true
+}`, testName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("netbox_branch.test", "status", "merged"),
+					resource.TestCheckResourceAttr("netbox_branch.test", "merge", "true"),
+				),
+			},
+			{
+				ResourceName:            "netbox_branch.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateId:           fmt.Sprintf("name=%s", testName),
+				ImportStateVerifyIgnore: []string{"sync_trigger"},
+			},
+		},
+	})
+}
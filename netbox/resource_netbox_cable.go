@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxCable() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxCableCreate,
-		Read:   resourceNetboxCableRead,
-		Update: resourceNetboxCableUpdate,
-		Delete: resourceNetboxCableDelete,
+		CreateContext: resourceNetboxCableCreate,
+		ReadContext:   resourceNetboxCableRead,
+		UpdateContext: resourceNetboxCableUpdate,
+		DeleteContext: resourceNetboxCableDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/cable/):
 
@@ -84,13 +85,13 @@ func resourceNetboxCable() *schema.Resource {
 			customFieldsKey: customFieldsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("dcim/cables", false),
 		},
 	}
 }
 
-func resourceNetboxCableCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCableCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableCable{
 		Status:      d.Get("status").(string),
@@ -110,29 +111,30 @@ func resourceNetboxCableCreate(d *schema.ResourceData, m interface{}) error {
 	bTerminations := d.Get("b_termination").(*schema.Set)
 	data.BTerminations = getGenericObjectsFromSchemaSet(bTerminations)
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "dcim.cable")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := dcim.NewDcimCablesCreateParams().WithData(&data)
+	params := dcim.NewDcimCablesCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimCablesCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxCableRead(d, m)
+	return resourceNetboxCableRead(ctx, d, m)
 }
 
-func resourceNetboxCableRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCableRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimCablesReadParams().WithID(id)
+	params := dcim.NewDcimCablesReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimCablesRead(params, nil)
 
@@ -143,7 +145,7 @@ func resourceNetboxCableRead(d *schema.ResourceData, m interface{}) error {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	cable := res.GetPayload()
@@ -178,17 +180,14 @@ func resourceNetboxCableRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("description", cable.Description)
 	d.Set("comments", cable.Comments)
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
-	if cf != nil {
-		d.Set(customFieldsKey, cf)
-	}
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(customFieldsKey, withoutDefaultCustomFields(api, d, getCustomFields(ctx, api, res.GetPayload().CustomFields)))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(res.GetPayload().Tags)))
 
 	return nil
 }
 
-func resourceNetboxCableUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCableUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -210,32 +209,33 @@ func resourceNetboxCableUpdate(d *schema.ResourceData, m interface{}) error {
 	bTerminations := d.Get("b_termination").(*schema.Set)
 	data.BTerminations = getGenericObjectsFromSchemaSet(bTerminations)
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "dcim.cable")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := dcim.NewDcimCablesPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimCablesPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimCablesPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimCablesPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxCableRead(d, m)
+	return resourceNetboxCableRead(ctx, d, m)
 }
 
-func resourceNetboxCableDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCableDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimCablesDeleteParams().WithID(id)
+	params := dcim.NewDcimCablesDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimCablesDelete(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
@@ -1,11 +1,12 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/circuits"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -14,10 +15,10 @@ var resourceNetboxCircuitStatusOptions = []string{"planned", "provisioning", "ac
 
 func resourceNetboxCircuit() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxCircuitCreate,
-		Read:   resourceNetboxCircuitRead,
-		Update: resourceNetboxCircuitUpdate,
-		Delete: resourceNetboxCircuitDelete,
+		CreateContext: resourceNetboxCircuitCreate,
+		ReadContext:   resourceNetboxCircuitRead,
+		UpdateContext: resourceNetboxCircuitUpdate,
+		DeleteContext: resourceNetboxCircuitDelete,
 
 		Description: `:meta:subcategory:Circuits:From the [official documentation](https://docs.netbox.dev/en/stable/features/circuits/#circuits_1):
 
@@ -50,13 +51,13 @@ func resourceNetboxCircuit() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("circuits/circuits", false),
 		},
 	}
 }
 
-func resourceNetboxCircuitCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableCircuit{}
 
@@ -82,22 +83,22 @@ func resourceNetboxCircuitCreate(d *schema.ResourceData, m interface{}) error {
 
 	data.Tags = []*models.NestedTag{}
 
-	params := circuits.NewCircuitsCircuitsCreateParams().WithData(&data)
+	params := circuits.NewCircuitsCircuitsCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Circuits.CircuitsCircuitsCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxCircuitRead(d, m)
+	return resourceNetboxCircuitRead(ctx, d, m)
 }
 
-func resourceNetboxCircuitRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := circuits.NewCircuitsCircuitsReadParams().WithID(id)
+	params := circuits.NewCircuitsCircuitsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Circuits.CircuitsCircuitsRead(params, nil)
 
@@ -110,7 +111,7 @@ func resourceNetboxCircuitRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.Set("cid", res.GetPayload().Cid)
@@ -137,8 +138,8 @@ func resourceNetboxCircuitRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceNetboxCircuitUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableCircuit{}
@@ -165,21 +166,21 @@ func resourceNetboxCircuitUpdate(d *schema.ResourceData, m interface{}) error {
 
 	data.Tags = []*models.NestedTag{}
 
-	params := circuits.NewCircuitsCircuitsPartialUpdateParams().WithID(id).WithData(&data)
+	params := circuits.NewCircuitsCircuitsPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Circuits.CircuitsCircuitsPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxCircuitRead(d, m)
+	return resourceNetboxCircuitRead(ctx, d, m)
 }
 
-func resourceNetboxCircuitDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := circuits.NewCircuitsCircuitsDeleteParams().WithID(id)
+	params := circuits.NewCircuitsCircuitsDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Circuits.CircuitsCircuitsDelete(params, nil)
 	if err != nil {
@@ -189,7 +190,7 @@ func resourceNetboxCircuitDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
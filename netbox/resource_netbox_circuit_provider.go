@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/circuits"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxCircuitProvider() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxCircuitProviderCreate,
-		Read:   resourceNetboxCircuitProviderRead,
-		Update: resourceNetboxCircuitProviderUpdate,
-		Delete: resourceNetboxCircuitProviderDelete,
+		CreateContext: resourceNetboxCircuitProviderCreate,
+		ReadContext:   resourceNetboxCircuitProviderRead,
+		UpdateContext: resourceNetboxCircuitProviderUpdate,
+		DeleteContext: resourceNetboxCircuitProviderDelete,
 
 		Description: `:meta:subcategory:Circuits:From the [official documentation](https://docs.netbox.dev/en/stable/features/circuits/#providers):
 
@@ -36,13 +37,13 @@ func resourceNetboxCircuitProvider() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("circuits/providers", true),
 		},
 	}
 }
 
-func resourceNetboxCircuitProviderCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitProviderCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableProvider{}
 
@@ -60,22 +61,22 @@ func resourceNetboxCircuitProviderCreate(d *schema.ResourceData, m interface{})
 	data.Tags = []*models.NestedTag{}
 	data.Asns = []int64{}
 
-	params := circuits.NewCircuitsProvidersCreateParams().WithData(&data)
+	params := circuits.NewCircuitsProvidersCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Circuits.CircuitsProvidersCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxCircuitProviderRead(d, m)
+	return resourceNetboxCircuitProviderRead(ctx, d, m)
 }
 
-func resourceNetboxCircuitProviderRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitProviderRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := circuits.NewCircuitsProvidersReadParams().WithID(id)
+	params := circuits.NewCircuitsProvidersReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Circuits.CircuitsProvidersRead(params, nil)
 
@@ -88,7 +89,7 @@ func resourceNetboxCircuitProviderRead(d *schema.ResourceData, m interface{}) er
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.Set("name", res.GetPayload().Name)
@@ -97,8 +98,8 @@ func resourceNetboxCircuitProviderRead(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-func resourceNetboxCircuitProviderUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitProviderUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableProvider{}
@@ -117,25 +118,25 @@ func resourceNetboxCircuitProviderUpdate(d *schema.ResourceData, m interface{})
 	data.Tags = []*models.NestedTag{}
 	data.Asns = []int64{}
 
-	params := circuits.NewCircuitsProvidersPartialUpdateParams().WithID(id).WithData(&data)
+	params := circuits.NewCircuitsProvidersPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Circuits.CircuitsProvidersPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxCircuitProviderRead(d, m)
+	return resourceNetboxCircuitProviderRead(ctx, d, m)
 }
 
-func resourceNetboxCircuitProviderDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitProviderDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := circuits.NewCircuitsProvidersDeleteParams().WithID(id)
+	params := circuits.NewCircuitsProvidersDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Circuits.CircuitsProvidersDelete(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
@@ -1,11 +1,12 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/circuits"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -14,10 +15,10 @@ var resourceNetboxCircuitTerminationTermSideOptions = []string{"A", "Z"}
 
 func resourceNetboxCircuitTermination() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxCircuitTerminationCreate,
-		Read:   resourceNetboxCircuitTerminationRead,
-		Update: resourceNetboxCircuitTerminationUpdate,
-		Delete: resourceNetboxCircuitTerminationDelete,
+		CreateContext: resourceNetboxCircuitTerminationCreate,
+		ReadContext:   resourceNetboxCircuitTerminationRead,
+		UpdateContext: resourceNetboxCircuitTerminationUpdate,
+		DeleteContext: resourceNetboxCircuitTerminationDelete,
 
 		Description: `:meta:subcategory:Circuits:From the [official documentation](https://docs.netbox.dev/en/stable/features/circuits/#circuit-terminations):
 
@@ -52,13 +53,13 @@ func resourceNetboxCircuitTermination() *schema.Resource {
 			customFieldsKey: customFieldsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("circuits/circuit-terminations", false),
 		},
 	}
 }
 
-func resourceNetboxCircuitTerminationCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitTerminationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableCircuitTermination{}
 
@@ -85,29 +86,30 @@ func resourceNetboxCircuitTerminationCreate(d *schema.ResourceData, m interface{
 		data.UpstreamSpeed = int64ToPtr(int64(upstreamspeedValue.(int)))
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "circuits.circuittermination")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := circuits.NewCircuitsCircuitTerminationsCreateParams().WithData(&data)
+	params := circuits.NewCircuitsCircuitTerminationsCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Circuits.CircuitsCircuitTerminationsCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxCircuitTerminationRead(d, m)
+	return resourceNetboxCircuitTerminationRead(ctx, d, m)
 }
 
-func resourceNetboxCircuitTerminationRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitTerminationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := circuits.NewCircuitsCircuitTerminationsReadParams().WithID(id)
+	params := circuits.NewCircuitsCircuitTerminationsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Circuits.CircuitsCircuitTerminationsRead(params, nil)
 
@@ -120,7 +122,7 @@ func resourceNetboxCircuitTerminationRead(d *schema.ResourceData, m interface{})
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	term := res.GetPayload()
@@ -151,18 +153,15 @@ func resourceNetboxCircuitTerminationRead(d *schema.ResourceData, m interface{})
 		d.Set("upstream_speed", nil)
 	}
 
-	d.Set(tagsKey, getTagListFromNestedTagList(term.Tags))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(term.Tags)))
 
-	cf := getCustomFields(term.CustomFields)
-	if cf != nil {
-		d.Set(customFieldsKey, cf)
-	}
+	d.Set(customFieldsKey, withoutDefaultCustomFields(api, d, getCustomFields(ctx, api, term.CustomFields)))
 
 	return nil
 }
 
-func resourceNetboxCircuitTerminationUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitTerminationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableCircuitTermination{}
@@ -190,28 +189,29 @@ func resourceNetboxCircuitTerminationUpdate(d *schema.ResourceData, m interface{
 		data.UpstreamSpeed = int64ToPtr(int64(upstreamspeedValue.(int)))
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	cf, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = cf
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "circuits.circuittermination")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := circuits.NewCircuitsCircuitTerminationsPartialUpdateParams().WithID(id).WithData(&data)
+	params := circuits.NewCircuitsCircuitTerminationsPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Circuits.CircuitsCircuitTerminationsPartialUpdate(params, nil)
+	_, err = api.Circuits.CircuitsCircuitTerminationsPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxCircuitTerminationRead(d, m)
+	return resourceNetboxCircuitTerminationRead(ctx, d, m)
 }
 
-func resourceNetboxCircuitTerminationDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitTerminationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := circuits.NewCircuitsCircuitTerminationsDeleteParams().WithID(id)
+	params := circuits.NewCircuitsCircuitTerminationsDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Circuits.CircuitsCircuitTerminationsDelete(params, nil)
 	if err != nil {
@@ -221,7 +221,7 @@ func resourceNetboxCircuitTerminationDelete(d *schema.ResourceData, m interface{
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
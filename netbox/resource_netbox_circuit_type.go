@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/circuits"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxCircuitType() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxCircuitTypeCreate,
-		Read:   resourceNetboxCircuitTypeRead,
-		Update: resourceNetboxCircuitTypeUpdate,
-		Delete: resourceNetboxCircuitTypeDelete,
+		CreateContext: resourceNetboxCircuitTypeCreate,
+		ReadContext:   resourceNetboxCircuitTypeRead,
+		UpdateContext: resourceNetboxCircuitTypeUpdate,
+		DeleteContext: resourceNetboxCircuitTypeDelete,
 
 		Description: `:meta:subcategory:Circuits:From the [official documentation](https://docs.netbox.dev/en/stable/features/circuits/#circuit-types):
 
@@ -34,13 +35,13 @@ func resourceNetboxCircuitType() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("circuits/circuit-types", true),
 		},
 	}
 }
 
-func resourceNetboxCircuitTypeCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitTypeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.CircuitType{}
 
@@ -57,22 +58,22 @@ func resourceNetboxCircuitTypeCreate(d *schema.ResourceData, m interface{}) erro
 
 	data.Tags = []*models.NestedTag{}
 
-	params := circuits.NewCircuitsCircuitTypesCreateParams().WithData(&data)
+	params := circuits.NewCircuitsCircuitTypesCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Circuits.CircuitsCircuitTypesCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxCircuitTypeRead(d, m)
+	return resourceNetboxCircuitTypeRead(ctx, d, m)
 }
 
-func resourceNetboxCircuitTypeRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitTypeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := circuits.NewCircuitsCircuitTypesReadParams().WithID(id)
+	params := circuits.NewCircuitsCircuitTypesReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Circuits.CircuitsCircuitTypesRead(params, nil)
 
@@ -85,7 +86,7 @@ func resourceNetboxCircuitTypeRead(d *schema.ResourceData, m interface{}) error
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.Set("name", res.GetPayload().Name)
@@ -94,8 +95,8 @@ func resourceNetboxCircuitTypeRead(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceNetboxCircuitTypeUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitTypeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.CircuitType{}
@@ -113,21 +114,21 @@ func resourceNetboxCircuitTypeUpdate(d *schema.ResourceData, m interface{}) erro
 
 	data.Tags = []*models.NestedTag{}
 
-	params := circuits.NewCircuitsCircuitTypesPartialUpdateParams().WithID(id).WithData(&data)
+	params := circuits.NewCircuitsCircuitTypesPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Circuits.CircuitsCircuitTypesPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxCircuitTypeRead(d, m)
+	return resourceNetboxCircuitTypeRead(ctx, d, m)
 }
 
-func resourceNetboxCircuitTypeDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCircuitTypeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := circuits.NewCircuitsCircuitTypesDeleteParams().WithID(id)
+	params := circuits.NewCircuitsCircuitTypesDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Circuits.CircuitsCircuitTypesDelete(params, nil)
 	if err != nil {
@@ -137,7 +138,7 @@ func resourceNetboxCircuitTypeDelete(d *schema.ResourceData, m interface{}) erro
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
@@ -1,20 +1,21 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/virtualization"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceNetboxCluster() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxClusterCreate,
-		Read:   resourceNetboxClusterRead,
-		Update: resourceNetboxClusterUpdate,
-		Delete: resourceNetboxClusterDelete,
+		CreateContext: resourceNetboxClusterCreate,
+		ReadContext:   resourceNetboxClusterRead,
+		UpdateContext: resourceNetboxClusterUpdate,
+		DeleteContext: resourceNetboxClusterDelete,
 
 		Description: `:meta:subcategory:Virtualization:From the [official documentation](https://docs.netbox.dev/en/stable/features/virtualization/#clusters):
 
@@ -54,13 +55,13 @@ func resourceNetboxCluster() *schema.Resource {
 			tagsKey: tagsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("virtualization/clusters", false),
 		},
 	}
 }
 
-func resourceNetboxClusterCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxClusterCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableCluster{}
 
@@ -88,26 +89,26 @@ func resourceNetboxClusterCreate(d *schema.ResourceData, m interface{}) error {
 		data.Tenant = &tenantID
 	}
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, _ := getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 	data.Tags = tags
 
-	params := virtualization.NewVirtualizationClustersCreateParams().WithData(&data)
+	params := virtualization.NewVirtualizationClustersCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Virtualization.VirtualizationClustersCreate(params, nil)
 	if err != nil {
 		//return errors.New(getTextFromError(err))
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxClusterRead(d, m)
+	return resourceNetboxClusterRead(ctx, d, m)
 }
 
-func resourceNetboxClusterRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxClusterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := virtualization.NewVirtualizationClustersReadParams().WithID(id)
+	params := virtualization.NewVirtualizationClustersReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Virtualization.VirtualizationClustersRead(params, nil)
 	if err != nil {
@@ -119,7 +120,7 @@ func resourceNetboxClusterRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.Set("name", res.GetPayload().Name)
@@ -146,12 +147,12 @@ func resourceNetboxClusterRead(d *schema.ResourceData, m interface{}) error {
 		d.Set("tenant_id", nil)
 	}
 
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(res.GetPayload().Tags)))
 	return nil
 }
 
-func resourceNetboxClusterUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxClusterUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableCluster{}
@@ -180,24 +181,24 @@ func resourceNetboxClusterUpdate(d *schema.ResourceData, m interface{}) error {
 		data.Tenant = &tenantID
 	}
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, _ := getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 	data.Tags = tags
 
-	params := virtualization.NewVirtualizationClustersPartialUpdateParams().WithID(id).WithData(&data)
+	params := virtualization.NewVirtualizationClustersPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Virtualization.VirtualizationClustersPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxClusterRead(d, m)
+	return resourceNetboxClusterRead(ctx, d, m)
 }
 
-func resourceNetboxClusterDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxClusterDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := virtualization.NewVirtualizationClustersDeleteParams().WithID(id)
+	params := virtualization.NewVirtualizationClustersDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Virtualization.VirtualizationClustersDelete(params, nil)
 	if err != nil {
@@ -207,7 +208,7 @@ func resourceNetboxClusterDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
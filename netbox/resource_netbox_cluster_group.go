@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/virtualization"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxClusterGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxClusterGroupCreate,
-		Read:   resourceNetboxClusterGroupRead,
-		Update: resourceNetboxClusterGroupUpdate,
-		Delete: resourceNetboxClusterGroupDelete,
+		CreateContext: resourceNetboxClusterGroupCreate,
+		ReadContext:   resourceNetboxClusterGroupRead,
+		UpdateContext: resourceNetboxClusterGroupUpdate,
+		DeleteContext: resourceNetboxClusterGroupDelete,
 
 		Description: `:meta:subcategory:Virtualization:From the [official documentation](https://docs.netbox.dev/en/stable/features/virtualization/#cluster-groups):
 
@@ -38,13 +39,13 @@ func resourceNetboxClusterGroup() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("virtualization/cluster-groups", true),
 		},
 	}
 }
 
-func resourceNetboxClusterGroupCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxClusterGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.ClusterGroup{}
 
@@ -67,22 +68,22 @@ func resourceNetboxClusterGroupCreate(d *schema.ResourceData, m interface{}) err
 
 	data.Tags = []*models.NestedTag{}
 
-	params := virtualization.NewVirtualizationClusterGroupsCreateParams().WithData(&data)
+	params := virtualization.NewVirtualizationClusterGroupsCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Virtualization.VirtualizationClusterGroupsCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxClusterGroupRead(d, m)
+	return resourceNetboxClusterGroupRead(ctx, d, m)
 }
 
-func resourceNetboxClusterGroupRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxClusterGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := virtualization.NewVirtualizationClusterGroupsReadParams().WithID(id)
+	params := virtualization.NewVirtualizationClusterGroupsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Virtualization.VirtualizationClusterGroupsRead(params, nil)
 	if err != nil {
@@ -94,7 +95,7 @@ func resourceNetboxClusterGroupRead(d *schema.ResourceData, m interface{}) error
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.Set("name", res.GetPayload().Name)
@@ -103,8 +104,8 @@ func resourceNetboxClusterGroupRead(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceNetboxClusterGroupUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxClusterGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.ClusterGroup{}
@@ -133,21 +134,21 @@ func resourceNetboxClusterGroupUpdate(d *schema.ResourceData, m interface{}) err
 
 	data.Tags = []*models.NestedTag{}
 
-	params := virtualization.NewVirtualizationClusterGroupsPartialUpdateParams().WithID(id).WithData(&data)
+	params := virtualization.NewVirtualizationClusterGroupsPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Virtualization.VirtualizationClusterGroupsPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxClusterGroupRead(d, m)
+	return resourceNetboxClusterGroupRead(ctx, d, m)
 }
 
-func resourceNetboxClusterGroupDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxClusterGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := virtualization.NewVirtualizationClusterGroupsDeleteParams().WithID(id)
+	params := virtualization.NewVirtualizationClusterGroupsDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Virtualization.VirtualizationClusterGroupsDelete(params, nil)
 	if err != nil {
@@ -157,7 +158,7 @@ func resourceNetboxClusterGroupDelete(d *schema.ResourceData, m interface{}) err
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
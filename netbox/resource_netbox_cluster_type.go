@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/virtualization"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxClusterType() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxClusterTypeCreate,
-		Read:   resourceNetboxClusterTypeRead,
-		Update: resourceNetboxClusterTypeUpdate,
-		Delete: resourceNetboxClusterTypeDelete,
+		CreateContext: resourceNetboxClusterTypeCreate,
+		ReadContext:   resourceNetboxClusterTypeRead,
+		UpdateContext: resourceNetboxClusterTypeUpdate,
+		DeleteContext: resourceNetboxClusterTypeDelete,
 
 		Description: `:meta:subcategory:Virtualization:From the [official documentation](https://docs.netbox.dev/en/stable/features/virtualization/#cluster-types):
 
@@ -34,13 +35,13 @@ func resourceNetboxClusterType() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("virtualization/cluster-types", true),
 		},
 	}
 }
 
-func resourceNetboxClusterTypeCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxClusterTypeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 	slugValue, slugOk := d.GetOk("slug")
@@ -53,7 +54,7 @@ func resourceNetboxClusterTypeCreate(d *schema.ResourceData, m interface{}) erro
 		slug = slugValue.(string)
 	}
 
-	params := virtualization.NewVirtualizationClusterTypesCreateParams().WithData(
+	params := virtualization.NewVirtualizationClusterTypesCreateParamsWithContext(ctx).WithData(
 		&models.ClusterType{
 			Name: &name,
 			Slug: &slug,
@@ -64,18 +65,18 @@ func resourceNetboxClusterTypeCreate(d *schema.ResourceData, m interface{}) erro
 	res, err := api.Virtualization.VirtualizationClusterTypesCreate(params, nil)
 	if err != nil {
 		//return errors.New(getTextFromError(err))
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxClusterTypeRead(d, m)
+	return resourceNetboxClusterTypeRead(ctx, d, m)
 }
 
-func resourceNetboxClusterTypeRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxClusterTypeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := virtualization.NewVirtualizationClusterTypesReadParams().WithID(id)
+	params := virtualization.NewVirtualizationClusterTypesReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Virtualization.VirtualizationClusterTypesRead(params, nil)
 	if err != nil {
@@ -87,7 +88,7 @@ func resourceNetboxClusterTypeRead(d *schema.ResourceData, m interface{}) error
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.Set("name", res.GetPayload().Name)
@@ -95,8 +96,8 @@ func resourceNetboxClusterTypeRead(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceNetboxClusterTypeUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxClusterTypeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.ClusterType{}
@@ -116,21 +117,21 @@ func resourceNetboxClusterTypeUpdate(d *schema.ResourceData, m interface{}) erro
 	data.Name = &name
 	data.Tags = []*models.NestedTag{}
 
-	params := virtualization.NewVirtualizationClusterTypesPartialUpdateParams().WithID(id).WithData(&data)
+	params := virtualization.NewVirtualizationClusterTypesPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Virtualization.VirtualizationClusterTypesPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxClusterTypeRead(d, m)
+	return resourceNetboxClusterTypeRead(ctx, d, m)
 }
 
-func resourceNetboxClusterTypeDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxClusterTypeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := virtualization.NewVirtualizationClusterTypesDeleteParams().WithID(id)
+	params := virtualization.NewVirtualizationClusterTypesDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Virtualization.VirtualizationClusterTypesDelete(params, nil)
 	if err != nil {
@@ -140,7 +141,7 @@ func resourceNetboxClusterTypeDelete(d *schema.ResourceData, m interface{}) erro
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
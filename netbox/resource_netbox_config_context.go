@@ -1,22 +1,23 @@
 package netbox
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/extras"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxConfigContext() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxConfigContextCreate,
-		Read:   resourceNetboxConfigContextRead,
-		Update: resourceNetboxConfigContextUpdate,
-		Delete: resourceNetboxConfigContextDelete,
+		CreateContext: resourceNetboxConfigContextCreate,
+		ReadContext:   resourceNetboxConfigContextRead,
+		UpdateContext: resourceNetboxConfigContextUpdate,
+		DeleteContext: resourceNetboxConfigContextDelete,
 
 		Description: `:meta:subcategory:Extras:From the [official documentation](https://docs.netbox.dev/en/stable/models/extras/configcontext/):
 
@@ -147,13 +148,13 @@ func resourceNetboxConfigContext() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("extras/config-contexts", false),
 		},
 	}
 }
 
-func resourceNetboxConfigContextCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxConfigContextCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	data := models.WritableConfigContext{}
 	data.Name = strToPtr(d.Get("name").(string))
 
@@ -181,23 +182,23 @@ func resourceNetboxConfigContextCreate(d *schema.ResourceData, m interface{}) er
 	data.Tags = toStringList(d.Get("tags"))
 	data.Weight = int64ToPtr(int64(d.Get("weight").(int)))
 
-	params := extras.NewExtrasConfigContextsCreateParams().WithData(&data)
+	params := extras.NewExtrasConfigContextsCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Extras.ExtrasConfigContextsCreate(params, nil)
 	if err != nil {
 		//return errors.New(getTextFromError(err))
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxConfigContextRead(d, m)
+	return resourceNetboxConfigContextRead(ctx, d, m)
 }
 
-func resourceNetboxConfigContextRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxConfigContextRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := extras.NewExtrasConfigContextsReadParams().WithID(id)
+	params := extras.NewExtrasConfigContextsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Extras.ExtrasConfigContextsRead(params, nil)
 
@@ -208,7 +209,7 @@ func resourceNetboxConfigContextRead(d *schema.ResourceData, m interface{}) erro
 			d.SetId("")
 			return nil
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.Set("name", res.GetPayload().Name)
@@ -317,8 +318,8 @@ func resourceNetboxConfigContextRead(d *schema.ResourceData, m interface{}) erro
 	return nil
 }
 
-func resourceNetboxConfigContextUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxConfigContextUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -351,25 +352,25 @@ func resourceNetboxConfigContextUpdate(d *schema.ResourceData, m interface{}) er
 	data.Tags = toStringList(d.Get("tags"))
 	data.Weight = int64ToPtr(int64(d.Get("weight").(int)))
 
-	params := extras.NewExtrasConfigContextsPartialUpdateParams().WithID(id).WithData(&data)
+	params := extras.NewExtrasConfigContextsPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Extras.ExtrasConfigContextsPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxConfigContextRead(d, m)
+	return resourceNetboxConfigContextRead(ctx, d, m)
 }
 
-func resourceNetboxConfigContextDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxConfigContextDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := extras.NewExtrasConfigContextsDeleteParams().WithID(id)
+	params := extras.NewExtrasConfigContextsDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Extras.ExtrasConfigContextsDelete(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/extras"
 	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -48,13 +47,13 @@ func resourceNetboxConfigTemplate() *schema.Resource {
 			tagsKey: tagsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("extras/config-templates", false),
 		},
 	}
 }
 
 func resourceNetboxConfigTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	var diags diag.Diagnostics
 
@@ -62,7 +61,7 @@ func resourceNetboxConfigTemplateCreate(ctx context.Context, d *schema.ResourceD
 	description := d.Get("description").(string)
 	templateCode := d.Get("template_code").(string)
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, _ := getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
 	data := models.WritableConfigTemplate{
 		Name:         &name,
@@ -78,17 +77,17 @@ func resourceNetboxConfigTemplateCreate(ctx context.Context, d *schema.ResourceD
 		var environmentParams any
 		err := json.Unmarshal([]byte(environmentParamsJSON.(string)), &environmentParams)
 		if err != nil {
-			return diag.FromErr(err)
+			return apiErrorDiagnostics(d, err)
 		}
 
 		data.EnvironmentParams = environmentParams
 	}
 
-	params := extras.NewExtrasConfigTemplatesCreateParams().WithData(&data)
+	params := extras.NewExtrasConfigTemplatesCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Extras.ExtrasConfigTemplatesCreate(params, nil)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
@@ -97,12 +96,12 @@ func resourceNetboxConfigTemplateCreate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceNetboxConfigTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
 	var diags diag.Diagnostics
 
-	params := extras.NewExtrasConfigTemplatesReadParams().WithID(id)
+	params := extras.NewExtrasConfigTemplatesReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Extras.ExtrasConfigTemplatesRead(params, nil)
 	if err != nil {
@@ -114,7 +113,7 @@ func resourceNetboxConfigTemplateRead(ctx context.Context, d *schema.ResourceDat
 				return nil
 			}
 		}
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 
 	tmpl := res.GetPayload()
@@ -126,7 +125,7 @@ func resourceNetboxConfigTemplateRead(ctx context.Context, d *schema.ResourceDat
 	if tmpl.EnvironmentParams != nil {
 		environmentParamsJSON, err := json.Marshal(tmpl.EnvironmentParams)
 		if err != nil {
-			return diag.FromErr(err)
+			return apiErrorDiagnostics(d, err)
 		}
 
 		d.Set("environment_params", string(environmentParamsJSON))
@@ -138,7 +137,7 @@ func resourceNetboxConfigTemplateRead(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceNetboxConfigTemplateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	var diags diag.Diagnostics
 
@@ -148,7 +147,7 @@ func resourceNetboxConfigTemplateUpdate(ctx context.Context, d *schema.ResourceD
 	description := d.Get("description").(string)
 	templateCode := d.Get("template_code").(string)
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, _ := getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
 	data := models.WritableConfigTemplate{
 		Name:         &name,
@@ -164,26 +163,26 @@ func resourceNetboxConfigTemplateUpdate(ctx context.Context, d *schema.ResourceD
 		var environmentParams any
 		err := json.Unmarshal([]byte(environmentParamsJSON.(string)), &environmentParams)
 		if err != nil {
-			return diag.FromErr(err)
+			return apiErrorDiagnostics(d, err)
 		}
 
 		data.EnvironmentParams = environmentParams
 	}
 
-	params := extras.NewExtrasConfigTemplatesUpdateParams().WithID(id).WithData(&data)
+	params := extras.NewExtrasConfigTemplatesUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 	_, err := api.Extras.ExtrasConfigTemplatesUpdate(params, nil)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 
 	return diags
 }
 
 func resourceNetboxConfigTemplateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := extras.NewExtrasConfigTemplatesDeleteParams().WithID(id)
+	params := extras.NewExtrasConfigTemplatesDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Extras.ExtrasConfigTemplatesDelete(params, nil)
 	if err != nil {
@@ -193,7 +192,7 @@ func resourceNetboxConfigTemplateDelete(ctx context.Context, d *schema.ResourceD
 				return nil
 			}
 		}
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
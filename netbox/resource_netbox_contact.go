@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/tenancy"
 	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/go-openapi/strfmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceNetboxContact() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxContactCreate,
-		Read:   resourceNetboxContactRead,
-		Update: resourceNetboxContactUpdate,
-		Delete: resourceNetboxContactDelete,
+		CreateContext: resourceNetboxContactCreate,
+		ReadContext:   resourceNetboxContactRead,
+		UpdateContext: resourceNetboxContactUpdate,
+		DeleteContext: resourceNetboxContactDelete,
 
 		Description: `:meta:subcategory:Tenancy:From the [official documentation](https://docs.netbox.dev/en/stable/features/contacts/#contacts_1):
 
@@ -43,20 +44,20 @@ func resourceNetboxContact() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("tenancy/contacts", false),
 		},
 	}
 }
 
-func resourceNetboxContactCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxContactCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 	phone := d.Get("phone").(string)
 	email := d.Get("email").(string)
 	groupID := int64(d.Get("group_id").(int))
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, _ := getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
 	data := &models.WritableContact{}
 
@@ -69,22 +70,22 @@ func resourceNetboxContactCreate(d *schema.ResourceData, m interface{}) error {
 		data.Group = &groupID
 	}
 
-	params := tenancy.NewTenancyContactsCreateParams().WithData(data)
+	params := tenancy.NewTenancyContactsCreateParamsWithContext(ctx).WithData(data)
 
 	res, err := api.Tenancy.TenancyContactsCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxContactRead(d, m)
+	return resourceNetboxContactRead(ctx, d, m)
 }
 
-func resourceNetboxContactRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxContactRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := tenancy.NewTenancyContactsReadParams().WithID(id)
+	params := tenancy.NewTenancyContactsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Tenancy.TenancyContactsRead(params, nil)
 	if err != nil {
@@ -96,7 +97,7 @@ func resourceNetboxContactRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.Set("name", res.GetPayload().Name)
@@ -109,8 +110,8 @@ func resourceNetboxContactRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceNetboxContactUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxContactUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableContact{}
@@ -120,7 +121,7 @@ func resourceNetboxContactUpdate(d *schema.ResourceData, m interface{}) error {
 	email := d.Get("email").(string)
 	groupID := int64(d.Get("group_id").(int))
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, _ := getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
 	data.Name = &name
 	data.Tags = tags
@@ -130,21 +131,21 @@ func resourceNetboxContactUpdate(d *schema.ResourceData, m interface{}) error {
 		data.Group = &groupID
 	}
 
-	params := tenancy.NewTenancyContactsPartialUpdateParams().WithID(id).WithData(&data)
+	params := tenancy.NewTenancyContactsPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Tenancy.TenancyContactsPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxContactRead(d, m)
+	return resourceNetboxContactRead(ctx, d, m)
 }
 
-func resourceNetboxContactDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxContactDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := tenancy.NewTenancyContactsDeleteParams().WithID(id)
+	params := tenancy.NewTenancyContactsDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Tenancy.TenancyContactsDelete(params, nil)
 	if err != nil {
@@ -154,7 +155,7 @@ func resourceNetboxContactDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
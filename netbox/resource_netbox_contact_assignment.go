@@ -1,11 +1,12 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/tenancy"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -14,10 +15,10 @@ var resourceNetboxContactAssignmentPriorityOptions = []string{"primary", "second
 
 func resourceNetboxContactAssignment() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxContactAssignmentCreate,
-		Read:   resourceNetboxContactAssignmentRead,
-		Update: resourceNetboxContactAssignmentUpdate,
-		Delete: resourceNetboxContactAssignmentDelete,
+		CreateContext: resourceNetboxContactAssignmentCreate,
+		ReadContext:   resourceNetboxContactAssignmentRead,
+		UpdateContext: resourceNetboxContactAssignmentUpdate,
+		DeleteContext: resourceNetboxContactAssignmentDelete,
 
 		Description: `:meta:subcategory:Tenancy:From the [official documentation](https://docs.netbox.dev/en/stable/features/contacts#contactassignments_1):
 
@@ -48,13 +49,13 @@ func resourceNetboxContactAssignment() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("tenancy/contact-assignments", false),
 		},
 	}
 }
 
-func resourceNetboxContactAssignmentCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxContactAssignmentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	contentType := d.Get("content_type").(string)
 	objectID := int64(d.Get("object_id").(int))
@@ -70,22 +71,22 @@ func resourceNetboxContactAssignmentCreate(d *schema.ResourceData, m interface{}
 	data.Role = &roleID
 	data.Priority = priority
 
-	params := tenancy.NewTenancyContactAssignmentsCreateParams().WithData(data)
+	params := tenancy.NewTenancyContactAssignmentsCreateParamsWithContext(ctx).WithData(data)
 
 	res, err := api.Tenancy.TenancyContactAssignmentsCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxContactAssignmentRead(d, m)
+	return resourceNetboxContactAssignmentRead(ctx, d, m)
 }
 
-func resourceNetboxContactAssignmentRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxContactAssignmentRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := tenancy.NewTenancyContactAssignmentsReadParams().WithID(id)
+	params := tenancy.NewTenancyContactAssignmentsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Tenancy.TenancyContactAssignmentsRead(params, nil)
 	if err != nil {
@@ -97,7 +98,7 @@ func resourceNetboxContactAssignmentRead(d *schema.ResourceData, m interface{})
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.Set("content_type", res.GetPayload().ObjectType)
@@ -118,8 +119,8 @@ func resourceNetboxContactAssignmentRead(d *schema.ResourceData, m interface{})
 	return nil
 }
 
-func resourceNetboxContactAssignmentUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxContactAssignmentUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableContactAssignment{}
@@ -142,21 +143,21 @@ func resourceNetboxContactAssignmentUpdate(d *schema.ResourceData, m interface{}
 	}
 	data.Priority = priority
 
-	params := tenancy.NewTenancyContactAssignmentsPartialUpdateParams().WithID(id).WithData(&data)
+	params := tenancy.NewTenancyContactAssignmentsPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Tenancy.TenancyContactAssignmentsPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxContactAssignmentRead(d, m)
+	return resourceNetboxContactAssignmentRead(ctx, d, m)
 }
 
-func resourceNetboxContactAssignmentDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxContactAssignmentDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := tenancy.NewTenancyContactAssignmentsDeleteParams().WithID(id)
+	params := tenancy.NewTenancyContactAssignmentsDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Tenancy.TenancyContactAssignmentsDelete(params, nil)
 	if err != nil {
@@ -166,7 +167,7 @@ func resourceNetboxContactAssignmentDelete(d *schema.ResourceData, m interface{}
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/tenancy"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxContactGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxContactGroupCreate,
-		Read:   resourceNetboxContactGroupRead,
-		Update: resourceNetboxContactGroupUpdate,
-		Delete: resourceNetboxContactGroupDelete,
+		CreateContext: resourceNetboxContactGroupCreate,
+		ReadContext:   resourceNetboxContactGroupRead,
+		UpdateContext: resourceNetboxContactGroupUpdate,
+		DeleteContext: resourceNetboxContactGroupDelete,
 
 		Description: `:meta:subcategory:Tenancy:From the [official documentation](https://docs.netbox.dev/en/stable/features/contacts/#contact-groups):
 
@@ -42,13 +43,13 @@ func resourceNetboxContactGroup() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("tenancy/contact-groups", true),
 		},
 	}
 }
 
-func resourceNetboxContactGroupCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxContactGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 	parentID := int64(d.Get("parent_id").(int))
@@ -73,23 +74,23 @@ func resourceNetboxContactGroupCreate(d *schema.ResourceData, m interface{}) err
 		data.Parent = &parentID
 	}
 
-	params := tenancy.NewTenancyContactGroupsCreateParams().WithData(data)
+	params := tenancy.NewTenancyContactGroupsCreateParamsWithContext(ctx).WithData(data)
 
 	res, err := api.Tenancy.TenancyContactGroupsCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxContactGroupRead(d, m)
+	return resourceNetboxContactGroupRead(ctx, d, m)
 }
 
-func resourceNetboxContactGroupRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxContactGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
-	params := tenancy.NewTenancyContactGroupsReadParams().WithID(id)
+	params := tenancy.NewTenancyContactGroupsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Tenancy.TenancyContactGroupsRead(params, nil)
 	if err != nil {
@@ -101,7 +102,7 @@ func resourceNetboxContactGroupRead(d *schema.ResourceData, m interface{}) error
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.Set("name", res.GetPayload().Name)
@@ -113,8 +114,8 @@ func resourceNetboxContactGroupRead(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceNetboxContactGroupUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxContactGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableContactGroup{}
@@ -140,25 +141,25 @@ func resourceNetboxContactGroupUpdate(d *schema.ResourceData, m interface{}) err
 	if parentID != 0 {
 		data.Parent = &parentID
 	}
-	params := tenancy.NewTenancyContactGroupsPartialUpdateParams().WithID(id).WithData(&data)
+	params := tenancy.NewTenancyContactGroupsPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Tenancy.TenancyContactGroupsPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxContactGroupRead(d, m)
+	return resourceNetboxContactGroupRead(ctx, d, m)
 }
 
-func resourceNetboxContactGroupDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxContactGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := tenancy.NewTenancyContactGroupsDeleteParams().WithID(id)
+	params := tenancy.NewTenancyContactGroupsDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Tenancy.TenancyContactGroupsDelete(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/tenancy"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxContactRole() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxContactRoleCreate,
-		Read:   resourceNetboxContactRoleRead,
-		Update: resourceNetboxContactRoleUpdate,
-		Delete: resourceNetboxContactRoleDelete,
+		CreateContext: resourceNetboxContactRoleCreate,
+		ReadContext:   resourceNetboxContactRoleRead,
+		UpdateContext: resourceNetboxContactRoleUpdate,
+		DeleteContext: resourceNetboxContactRoleDelete,
 
 		Description: `:meta:subcategory:Tenancy:From the [official documentation](https://docs.netbox.dev/en/stable/features/contacts/#contactroles):
 
@@ -34,13 +35,13 @@ func resourceNetboxContactRole() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("tenancy/contact-roles", true),
 		},
 	}
 }
 
-func resourceNetboxContactRoleCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxContactRoleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 
@@ -57,22 +58,22 @@ func resourceNetboxContactRoleCreate(d *schema.ResourceData, m interface{}) erro
 	data.Name = &name
 	data.Tags = []*models.NestedTag{}
 
-	params := tenancy.NewTenancyContactRolesCreateParams().WithData(data)
+	params := tenancy.NewTenancyContactRolesCreateParamsWithContext(ctx).WithData(data)
 
 	res, err := api.Tenancy.TenancyContactRolesCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxContactRoleRead(d, m)
+	return resourceNetboxContactRoleRead(ctx, d, m)
 }
 
-func resourceNetboxContactRoleRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxContactRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := tenancy.NewTenancyContactRolesReadParams().WithID(id)
+	params := tenancy.NewTenancyContactRolesReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Tenancy.TenancyContactRolesRead(params, nil)
 
@@ -85,7 +86,7 @@ func resourceNetboxContactRoleRead(d *schema.ResourceData, m interface{}) error
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	contactrole := res.GetPayload()
@@ -95,8 +96,8 @@ func resourceNetboxContactRoleRead(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceNetboxContactRoleUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxContactRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.ContactRole{}
@@ -113,21 +114,21 @@ func resourceNetboxContactRoleUpdate(d *schema.ResourceData, m interface{}) erro
 	data.Name = &name
 	data.Tags = []*models.NestedTag{}
 
-	params := tenancy.NewTenancyContactRolesPartialUpdateParams().WithID(id).WithData(&data)
+	params := tenancy.NewTenancyContactRolesPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Tenancy.TenancyContactRolesPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxContactRoleRead(d, m)
+	return resourceNetboxContactRoleRead(ctx, d, m)
 }
 
-func resourceNetboxContactRoleDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxContactRoleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := tenancy.NewTenancyContactRolesDeleteParams().WithID(id)
+	params := tenancy.NewTenancyContactRolesDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Tenancy.TenancyContactRolesDelete(params, nil)
 	if err != nil {
@@ -137,7 +138,7 @@ func resourceNetboxContactRoleDelete(d *schema.ResourceData, m interface{}) erro
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
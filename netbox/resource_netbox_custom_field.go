@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/extras"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceCustomField() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxCustomFieldCreate,
-		Read:   resourceNetboxCustomFieldRead,
-		Update: resourceNetboxCustomFieldUpdate,
-		Delete: resourceNetboxCustomFieldDelete,
+		CreateContext: resourceNetboxCustomFieldCreate,
+		ReadContext:   resourceNetboxCustomFieldRead,
+		UpdateContext: resourceNetboxCustomFieldUpdate,
+		DeleteContext: resourceNetboxCustomFieldDelete,
 
 		Description: `:meta:subcategory:Extras:From the [official documentation](https://docs.netbox.dev/en/stable/customization/custom-fields/#custom-fields):
 
@@ -33,9 +34,12 @@ func resourceCustomField() *schema.Resource {
 				Required: true,
 				ValidateFunc: validation.StringInSlice([]string{
 					models.CustomFieldTypeValueText,
+					models.CustomFieldTypeValueLongtext,
 					models.CustomFieldTypeValueInteger,
+					models.CustomFieldTypeValueDecimal,
 					models.CustomFieldTypeValueBoolean,
 					models.CustomFieldTypeValueDate,
+					"datetime",
 					models.CustomFieldTypeValueURL,
 					models.CustomFieldTypeValueSelect,
 					models.CustomFieldTypeValueMultiselect,
@@ -95,13 +99,13 @@ func resourceCustomField() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("extras/custom-fields", false),
 		},
 	}
 }
 
-func resourceNetboxCustomFieldUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCustomFieldUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -141,19 +145,21 @@ func resourceNetboxCustomFieldUpdate(d *schema.ResourceData, m interface{}) erro
 		data.ValidationMinimum = int64ToPtr(int64(vmin.(int)))
 	}
 
-	params := extras.NewExtrasCustomFieldsUpdateParams().WithID(id).WithData(data)
+	params := extras.NewExtrasCustomFieldsUpdateParamsWithContext(ctx).WithID(id).WithData(data)
 	res, err := api.Extras.ExtrasCustomFieldsUpdate(params, nil)
+	// The cached types of custom fields may be outdated now
+	api.customFields.invalidate()
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxCustomFieldRead(d, m)
+	return resourceNetboxCustomFieldRead(ctx, d, m)
 }
 
-func resourceNetboxCustomFieldCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCustomFieldCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := &models.WritableCustomField{
 		Name:            strToPtr(d.Get("name").(string)),
@@ -191,35 +197,35 @@ func resourceNetboxCustomFieldCreate(d *schema.ResourceData, m interface{}) erro
 		data.ValidationMinimum = int64ToPtr(int64(vmin.(int)))
 	}
 
-	params := extras.NewExtrasCustomFieldsCreateParams().WithData(data)
+	params := extras.NewExtrasCustomFieldsCreateParamsWithContext(ctx).WithData(data)
 
 	res, err := api.Extras.ExtrasCustomFieldsCreate(params, nil)
 	if err != nil {
 		//return errors.New(getTextFromError(err))
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxCustomFieldRead(d, m)
+	return resourceNetboxCustomFieldRead(ctx, d, m)
 }
 
-func resourceNetboxCustomFieldRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCustomFieldRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := extras.NewExtrasCustomFieldsReadParams().WithID(id)
+	params := extras.NewExtrasCustomFieldsReadParamsWithContext(ctx).WithID(id)
 	res, err := api.Extras.ExtrasCustomFieldsRead(params, nil)
 	if err != nil {
 		errapi, ok := err.(*extras.ExtrasCustomFieldsReadDefault)
 		if !ok {
-			return err
+			return apiErrorDiagnostics(d, err)
 		}
 		errorcode := errapi.Code()
 		if errorcode == 404 {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	customField := res.GetPayload()
@@ -250,11 +256,13 @@ func resourceNetboxCustomFieldRead(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceNetboxCustomFieldDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCustomFieldDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := extras.NewExtrasCustomFieldsDeleteParams().WithID(id)
+	params := extras.NewExtrasCustomFieldsDeleteParamsWithContext(ctx).WithID(id)
 	_, err := api.Extras.ExtrasCustomFieldsDelete(params, nil)
+	// The cached types of custom fields may still contain the deleted field
+	api.customFields.invalidate()
 	if err != nil {
 		if errresp, ok := err.(*extras.ExtrasCustomFieldsDeleteDefault); ok {
 			errorcode := errresp.Code()
@@ -262,7 +270,7 @@ func resourceNetboxCustomFieldDelete(d *schema.ResourceData, m interface{}) erro
 				d.SetId("")
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
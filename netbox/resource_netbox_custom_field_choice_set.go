@@ -1,12 +1,12 @@
 package netbox
 
 import (
-	"errors"
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/extras"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -15,10 +15,10 @@ var resourceNetboxCustomFieldChoiceSetBaseChoicesOptions = []string{"IATA", "ISO
 
 func resourceNetboxCustomFieldChoiceSet() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxCustomFieldChoiceSetCreate,
-		Read:   resourceNetboxCustomFieldChoiceSetRead,
-		Update: resourceNetboxCustomFieldChoiceSetUpdate,
-		Delete: resourceNetboxCustomFieldChoiceSetDelete,
+		CreateContext: resourceNetboxCustomFieldChoiceSetCreate,
+		ReadContext:   resourceNetboxCustomFieldChoiceSetRead,
+		UpdateContext: resourceNetboxCustomFieldChoiceSetUpdate,
+		DeleteContext: resourceNetboxCustomFieldChoiceSetDelete,
 
 		Description: `:meta:subcategory:Extras:From the [official documentation](https://docs.netbox.dev/en/stable/models/extras/customfieldchoiceset/):
 
@@ -79,13 +79,13 @@ A choice set must define a base choice set and/or a set of arbitrary extra choic
 			customFieldsKey: customFieldsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("extras/custom-field-choice-sets", false),
 		},
 	}
 }
 
-func resourceNetboxCustomFieldChoiceSetCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCustomFieldChoiceSetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 
@@ -102,29 +102,29 @@ func resourceNetboxCustomFieldChoiceSetCreate(d *schema.ResourceData, m interfac
 		for _, innerList := range extraChoices.([]interface{}) {
 			tmp := innerList.([]interface{})
 			if len(tmp) != 2 {
-				return errors.New("length of inner lists must be exactly two for custom field choice sets")
+				return diag.Errorf("length of inner lists must be exactly two for custom field choice sets")
 			}
 			extraChoiceListList = append(extraChoiceListList, []string{tmp[0].(string), tmp[1].(string)})
 		}
 		data.ExtraChoices = extraChoiceListList
 	}
 
-	params := extras.NewExtrasCustomFieldChoiceSetsCreateParams().WithData(&data)
+	params := extras.NewExtrasCustomFieldChoiceSetsCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Extras.ExtrasCustomFieldChoiceSetsCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxCustomFieldChoiceSetRead(d, m)
+	return resourceNetboxCustomFieldChoiceSetRead(ctx, d, m)
 }
 
-func resourceNetboxCustomFieldChoiceSetRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCustomFieldChoiceSetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := extras.NewExtrasCustomFieldChoiceSetsReadParams().WithID(id)
+	params := extras.NewExtrasCustomFieldChoiceSetsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Extras.ExtrasCustomFieldChoiceSetsRead(params, nil)
 
@@ -137,7 +137,7 @@ func resourceNetboxCustomFieldChoiceSetRead(d *schema.ResourceData, m interface{
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	choiceSet := res.GetPayload()
@@ -153,8 +153,8 @@ func resourceNetboxCustomFieldChoiceSetRead(d *schema.ResourceData, m interface{
 	return nil
 }
 
-func resourceNetboxCustomFieldChoiceSetUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCustomFieldChoiceSetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -173,28 +173,28 @@ func resourceNetboxCustomFieldChoiceSetUpdate(d *schema.ResourceData, m interfac
 		for _, innerList := range extraChoices.([]interface{}) {
 			tmp := innerList.([]interface{})
 			if len(tmp) != 2 {
-				return errors.New("length of inner lists must be exactly two for custom field choice sets")
+				return diag.Errorf("length of inner lists must be exactly two for custom field choice sets")
 			}
 			extraChoiceListList = append(extraChoiceListList, []string{tmp[0].(string), tmp[1].(string)})
 		}
 		data.ExtraChoices = extraChoiceListList
 	}
 
-	params := extras.NewExtrasCustomFieldChoiceSetsPartialUpdateParams().WithID(id).WithData(&data)
+	params := extras.NewExtrasCustomFieldChoiceSetsPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Extras.ExtrasCustomFieldChoiceSetsPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxCustomFieldChoiceSetRead(d, m)
+	return resourceNetboxCustomFieldChoiceSetRead(ctx, d, m)
 }
 
-func resourceNetboxCustomFieldChoiceSetDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxCustomFieldChoiceSetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := extras.NewExtrasCustomFieldChoiceSetsDeleteParams().WithID(id)
+	params := extras.NewExtrasCustomFieldChoiceSetsDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Extras.ExtrasCustomFieldChoiceSetsDelete(params, nil)
 	if err != nil {
@@ -204,7 +204,7 @@ func resourceNetboxCustomFieldChoiceSetDelete(d *schema.ResourceData, m interfac
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
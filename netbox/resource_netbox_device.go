@@ -5,7 +5,6 @@ import (
 	"encoding/json"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -137,13 +136,13 @@ func resourceNetboxDevice() *schema.Resource {
 			customFieldsKey: customFieldsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("dcim/devices", false),
 		},
 	}
 }
 
 func resourceNetboxDeviceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 
@@ -235,18 +234,19 @@ func resourceNetboxDeviceCreate(ctx context.Context, d *schema.ResourceData, m i
 		}
 	}
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "dcim.device")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	params := dcim.NewDcimDevicesCreateParams().WithData(&data)
+	params := dcim.NewDcimDevicesCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimDevicesCreate(params, nil)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
@@ -254,12 +254,12 @@ func resourceNetboxDeviceCreate(ctx context.Context, d *schema.ResourceData, m i
 	if vcMaster, ok := d.GetOk("virtual_chassis_master"); ok {
 		var err error
 		if vcMaster.(bool) {
-			err = virtualChassisUpdateMaster(api, *data.VirtualChassis, &(res.GetPayload().ID))
+			err = virtualChassisUpdateMaster(ctx, api, *data.VirtualChassis, &(res.GetPayload().ID))
 		} else {
-			err = virtualChassisUpdateMaster(api, *data.VirtualChassis, nil)
+			err = virtualChassisUpdateMaster(ctx, api, *data.VirtualChassis, nil)
 		}
 		if err != nil {
-			return diag.FromErr(err)
+			return apiErrorDiagnostics(d, err)
 		}
 	}
 
@@ -267,13 +267,13 @@ func resourceNetboxDeviceCreate(ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceNetboxDeviceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	var diags diag.Diagnostics
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
-	params := dcim.NewDcimDevicesReadParams().WithID(id)
+	params := dcim.NewDcimDevicesReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimDevicesRead(params, nil)
 	if err != nil {
@@ -285,7 +285,7 @@ func resourceNetboxDeviceRead(ctx context.Context, d *schema.ResourceData, m int
 				return nil
 			}
 		}
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 
 	device := res.GetPayload()
@@ -350,10 +350,7 @@ func resourceNetboxDeviceRead(ctx context.Context, d *schema.ResourceData, m int
 		d.Set("config_template_id", nil)
 	}
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
-	if cf != nil {
-		d.Set(customFieldsKey, cf)
-	}
+	d.Set(customFieldsKey, withoutDefaultCustomFields(api, d, getCustomFields(ctx, api, res.GetPayload().CustomFields)))
 
 	d.Set("asset_tag", device.AssetTag)
 
@@ -400,12 +397,12 @@ func resourceNetboxDeviceRead(ctx context.Context, d *schema.ResourceData, m int
 		d.Set("local_context_data", nil)
 	}
 
-	d.Set(tagsKey, getTagListFromNestedTagList(device.Tags))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(device.Tags)))
 	return diags
 }
 
 func resourceNetboxDeviceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableDeviceWithConfigContext{}
@@ -481,12 +478,13 @@ func resourceNetboxDeviceUpdate(ctx context.Context, d *schema.ResourceData, m i
 		}
 	}
 
-	cf, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = cf
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "dcim.device")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
 	if d.HasChanges("asset_tag") {
 		if assetTagValue, ok := d.GetOk("asset_tag"); ok {
@@ -528,27 +526,27 @@ func resourceNetboxDeviceUpdate(ctx context.Context, d *schema.ResourceData, m i
 		data.Serial = serial
 	}
 
-	params := dcim.NewDcimDevicesUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimDevicesUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimDevicesUpdate(params, nil)
+	_, err = api.Dcim.DcimDevicesUpdate(params, nil)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 
 	if d.HasChange("virtual_chassis_master") && data.VirtualChassis != nil {
 		var err error
 		if vcMaster, ok := d.GetOk("virtual_chassis_master"); ok {
 			if vcMaster.(bool) {
-				err = virtualChassisUpdateMaster(api, *data.VirtualChassis, &id)
+				err = virtualChassisUpdateMaster(ctx, api, *data.VirtualChassis, &id)
 			} else {
-				err = virtualChassisUpdateMaster(api, *data.VirtualChassis, nil)
+				err = virtualChassisUpdateMaster(ctx, api, *data.VirtualChassis, nil)
 			}
 		} else {
 			// It was set before, but no longer set, remove it as master
-			err = virtualChassisUpdateMaster(api, *data.VirtualChassis, nil)
+			err = virtualChassisUpdateMaster(ctx, api, *data.VirtualChassis, nil)
 		}
 		if err != nil {
-			return diag.FromErr(err)
+			return apiErrorDiagnostics(d, err)
 		}
 	}
 
@@ -556,7 +554,7 @@ func resourceNetboxDeviceUpdate(ctx context.Context, d *schema.ResourceData, m i
 }
 
 func resourceNetboxDeviceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	var diags diag.Diagnostics
 
@@ -567,14 +565,14 @@ func resourceNetboxDeviceDelete(ctx context.Context, d *schema.ResourceData, m i
 	if virtualChassisIDValue, ok := d.GetOk("virtual_chassis_id"); ok {
 		if d.Get("virtual_chassis_master").(bool) {
 			virtualChassisID := int64(virtualChassisIDValue.(int))
-			err := virtualChassisUpdateMaster(api, virtualChassisID, nil)
+			err := virtualChassisUpdateMaster(ctx, api, virtualChassisID, nil)
 			if err != nil {
-				return diag.FromErr(err)
+				return apiErrorDiagnostics(d, err)
 			}
 		}
 	}
 
-	params := dcim.NewDcimDevicesDeleteParams().WithID(id)
+	params := dcim.NewDcimDevicesDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimDevicesDelete(params, nil)
 	if err != nil {
@@ -584,7 +582,7 @@ func resourceNetboxDeviceDelete(ctx context.Context, d *schema.ResourceData, m i
 				return nil
 			}
 		}
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 	return diags
 }
@@ -1,20 +1,21 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceNetboxDeviceConsolePort() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxDeviceConsolePortCreate,
-		Read:   resourceNetboxDeviceConsolePortRead,
-		Update: resourceNetboxDeviceConsolePortUpdate,
-		Delete: resourceNetboxDeviceConsolePortDelete,
+		CreateContext: resourceNetboxDeviceConsolePortCreate,
+		ReadContext:   resourceNetboxDeviceConsolePortRead,
+		UpdateContext: resourceNetboxDeviceConsolePortUpdate,
+		DeleteContext: resourceNetboxDeviceConsolePortDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/consoleport/):
 
@@ -60,13 +61,13 @@ func resourceNetboxDeviceConsolePort() *schema.Resource {
 			customFieldsKey: customFieldsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("dcim/console-ports", false),
 		},
 	}
 }
 
-func resourceNetboxDeviceConsolePortCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceConsolePortCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableConsolePort{
 		Device:        int64ToPtr(int64(d.Get("device_id").(int))),
@@ -79,29 +80,30 @@ func resourceNetboxDeviceConsolePortCreate(d *schema.ResourceData, m interface{}
 		MarkConnected: d.Get("mark_connected").(bool),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "dcim.consoleport")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := dcim.NewDcimConsolePortsCreateParams().WithData(&data)
+	params := dcim.NewDcimConsolePortsCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimConsolePortsCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxDeviceConsolePortRead(d, m)
+	return resourceNetboxDeviceConsolePortRead(ctx, d, m)
 }
 
-func resourceNetboxDeviceConsolePortRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceConsolePortRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimConsolePortsReadParams().WithID(id)
+	params := dcim.NewDcimConsolePortsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimConsolePortsRead(params, nil)
 
@@ -111,7 +113,7 @@ func resourceNetboxDeviceConsolePortRead(d *schema.ResourceData, m interface{})
 			d.SetId("")
 			return nil
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	consolePort := res.GetPayload()
@@ -147,17 +149,14 @@ func resourceNetboxDeviceConsolePortRead(d *schema.ResourceData, m interface{})
 	d.Set("description", consolePort.Description)
 	d.Set("mark_connected", consolePort.MarkConnected)
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
-	if cf != nil {
-		d.Set(customFieldsKey, cf)
-	}
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(customFieldsKey, withoutDefaultCustomFields(api, d, getCustomFields(ctx, api, res.GetPayload().CustomFields)))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(res.GetPayload().Tags)))
 
 	return nil
 }
 
-func resourceNetboxDeviceConsolePortUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceConsolePortUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -172,32 +171,33 @@ func resourceNetboxDeviceConsolePortUpdate(d *schema.ResourceData, m interface{}
 		MarkConnected: d.Get("mark_connected").(bool),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "dcim.consoleport")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := dcim.NewDcimConsolePortsPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimConsolePortsPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimConsolePortsPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimConsolePortsPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxDeviceConsolePortRead(d, m)
+	return resourceNetboxDeviceConsolePortRead(ctx, d, m)
 }
 
-func resourceNetboxDeviceConsolePortDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceConsolePortDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimConsolePortsDeleteParams().WithID(id)
+	params := dcim.NewDcimConsolePortsDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimConsolePortsDelete(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
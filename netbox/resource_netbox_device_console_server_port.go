@@ -1,20 +1,21 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceNetboxDeviceConsoleServerPort() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxDeviceConsoleServerPortCreate,
-		Read:   resourceNetboxDeviceConsoleServerPortRead,
-		Update: resourceNetboxDeviceConsoleServerPortUpdate,
-		Delete: resourceNetboxDeviceConsoleServerPortDelete,
+		CreateContext: resourceNetboxDeviceConsoleServerPortCreate,
+		ReadContext:   resourceNetboxDeviceConsoleServerPortRead,
+		UpdateContext: resourceNetboxDeviceConsoleServerPortUpdate,
+		DeleteContext: resourceNetboxDeviceConsoleServerPortDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/consoleserverport/):
 
@@ -60,13 +61,13 @@ func resourceNetboxDeviceConsoleServerPort() *schema.Resource {
 			customFieldsKey: customFieldsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("dcim/console-server-ports", false),
 		},
 	}
 }
 
-func resourceNetboxDeviceConsoleServerPortCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceConsoleServerPortCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableConsoleServerPort{
 		Device:        int64ToPtr(int64(d.Get("device_id").(int))),
@@ -79,29 +80,30 @@ func resourceNetboxDeviceConsoleServerPortCreate(d *schema.ResourceData, m inter
 		MarkConnected: d.Get("mark_connected").(bool),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "dcim.consoleserverport")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := dcim.NewDcimConsoleServerPortsCreateParams().WithData(&data)
+	params := dcim.NewDcimConsoleServerPortsCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimConsoleServerPortsCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxDeviceConsoleServerPortRead(d, m)
+	return resourceNetboxDeviceConsoleServerPortRead(ctx, d, m)
 }
 
-func resourceNetboxDeviceConsoleServerPortRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceConsoleServerPortRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimConsoleServerPortsReadParams().WithID(id)
+	params := dcim.NewDcimConsoleServerPortsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimConsoleServerPortsRead(params, nil)
 
@@ -111,7 +113,7 @@ func resourceNetboxDeviceConsoleServerPortRead(d *schema.ResourceData, m interfa
 			d.SetId("")
 			return nil
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	consoleServerPort := res.GetPayload()
@@ -147,17 +149,14 @@ func resourceNetboxDeviceConsoleServerPortRead(d *schema.ResourceData, m interfa
 	d.Set("description", consoleServerPort.Description)
 	d.Set("mark_connected", consoleServerPort.MarkConnected)
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
-	if cf != nil {
-		d.Set(customFieldsKey, cf)
-	}
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(customFieldsKey, withoutDefaultCustomFields(api, d, getCustomFields(ctx, api, res.GetPayload().CustomFields)))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(res.GetPayload().Tags)))
 
 	return nil
 }
 
-func resourceNetboxDeviceConsoleServerPortUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceConsoleServerPortUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -172,32 +171,33 @@ func resourceNetboxDeviceConsoleServerPortUpdate(d *schema.ResourceData, m inter
 		MarkConnected: d.Get("mark_connected").(bool),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "dcim.consoleserverport")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := dcim.NewDcimConsoleServerPortsPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimConsoleServerPortsPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimConsoleServerPortsPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimConsoleServerPortsPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxDeviceConsoleServerPortRead(d, m)
+	return resourceNetboxDeviceConsoleServerPortRead(ctx, d, m)
 }
 
-func resourceNetboxDeviceConsoleServerPortDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceConsoleServerPortDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimConsoleServerPortsDeleteParams().WithID(id)
+	params := dcim.NewDcimConsoleServerPortsDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimConsoleServerPortsDelete(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
@@ -1,20 +1,21 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceNetboxDeviceFrontPort() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxDeviceFrontPortCreate,
-		Read:   resourceNetboxDeviceFrontPortRead,
-		Update: resourceNetboxDeviceFrontPortUpdate,
-		Delete: resourceNetboxDeviceFrontPortDelete,
+		CreateContext: resourceNetboxDeviceFrontPortCreate,
+		ReadContext:   resourceNetboxDeviceFrontPortRead,
+		UpdateContext: resourceNetboxDeviceFrontPortUpdate,
+		DeleteContext: resourceNetboxDeviceFrontPortDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/frontport/):
 
@@ -67,13 +68,13 @@ func resourceNetboxDeviceFrontPort() *schema.Resource {
 			customFieldsKey: customFieldsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("dcim/front-ports", false),
 		},
 	}
 }
 
-func resourceNetboxDeviceFrontPortCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceFrontPortCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableFrontPort{
 		Device:           int64ToPtr(int64(d.Get("device_id").(int))),
@@ -88,29 +89,30 @@ func resourceNetboxDeviceFrontPortCreate(d *schema.ResourceData, m interface{})
 		MarkConnected:    d.Get("mark_connected").(bool),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "dcim.frontport")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := dcim.NewDcimFrontPortsCreateParams().WithData(&data)
+	params := dcim.NewDcimFrontPortsCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimFrontPortsCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxDeviceFrontPortRead(d, m)
+	return resourceNetboxDeviceFrontPortRead(ctx, d, m)
 }
 
-func resourceNetboxDeviceFrontPortRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceFrontPortRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimFrontPortsReadParams().WithID(id)
+	params := dcim.NewDcimFrontPortsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimFrontPortsRead(params, nil)
 
@@ -121,7 +123,7 @@ func resourceNetboxDeviceFrontPortRead(d *schema.ResourceData, m interface{}) er
 			d.SetId("")
 			return nil
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	frontPort := res.GetPayload()
@@ -159,17 +161,14 @@ func resourceNetboxDeviceFrontPortRead(d *schema.ResourceData, m interface{}) er
 	d.Set("description", frontPort.Description)
 	d.Set("mark_connected", frontPort.MarkConnected)
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
-	if cf != nil {
-		d.Set(customFieldsKey, cf)
-	}
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(customFieldsKey, withoutDefaultCustomFields(api, d, getCustomFields(ctx, api, res.GetPayload().CustomFields)))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(res.GetPayload().Tags)))
 
 	return nil
 }
 
-func resourceNetboxDeviceFrontPortUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceFrontPortUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -186,32 +185,33 @@ func resourceNetboxDeviceFrontPortUpdate(d *schema.ResourceData, m interface{})
 		MarkConnected:    d.Get("mark_connected").(bool),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "dcim.frontport")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := dcim.NewDcimFrontPortsPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimFrontPortsPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimFrontPortsPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimFrontPortsPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxDeviceFrontPortRead(d, m)
+	return resourceNetboxDeviceFrontPortRead(ctx, d, m)
 }
 
-func resourceNetboxDeviceFrontPortDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceFrontPortDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimFrontPortsDeleteParams().WithID(id)
+	params := dcim.NewDcimFrontPortsDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimFrontPortsDelete(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
@@ -5,7 +5,6 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -56,6 +55,7 @@ func resourceNetboxDeviceInterface() *schema.Resource {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ValidateFunc: validation.IsMACAddress,
+				Description:  "Not supported by Netbox 4.2 and later, where MAC addresses are separate objects.",
 				// Netbox converts MAC addresses always to uppercase
 				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
 					return strings.EqualFold(old, new)
@@ -102,14 +102,16 @@ func resourceNetboxDeviceInterface() *schema.Resource {
 				Optional: true,
 			},
 		},
+		// Netbox 4.2 made the MAC address of interfaces read-only
+		CustomizeDiff: requireNetboxVersionForAttribute("mac_address", "", "4.2.0"),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("dcim/interfaces", false),
 		},
 	}
 }
 
 func resourceNetboxDeviceInterfaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	var diags diag.Diagnostics
 
@@ -120,7 +122,7 @@ func resourceNetboxDeviceInterfaceCreate(ctx context.Context, d *schema.Resource
 	enabled := d.Get("enabled").(bool)
 	mgmtonly := d.Get("mgmtonly").(bool)
 	mode := d.Get("mode").(string)
-	tags, diagnostics := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, diagnostics := getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 	if diagnostics != nil {
 		diags = append(diags, diagnostics...)
 	}
@@ -160,11 +162,11 @@ func resourceNetboxDeviceInterfaceCreate(ctx context.Context, d *schema.Resource
 		data.UntaggedVlan = int64ToPtr(int64(untaggedVlan))
 	}
 
-	params := dcim.NewDcimInterfacesCreateParams().WithData(&data)
+	params := dcim.NewDcimInterfacesCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimInterfacesCreate(params, nil)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
@@ -173,12 +175,12 @@ func resourceNetboxDeviceInterfaceCreate(ctx context.Context, d *schema.Resource
 }
 
 func resourceNetboxDeviceInterfaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
 	var diags diag.Diagnostics
 
-	params := dcim.NewDcimInterfacesReadParams().WithID(id)
+	params := dcim.NewDcimInterfacesReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimInterfacesRead(params, nil)
 	if err != nil {
@@ -190,7 +192,7 @@ func resourceNetboxDeviceInterfaceRead(ctx context.Context, d *schema.ResourceDa
 				return nil
 			}
 		}
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 
 	iface := res.GetPayload()
@@ -204,7 +206,7 @@ func resourceNetboxDeviceInterfaceRead(ctx context.Context, d *schema.ResourceDa
 	d.Set("mac_address", iface.MacAddress)
 	d.Set("mtu", iface.Mtu)
 	d.Set("speed", iface.Speed)
-	d.Set(tagsKey, getTagListFromNestedTagList(iface.Tags))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(iface.Tags)))
 	d.Set("tagged_vlans", getIDsFromNestedVLANDevice(iface.TaggedVlans))
 	d.Set("device_id", iface.Device.ID)
 
@@ -225,7 +227,7 @@ func resourceNetboxDeviceInterfaceRead(ctx context.Context, d *schema.ResourceDa
 }
 
 func resourceNetboxDeviceInterfaceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	var diags diag.Diagnostics
 
@@ -238,7 +240,7 @@ func resourceNetboxDeviceInterfaceUpdate(ctx context.Context, d *schema.Resource
 	enabled := d.Get("enabled").(bool)
 	mgmtonly := d.Get("mgmtonly").(bool)
 	mode := d.Get("mode").(string)
-	tags, diagnostics := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, diagnostics := getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 	if diagnostics != nil {
 		diags = append(diags, diagnostics...)
 	}
@@ -285,20 +287,20 @@ func resourceNetboxDeviceInterfaceUpdate(ctx context.Context, d *schema.Resource
 		data.UntaggedVlan = &untaggedvlan
 	}
 
-	params := dcim.NewDcimInterfacesPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimInterfacesPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 	_, err := api.Dcim.DcimInterfacesPartialUpdate(params, nil)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 
 	return diags
 }
 
 func resourceNetboxDeviceInterfaceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimInterfacesDeleteParams().WithID(id)
+	params := dcim.NewDcimInterfacesDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimInterfacesDelete(params, nil)
 	if err != nil {
@@ -308,7 +310,7 @@ func resourceNetboxDeviceInterfaceDelete(ctx context.Context, d *schema.Resource
 				return nil
 			}
 		}
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxDeviceModuleBay() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxDeviceModuleBayCreate,
-		Read:   resourceNetboxDeviceModuleBayRead,
-		Update: resourceNetboxDeviceModuleBayUpdate,
-		Delete: resourceNetboxDeviceModuleBayDelete,
+		CreateContext: resourceNetboxDeviceModuleBayCreate,
+		ReadContext:   resourceNetboxDeviceModuleBayRead,
+		UpdateContext: resourceNetboxDeviceModuleBayUpdate,
+		DeleteContext: resourceNetboxDeviceModuleBayDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/modulebay/):
 
@@ -47,13 +48,13 @@ func resourceNetboxDeviceModuleBay() *schema.Resource {
 			customFieldsKey: customFieldsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("dcim/module-bays", false),
 		},
 	}
 }
 
-func resourceNetboxDeviceModuleBayCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceModuleBayCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableModuleBay{
 		Device:      int64ToPtr(int64(d.Get("device_id").(int))),
@@ -63,29 +64,30 @@ func resourceNetboxDeviceModuleBayCreate(d *schema.ResourceData, m interface{})
 		Description: getOptionalStr(d, "description", false),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "dcim.modulebay")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := dcim.NewDcimModuleBaysCreateParams().WithData(&data)
+	params := dcim.NewDcimModuleBaysCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimModuleBaysCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxDeviceModuleBayRead(d, m)
+	return resourceNetboxDeviceModuleBayRead(ctx, d, m)
 }
 
-func resourceNetboxDeviceModuleBayRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceModuleBayRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimModuleBaysReadParams().WithID(id)
+	params := dcim.NewDcimModuleBaysReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimModuleBaysRead(params, nil)
 
@@ -96,7 +98,7 @@ func resourceNetboxDeviceModuleBayRead(d *schema.ResourceData, m interface{}) er
 			d.SetId("")
 			return nil
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	moduleBay := res.GetPayload()
@@ -112,17 +114,14 @@ func resourceNetboxDeviceModuleBayRead(d *schema.ResourceData, m interface{}) er
 	d.Set("position", moduleBay.Position)
 	d.Set("description", moduleBay.Description)
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
-	if cf != nil {
-		d.Set(customFieldsKey, cf)
-	}
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(customFieldsKey, withoutDefaultCustomFields(api, d, getCustomFields(ctx, api, res.GetPayload().CustomFields)))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(res.GetPayload().Tags)))
 
 	return nil
 }
 
-func resourceNetboxDeviceModuleBayUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceModuleBayUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -134,32 +133,33 @@ func resourceNetboxDeviceModuleBayUpdate(d *schema.ResourceData, m interface{})
 		Description: getOptionalStr(d, "description", true),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "dcim.modulebay")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := dcim.NewDcimModuleBaysPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimModuleBaysPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimModuleBaysPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimModuleBaysPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxDeviceModuleBayRead(d, m)
+	return resourceNetboxDeviceModuleBayRead(ctx, d, m)
 }
 
-func resourceNetboxDeviceModuleBayDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceModuleBayDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimModuleBaysDeleteParams().WithID(id)
+	params := dcim.NewDcimModuleBaysDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimModuleBaysDelete(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxPowerFeed() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxPowerFeedCreate,
-		Read:   resourceNetboxPowerFeedRead,
-		Update: resourceNetboxPowerFeedUpdate,
-		Delete: resourceNetboxPowerFeedDelete,
+		CreateContext: resourceNetboxPowerFeedCreate,
+		ReadContext:   resourceNetboxPowerFeedRead,
+		UpdateContext: resourceNetboxPowerFeedUpdate,
+		DeleteContext: resourceNetboxPowerFeedDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/powerfeed/):
 
@@ -88,13 +89,13 @@ func resourceNetboxPowerFeed() *schema.Resource {
 			customFieldsKey: customFieldsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("dcim/power-feeds", false),
 		},
 	}
 }
 
-func resourceNetboxPowerFeedCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPowerFeedCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritablePowerFeed{
 		PowerPanel:     int64ToPtr(int64(d.Get("power_panel_id").(int))),
@@ -112,29 +113,30 @@ func resourceNetboxPowerFeedCreate(d *schema.ResourceData, m interface{}) error
 		Comments:       getOptionalStr(d, "comments", false),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "dcim.powerfeed")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := dcim.NewDcimPowerFeedsCreateParams().WithData(&data)
+	params := dcim.NewDcimPowerFeedsCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimPowerFeedsCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxPowerFeedRead(d, m)
+	return resourceNetboxPowerFeedRead(ctx, d, m)
 }
 
-func resourceNetboxPowerFeedRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPowerFeedRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimPowerFeedsReadParams().WithID(id)
+	params := dcim.NewDcimPowerFeedsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimPowerFeedsRead(params, nil)
 
@@ -145,7 +147,7 @@ func resourceNetboxPowerFeedRead(d *schema.ResourceData, m interface{}) error {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	powerFeed := res.GetPayload()
@@ -196,17 +198,14 @@ func resourceNetboxPowerFeedRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("description", powerFeed.Description)
 	d.Set("comments", powerFeed.Comments)
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
-	if cf != nil {
-		d.Set(customFieldsKey, cf)
-	}
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(customFieldsKey, withoutDefaultCustomFields(api, d, getCustomFields(ctx, api, res.GetPayload().CustomFields)))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(res.GetPayload().Tags)))
 
 	return nil
 }
 
-func resourceNetboxPowerFeedUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPowerFeedUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -226,32 +225,33 @@ func resourceNetboxPowerFeedUpdate(d *schema.ResourceData, m interface{}) error
 		Comments:       getOptionalStr(d, "comments", true),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "dcim.powerfeed")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := dcim.NewDcimPowerFeedsPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimPowerFeedsPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimPowerFeedsPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimPowerFeedsPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxPowerFeedRead(d, m)
+	return resourceNetboxPowerFeedRead(ctx, d, m)
 }
 
-func resourceNetboxPowerFeedDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPowerFeedDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimPowerFeedsDeleteParams().WithID(id)
+	params := dcim.NewDcimPowerFeedsDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimPowerFeedsDelete(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
@@ -1,20 +1,21 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceNetboxDevicePowerPort() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxDevicePowerPortCreate,
-		Read:   resourceNetboxDevicePowerPortRead,
-		Update: resourceNetboxDevicePowerPortUpdate,
-		Delete: resourceNetboxDevicePowerPortDelete,
+		CreateContext: resourceNetboxDevicePowerPortCreate,
+		ReadContext:   resourceNetboxDevicePowerPortRead,
+		UpdateContext: resourceNetboxDevicePowerPortUpdate,
+		DeleteContext: resourceNetboxDevicePowerPortDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/powerport/):
 
@@ -63,13 +64,13 @@ func resourceNetboxDevicePowerPort() *schema.Resource {
 			customFieldsKey: customFieldsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("dcim/power-ports", false),
 		},
 	}
 }
 
-func resourceNetboxDevicePowerPortCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDevicePowerPortCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritablePowerPort{
 		Device:        int64ToPtr(int64(d.Get("device_id").(int))),
@@ -83,29 +84,30 @@ func resourceNetboxDevicePowerPortCreate(d *schema.ResourceData, m interface{})
 		MarkConnected: d.Get("mark_connected").(bool),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "dcim.powerport")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := dcim.NewDcimPowerPortsCreateParams().WithData(&data)
+	params := dcim.NewDcimPowerPortsCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimPowerPortsCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxDevicePowerPortRead(d, m)
+	return resourceNetboxDevicePowerPortRead(ctx, d, m)
 }
 
-func resourceNetboxDevicePowerPortRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDevicePowerPortRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimPowerPortsReadParams().WithID(id)
+	params := dcim.NewDcimPowerPortsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimPowerPortsRead(params, nil)
 
@@ -116,7 +118,7 @@ func resourceNetboxDevicePowerPortRead(d *schema.ResourceData, m interface{}) er
 			d.SetId("")
 			return nil
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	powerPort := res.GetPayload()
@@ -149,17 +151,14 @@ func resourceNetboxDevicePowerPortRead(d *schema.ResourceData, m interface{}) er
 	d.Set("description", powerPort.Description)
 	d.Set("mark_connected", powerPort.MarkConnected)
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
-	if cf != nil {
-		d.Set(customFieldsKey, cf)
-	}
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(customFieldsKey, withoutDefaultCustomFields(api, d, getCustomFields(ctx, api, res.GetPayload().CustomFields)))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(res.GetPayload().Tags)))
 
 	return nil
 }
 
-func resourceNetboxDevicePowerPortUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDevicePowerPortUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -175,32 +174,33 @@ func resourceNetboxDevicePowerPortUpdate(d *schema.ResourceData, m interface{})
 		MarkConnected: d.Get("mark_connected").(bool),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "dcim.powerport")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := dcim.NewDcimPowerPortsPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimPowerPortsPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimPowerPortsPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimPowerPortsPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxDevicePowerPortRead(d, m)
+	return resourceNetboxDevicePowerPortRead(ctx, d, m)
 }
 
-func resourceNetboxDevicePowerPortDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDevicePowerPortDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimPowerPortsDeleteParams().WithID(id)
+	params := dcim.NewDcimPowerPortsDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimPowerPortsDelete(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxDevicePrimaryIP() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxDevicePrimaryIPCreate,
-		Read:   resourceNetboxDevicePrimaryIPRead,
-		Update: resourceNetboxDevicePrimaryIPUpdate,
-		Delete: resourceNetboxDevicePrimaryIPDelete,
+		CreateContext: resourceNetboxDevicePrimaryIPCreate,
+		ReadContext:   resourceNetboxDevicePrimaryIPRead,
+		UpdateContext: resourceNetboxDevicePrimaryIPUpdate,
+		DeleteContext: resourceNetboxDevicePrimaryIPDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):This resource is used to define the primary IP for a given device. The primary IP is reflected in the device Netbox UI, which identifies the Primary IPv4 and IPv6 addresses.`,
 
@@ -36,21 +37,21 @@ func resourceNetboxDevicePrimaryIP() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("dcim/devices", false),
 		},
 	}
 }
 
-func resourceNetboxDevicePrimaryIPCreate(d *schema.ResourceData, m interface{}) error {
+func resourceNetboxDevicePrimaryIPCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId(strconv.Itoa(d.Get("device_id").(int)))
 
-	return resourceNetboxDevicePrimaryIPUpdate(d, m)
+	return resourceNetboxDevicePrimaryIPUpdate(ctx, d, m)
 }
 
-func resourceNetboxDevicePrimaryIPRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDevicePrimaryIPRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimDevicesReadParams().WithID(id)
+	params := dcim.NewDcimDevicesReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimDevicesRead(params, nil)
 	if err != nil {
@@ -62,7 +63,7 @@ func resourceNetboxDevicePrimaryIPRead(d *schema.ResourceData, m interface{}) er
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	IPAddressVersion := d.Get("ip_address_version")
@@ -81,8 +82,8 @@ func resourceNetboxDevicePrimaryIPRead(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-func resourceNetboxDevicePrimaryIPUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDevicePrimaryIPUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	deviceID := int64(d.Get("device_id").(int))
 	IPAddressID := int64(d.Get("ip_address_id").(int))
@@ -91,10 +92,10 @@ func resourceNetboxDevicePrimaryIPUpdate(d *schema.ResourceData, m interface{})
 	// because the go-netbox library does not have patch support atm, we have to get the whole object and re-put it
 
 	// first, get the device
-	readParams := dcim.NewDcimDevicesReadParams().WithID(deviceID)
+	readParams := dcim.NewDcimDevicesReadParamsWithContext(ctx).WithID(deviceID)
 	res, err := api.Dcim.DcimDevicesRead(readParams, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	device := res.GetPayload()
@@ -147,17 +148,17 @@ func resourceNetboxDevicePrimaryIPUpdate(d *schema.ResourceData, m interface{})
 		}
 	}
 
-	updateParams := dcim.NewDcimDevicesPartialUpdateParams().WithID(deviceID).WithData(&data)
+	updateParams := dcim.NewDcimDevicesPartialUpdateParamsWithContext(ctx).WithID(deviceID).WithData(&data)
 
 	_, err = api.Dcim.DcimDevicesPartialUpdate(updateParams, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
-	return resourceNetboxDevicePrimaryIPRead(d, m)
+	return resourceNetboxDevicePrimaryIPRead(ctx, d, m)
 }
 
-func resourceNetboxDevicePrimaryIPDelete(d *schema.ResourceData, m interface{}) error {
+func resourceNetboxDevicePrimaryIPDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Set ip_address_id to minus one and go to update. Update will set nil
 	d.Set("ip_address_id", -1)
-	return resourceNetboxDevicePrimaryIPUpdate(d, m)
+	return resourceNetboxDevicePrimaryIPUpdate(ctx, d, m)
 }
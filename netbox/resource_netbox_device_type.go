@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxDeviceType() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxDeviceTypeCreate,
-		Read:   resourceNetboxDeviceTypeRead,
-		Update: resourceNetboxDeviceTypeUpdate,
-		Delete: resourceNetboxDeviceTypeDelete,
+		CreateContext: resourceNetboxDeviceTypeCreate,
+		ReadContext:   resourceNetboxDeviceTypeRead,
+		UpdateContext: resourceNetboxDeviceTypeUpdate,
+		DeleteContext: resourceNetboxDeviceTypeDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/features/device-types/#device-types_1):
 
@@ -52,13 +53,13 @@ func resourceNetboxDeviceType() *schema.Resource {
 			tagsKey: tagsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("dcim/device-types", true),
 		},
 	}
 }
 
-func resourceNetboxDeviceTypeCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceTypeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableDeviceType{}
 
@@ -90,24 +91,24 @@ func resourceNetboxDeviceTypeCreate(d *schema.ResourceData, m interface{}) error
 		data.IsFullDepth = isFullDepthValue.(bool)
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	params := dcim.NewDcimDeviceTypesCreateParams().WithData(&data)
+	params := dcim.NewDcimDeviceTypesCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimDeviceTypesCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxDeviceTypeRead(d, m)
+	return resourceNetboxDeviceTypeRead(ctx, d, m)
 }
 
-func resourceNetboxDeviceTypeRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceTypeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimDeviceTypesReadParams().WithID(id)
+	params := dcim.NewDcimDeviceTypesReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimDeviceTypesRead(params, nil)
 
@@ -120,7 +121,7 @@ func resourceNetboxDeviceTypeRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	deviceType := res.GetPayload()
@@ -130,13 +131,13 @@ func resourceNetboxDeviceTypeRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("part_number", deviceType.PartNumber)
 	d.Set("u_height", deviceType.UHeight)
 	d.Set("is_full_depth", deviceType.IsFullDepth)
-	d.Set(tagsKey, getTagListFromNestedTagList(deviceType.Tags))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(deviceType.Tags)))
 
 	return nil
 }
 
-func resourceNetboxDeviceTypeUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceTypeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableDeviceType{}
@@ -169,23 +170,23 @@ func resourceNetboxDeviceTypeUpdate(d *schema.ResourceData, m interface{}) error
 		data.IsFullDepth = isFullDepthValue.(bool)
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	params := dcim.NewDcimDeviceTypesPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimDeviceTypesPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Dcim.DcimDeviceTypesPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxDeviceTypeRead(d, m)
+	return resourceNetboxDeviceTypeRead(ctx, d, m)
 }
 
-func resourceNetboxDeviceTypeDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxDeviceTypeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimDeviceTypesDeleteParams().WithID(id)
+	params := dcim.NewDcimDeviceTypesDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimDeviceTypesDelete(params, nil)
 	if err != nil {
@@ -195,7 +196,7 @@ func resourceNetboxDeviceTypeDelete(d *schema.ResourceData, m interface{}) error
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
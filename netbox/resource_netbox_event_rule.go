@@ -1,12 +1,13 @@
 package netbox
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/extras"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -15,10 +16,10 @@ var resourceNetboxEventRuleActionTypeOptions = []string{"webhook"}
 
 func resourceNetboxEventRule() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxEventRuleCreate,
-		Read:   resourceNetboxEventRuleRead,
-		Update: resourceNetboxEventRuleUpdate,
-		Delete: resourceNetboxEventRuleDelete,
+		CreateContext: resourceNetboxEventRuleCreate,
+		ReadContext:   resourceNetboxEventRuleRead,
+		UpdateContext: resourceNetboxEventRuleUpdate,
+		DeleteContext: resourceNetboxEventRuleDelete,
 
 		Description: `:meta:subcategory:Extras:From the [official documentation](https://docs.netbox.dev/en/stable/features/event-rules/):
 
@@ -74,13 +75,13 @@ func resourceNetboxEventRule() *schema.Resource {
 			tagsKey: tagsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("extras/event-rules", false),
 		},
 	}
 }
 
-func resourceNetboxEventRuleCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxEventRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := &models.WritableEventRule{}
 
@@ -103,7 +104,7 @@ func resourceNetboxEventRuleCreate(d *schema.ResourceData, m interface{}) error
 	data.Enabled = enabled
 	data.ActionObjectID = getOptionalInt(d, "action_object_id")
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, _ := getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 	data.Tags = tags
 
 	ctypes := d.Get("content_types").(*schema.Set).List()
@@ -117,27 +118,27 @@ func resourceNetboxEventRuleCreate(d *schema.ResourceData, m interface{}) error
 		var conditions any
 		err := json.Unmarshal([]byte(conditionsData.(string)), &conditions)
 		if err != nil {
-			return err
+			return apiErrorDiagnostics(d, err)
 		}
 		data.Conditions = conditions
 	}
 
-	params := extras.NewExtrasEventRulesCreateParams().WithData(data)
+	params := extras.NewExtrasEventRulesCreateParamsWithContext(ctx).WithData(data)
 
 	res, err := api.Extras.ExtrasEventRulesCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxEventRuleRead(d, m)
+	return resourceNetboxEventRuleRead(ctx, d, m)
 }
 
-func resourceNetboxEventRuleRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxEventRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := extras.NewExtrasEventRulesReadParams().WithID(id)
+	params := extras.NewExtrasEventRulesReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Extras.ExtrasEventRulesRead(params, nil)
 	if err != nil {
@@ -148,7 +149,7 @@ func resourceNetboxEventRuleRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	eventRule := res.GetPayload()
@@ -164,18 +165,18 @@ func resourceNetboxEventRuleRead(d *schema.ResourceData, m interface{}) error {
 	if eventRule.Conditions != nil {
 		conditions, err := json.Marshal(eventRule.Conditions)
 		if err != nil {
-			return err
+			return apiErrorDiagnostics(d, err)
 		}
 		d.Set("conditions", string(conditions))
 	}
 
-	d.Set(tagsKey, getTagListFromNestedTagList(eventRule.Tags))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(eventRule.Tags)))
 
 	return nil
 }
 
-func resourceNetboxEventRuleUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxEventRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableEventRule{}
@@ -203,12 +204,12 @@ func resourceNetboxEventRuleUpdate(d *schema.ResourceData, m interface{}) error
 		var conditions any
 		err := json.Unmarshal([]byte(conditionsData.(string)), &conditions)
 		if err != nil {
-			return err
+			return apiErrorDiagnostics(d, err)
 		}
 		data.Conditions = conditions
 	}
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, _ := getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 	data.Tags = tags
 
 	ctypes := d.Get("content_types").(*schema.Set).List()
@@ -218,21 +219,21 @@ func resourceNetboxEventRuleUpdate(d *schema.ResourceData, m interface{}) error
 	}
 	data.ObjectTypes = objectTypes
 
-	params := extras.NewExtrasEventRulesUpdateParams().WithID(id).WithData(&data)
+	params := extras.NewExtrasEventRulesUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Extras.ExtrasEventRulesUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxEventRuleRead(d, m)
+	return resourceNetboxEventRuleRead(ctx, d, m)
 }
 
-func resourceNetboxEventRuleDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxEventRuleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := extras.NewExtrasEventRulesDeleteParams().WithID(id)
+	params := extras.NewExtrasEventRulesDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Extras.ExtrasEventRulesDelete(params, nil)
 	if err != nil {
@@ -242,7 +243,7 @@ func resourceNetboxEventRuleDelete(d *schema.ResourceData, m interface{}) error
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
@@ -1,20 +1,21 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/users"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceNetboxGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxGroupCreate,
-		Read:   resourceNetboxGroupRead,
-		Update: resourceNetboxGroupUpdate,
-		Delete: resourceNetboxGroupDelete,
+		CreateContext: resourceNetboxGroupCreate,
+		ReadContext:   resourceNetboxGroupRead,
+		UpdateContext: resourceNetboxGroupUpdate,
+		DeleteContext: resourceNetboxGroupDelete,
 
 		Description: `:meta:subcategory:Authentication:This resource is used to manage groups.`,
 
@@ -25,32 +26,32 @@ func resourceNetboxGroup() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("users/groups", false),
 		},
 	}
 }
-func resourceNetboxGroupCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	data := models.Group{}
 
 	name := d.Get("name").(string)
 
 	data.Name = &name
 
-	params := users.NewUsersGroupsCreateParams().WithData(&data)
+	params := users.NewUsersGroupsCreateParamsWithContext(ctx).WithData(&data)
 	res, err := api.Users.UsersGroupsCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxGroupRead(d, m)
+	return resourceNetboxGroupRead(ctx, d, m)
 }
 
-func resourceNetboxGroupRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := users.NewUsersGroupsReadParams().WithID(id)
+	params := users.NewUsersGroupsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Users.UsersGroupsRead(params, nil)
 	if err != nil {
@@ -62,7 +63,7 @@ func resourceNetboxGroupRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	if res.GetPayload().Name != nil {
@@ -72,8 +73,8 @@ func resourceNetboxGroupRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceNetboxGroupUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.Group{}
 
@@ -81,18 +82,18 @@ func resourceNetboxGroupUpdate(d *schema.ResourceData, m interface{}) error {
 
 	data.Name = &name
 
-	params := users.NewUsersGroupsUpdateParams().WithID(id).WithData(&data)
+	params := users.NewUsersGroupsUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 	_, err := api.Users.UsersGroupsUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
-	return resourceNetboxGroupRead(d, m)
+	return resourceNetboxGroupRead(ctx, d, m)
 }
 
-func resourceNetboxGroupDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := users.NewUsersGroupsDeleteParams().WithID(id)
+	params := users.NewUsersGroupsDeleteParamsWithContext(ctx).WithID(id)
 	_, err := api.Users.UsersGroupsDelete(params, nil)
 	if err != nil {
 		if errresp, ok := err.(*users.UsersGroupsDeleteDefault); ok {
@@ -101,7 +102,7 @@ func resourceNetboxGroupDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	d.SetId("")
 	return nil
@@ -5,7 +5,6 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/virtualization"
 	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -47,6 +46,7 @@ func resourceNetboxInterface() *schema.Resource {
 				Type:         schema.TypeString,
 				Optional:     true,
 				ValidateFunc: validation.IsMACAddress,
+				Description:  "Not supported by Netbox 4.2 and later, where MAC addresses are separate objects.",
 				// Netbox converts MAC addresses always to uppercase
 				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
 					return strings.EqualFold(old, new)
@@ -81,14 +81,16 @@ func resourceNetboxInterface() *schema.Resource {
 				Optional: true,
 			},
 		},
+		// Netbox 4.2 made the MAC address of interfaces read-only
+		CustomizeDiff: requireNetboxVersionForAttribute("mac_address", "", "4.2.0"),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("virtualization/interfaces", false),
 		},
 	}
 }
 
 func resourceNetboxInterfaceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	var diags diag.Diagnostics
 
@@ -96,7 +98,7 @@ func resourceNetboxInterfaceCreate(ctx context.Context, d *schema.ResourceData,
 	description := d.Get("description").(string)
 	enabled := d.Get("enabled").(bool)
 	mode := d.Get("mode").(string)
-	tags, diagnostics := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, diagnostics := getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 	if diagnostics != nil {
 		diags = append(diags, diagnostics...)
 	}
@@ -121,11 +123,11 @@ func resourceNetboxInterfaceCreate(ctx context.Context, d *schema.ResourceData,
 	if untaggedVlan, ok := d.Get("untagged_vlan").(int); ok && untaggedVlan != 0 {
 		data.UntaggedVlan = int64ToPtr(int64(untaggedVlan))
 	}
-	params := virtualization.NewVirtualizationInterfacesCreateParams().WithData(&data)
+	params := virtualization.NewVirtualizationInterfacesCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Virtualization.VirtualizationInterfacesCreate(params, nil)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
@@ -134,12 +136,12 @@ func resourceNetboxInterfaceCreate(ctx context.Context, d *schema.ResourceData,
 }
 
 func resourceNetboxInterfaceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
 	var diags diag.Diagnostics
 
-	params := virtualization.NewVirtualizationInterfacesReadParams().WithID(id)
+	params := virtualization.NewVirtualizationInterfacesReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Virtualization.VirtualizationInterfacesRead(params, nil)
 	if err != nil {
@@ -151,7 +153,7 @@ func resourceNetboxInterfaceRead(ctx context.Context, d *schema.ResourceData, m
 				return nil
 			}
 		}
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 
 	iface := res.GetPayload()
@@ -161,7 +163,7 @@ func resourceNetboxInterfaceRead(ctx context.Context, d *schema.ResourceData, m
 	d.Set("enabled", iface.Enabled)
 	d.Set("mac_address", iface.MacAddress)
 	d.Set("mtu", iface.Mtu)
-	d.Set(tagsKey, getTagListFromNestedTagList(iface.Tags))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(iface.Tags)))
 	d.Set("tagged_vlans", getIDsFromNestedVLAN(iface.TaggedVlans))
 	d.Set("virtual_machine_id", iface.VirtualMachine.ID)
 
@@ -176,7 +178,7 @@ func resourceNetboxInterfaceRead(ctx context.Context, d *schema.ResourceData, m
 }
 
 func resourceNetboxInterfaceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	var diags diag.Diagnostics
 
@@ -186,7 +188,7 @@ func resourceNetboxInterfaceUpdate(ctx context.Context, d *schema.ResourceData,
 	description := d.Get("description").(string)
 	enabled := d.Get("enabled").(bool)
 	mode := d.Get("mode").(string)
-	tags, diagnostics := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, diagnostics := getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 	if diagnostics != nil {
 		diags = append(diags, diagnostics...)
 	}
@@ -216,20 +218,20 @@ func resourceNetboxInterfaceUpdate(ctx context.Context, d *schema.ResourceData,
 		data.UntaggedVlan = &untaggedvlan
 	}
 
-	params := virtualization.NewVirtualizationInterfacesPartialUpdateParams().WithID(id).WithData(&data)
+	params := virtualization.NewVirtualizationInterfacesPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 	_, err := api.Virtualization.VirtualizationInterfacesPartialUpdate(params, nil)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 
 	return diags
 }
 
 func resourceNetboxInterfaceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := virtualization.NewVirtualizationInterfacesDeleteParams().WithID(id)
+	params := virtualization.NewVirtualizationInterfacesDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Virtualization.VirtualizationInterfacesDelete(params, nil)
 	if err != nil {
@@ -239,7 +241,7 @@ func resourceNetboxInterfaceDelete(ctx context.Context, d *schema.ResourceData,
 				return nil
 			}
 		}
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
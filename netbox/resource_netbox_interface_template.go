@@ -4,7 +4,6 @@ import (
 	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -58,13 +57,13 @@ func resourceNetboxInterfaceTemplate() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("dcim/interface-templates", false),
 		},
 	}
 }
 
 func resourceNetboxInterfaceTemplateCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	var diags diag.Diagnostics
 
@@ -88,11 +87,11 @@ func resourceNetboxInterfaceTemplateCreate(ctx context.Context, d *schema.Resour
 	if moduleTypeID, ok := d.Get("module_type_id").(int); ok && moduleTypeID != 0 {
 		data.ModuleType = int64ToPtr(int64(moduleTypeID))
 	}
-	params := dcim.NewDcimInterfaceTemplatesCreateParams().WithData(&data)
+	params := dcim.NewDcimInterfaceTemplatesCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimInterfaceTemplatesCreate(params, nil)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
@@ -101,12 +100,12 @@ func resourceNetboxInterfaceTemplateCreate(ctx context.Context, d *schema.Resour
 }
 
 func resourceNetboxInterfaceTemplateRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
 	var diags diag.Diagnostics
 
-	params := dcim.NewDcimInterfaceTemplatesReadParams().WithID(id)
+	params := dcim.NewDcimInterfaceTemplatesReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimInterfaceTemplatesRead(params, nil)
 	if err != nil {
@@ -118,7 +117,7 @@ func resourceNetboxInterfaceTemplateRead(ctx context.Context, d *schema.Resource
 				return nil
 			}
 		}
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 
 	tmpl := res.GetPayload()
@@ -140,7 +139,7 @@ func resourceNetboxInterfaceTemplateRead(ctx context.Context, d *schema.Resource
 }
 
 func resourceNetboxInterfaceTemplateUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	var diags diag.Diagnostics
 
@@ -170,20 +169,20 @@ func resourceNetboxInterfaceTemplateUpdate(ctx context.Context, d *schema.Resour
 		data.ModuleType = &moduleTypeID
 	}
 
-	params := dcim.NewDcimInterfaceTemplatesPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimInterfaceTemplatesPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 	_, err := api.Dcim.DcimInterfaceTemplatesPartialUpdate(params, nil)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 
 	return diags
 }
 
 func resourceNetboxInterfaceTemplateDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimInterfaceTemplatesDeleteParams().WithID(id)
+	params := dcim.NewDcimInterfaceTemplatesDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimInterfaceTemplatesDelete(params, nil)
 	if err != nil {
@@ -193,7 +192,7 @@ func resourceNetboxInterfaceTemplateDelete(ctx context.Context, d *schema.Resour
 				return nil
 			}
 		}
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
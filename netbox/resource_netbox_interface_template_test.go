@@ -5,7 +5,6 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	log "github.com/sirupsen/logrus"
@@ -131,7 +130,7 @@ func init() {
 			if err != nil {
 				return fmt.Errorf("Error getting client: %s", err)
 			}
-			api := m.(*client.NetBoxAPI)
+			api := m.(*providerState)
 			params := dcim.NewDcimInterfaceTemplatesListParams()
 			res, err := api.Dcim.DcimInterfaceTemplatesList(params, nil)
 			if err != nil {
@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxInventoryItem() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxInventoryItemCreate,
-		Read:   resourceNetboxInventoryItemRead,
-		Update: resourceNetboxInventoryItemUpdate,
-		Delete: resourceNetboxInventoryItemDelete,
+		CreateContext: resourceNetboxInventoryItemCreate,
+		ReadContext:   resourceNetboxInventoryItemRead,
+		UpdateContext: resourceNetboxInventoryItemUpdate,
+		DeleteContext: resourceNetboxInventoryItemDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/inventoryitem/):
 
@@ -89,13 +90,13 @@ func resourceNetboxInventoryItem() *schema.Resource {
 			customFieldsKey: customFieldsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("dcim/inventory-items", false),
 		},
 	}
 }
 
-func resourceNetboxInventoryItemCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxInventoryItemCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	data := models.WritableInventoryItem{
 		Device:       int64ToPtr(int64(d.Get("device_id").(int))),
 		Name:         strToPtr(d.Get("name").(string)),
@@ -118,29 +119,30 @@ func resourceNetboxInventoryItemCreate(d *schema.ResourceData, m interface{}) er
 		data.ComponentID = getOptionalInt(d, "component_id")
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "dcim.inventoryitem")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := dcim.NewDcimInventoryItemsCreateParams().WithData(&data)
+	params := dcim.NewDcimInventoryItemsCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimInventoryItemsCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxInventoryItemRead(d, m)
+	return resourceNetboxInventoryItemRead(ctx, d, m)
 }
 
-func resourceNetboxInventoryItemRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxInventoryItemRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimInventoryItemsReadParams().WithID(id)
+	params := dcim.NewDcimInventoryItemsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimInventoryItemsRead(params, nil)
 
@@ -151,7 +153,7 @@ func resourceNetboxInventoryItemRead(d *schema.ResourceData, m interface{}) erro
 			d.SetId("")
 			return nil
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	item := res.GetPayload()
@@ -188,17 +190,14 @@ func resourceNetboxInventoryItemRead(d *schema.ResourceData, m interface{}) erro
 	d.Set("component_type", item.ComponentType)
 	d.Set("component_id", item.ComponentID)
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
-	if cf != nil {
-		d.Set(customFieldsKey, cf)
-	}
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(customFieldsKey, withoutDefaultCustomFields(api, d, getCustomFields(ctx, api, res.GetPayload().CustomFields)))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(res.GetPayload().Tags)))
 
 	return nil
 }
 
-func resourceNetboxInventoryItemUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxInventoryItemUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -224,32 +223,33 @@ func resourceNetboxInventoryItemUpdate(d *schema.ResourceData, m interface{}) er
 		data.ComponentID = getOptionalInt(d, "component_id")
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "dcim.inventoryitem")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := dcim.NewDcimInventoryItemsPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimInventoryItemsPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimInventoryItemsPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimInventoryItemsPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxInventoryItemRead(d, m)
+	return resourceNetboxInventoryItemRead(ctx, d, m)
 }
 
-func resourceNetboxInventoryItemDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxInventoryItemDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimInventoryItemsDeleteParams().WithID(id)
+	params := dcim.NewDcimInventoryItemsDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimInventoryItemsDelete(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
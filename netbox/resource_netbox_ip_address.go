@@ -1,11 +1,12 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -16,10 +17,10 @@ var resourceNetboxIPAddressRoleOptions = []string{"loopback", "secondary", "anyc
 
 func resourceNetboxIPAddress() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxIPAddressCreate,
-		Read:   resourceNetboxIPAddressRead,
-		Update: resourceNetboxIPAddressUpdate,
-		Delete: resourceNetboxIPAddressDelete,
+		CreateContext: resourceNetboxIPAddressCreate,
+		ReadContext:   resourceNetboxIPAddressRead,
+		UpdateContext: resourceNetboxIPAddressUpdate,
+		DeleteContext: resourceNetboxIPAddressDelete,
 
 		Description: `:meta:subcategory:IP Address Management (IPAM):From the [official documentation](https://docs.netbox.dev/en/stable/features/ipam/#ip-addresses):
 
@@ -111,13 +112,13 @@ func resourceNetboxIPAddress() *schema.Resource {
 			customFieldsKey: customFieldsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("ipam/ip-addresses", false),
 		},
 	}
 }
 
-func resourceNetboxIPAddressCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxIPAddressCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableIPAddress{}
 
@@ -152,30 +153,31 @@ func resourceNetboxIPAddressCreate(d *schema.ResourceData, m interface{}) error
 		data.AssignedObjectID = nil
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	cf, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = cf
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "ipam.ipaddress")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := ipam.NewIpamIPAddressesCreateParams().WithData(&data)
+	params := ipam.NewIpamIPAddressesCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Ipam.IpamIPAddressesCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxIPAddressRead(d, m)
+	return resourceNetboxIPAddressRead(ctx, d, m)
 }
 
-func resourceNetboxIPAddressRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxIPAddressRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamIPAddressesReadParams().WithID(id)
+	params := ipam.NewIpamIPAddressesReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Ipam.IpamIPAddressesRead(params, nil)
 	if err != nil {
@@ -187,7 +189,7 @@ func resourceNetboxIPAddressRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	ipAddress := res.GetPayload()
@@ -260,16 +262,13 @@ func resourceNetboxIPAddressRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("ip_address", ipAddress.Address)
 	d.Set("description", ipAddress.Description)
 	d.Set("status", ipAddress.Status.Value)
-	d.Set(tagsKey, getTagListFromNestedTagList(ipAddress.Tags))
-	cf := getCustomFields(res.GetPayload().CustomFields)
-	if cf != nil {
-		d.Set(customFieldsKey, cf)
-	}
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(ipAddress.Tags)))
+	d.Set(customFieldsKey, withoutDefaultCustomFields(api, d, getCustomFields(ctx, api, res.GetPayload().CustomFields)))
 	return nil
 }
 
-func resourceNetboxIPAddressUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxIPAddressUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableIPAddress{}
@@ -305,27 +304,29 @@ func resourceNetboxIPAddressUpdate(d *schema.ResourceData, m interface{}) error
 		data.AssignedObjectID = nil
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	if cf, ok := d.GetOk(customFieldsKey); ok {
-		data.CustomFields = cf
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "ipam.ipaddress")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := ipam.NewIpamIPAddressesUpdateParams().WithID(id).WithData(&data)
+	params := ipam.NewIpamIPAddressesUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Ipam.IpamIPAddressesUpdate(params, nil)
+	_, err = api.Ipam.IpamIPAddressesUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxIPAddressRead(d, m)
+	return resourceNetboxIPAddressRead(ctx, d, m)
 }
 
-func resourceNetboxIPAddressDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxIPAddressDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamIPAddressesDeleteParams().WithID(id)
+	params := ipam.NewIpamIPAddressesDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Ipam.IpamIPAddressesDelete(params, nil)
 	if err != nil {
@@ -335,7 +336,7 @@ func resourceNetboxIPAddressDelete(d *schema.ResourceData, m interface{}) error
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxIpamRole() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxIpamRoleCreate,
-		Read:   resourceNetboxIpamRoleRead,
-		Update: resourceNetboxIpamRoleUpdate,
-		Delete: resourceNetboxIpamRoleDelete,
+		CreateContext: resourceNetboxIpamRoleCreate,
+		ReadContext:   resourceNetboxIpamRoleRead,
+		UpdateContext: resourceNetboxIpamRoleUpdate,
+		DeleteContext: resourceNetboxIpamRoleDelete,
 
 		Description: `:meta:subcategory:IP Address Management (IPAM):From the [official documentation](https://docs.netbox.dev/en/stable/features/ipam/#prefixvlan-roles):
 
@@ -43,12 +44,12 @@ func resourceNetboxIpamRole() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("ipam/roles", true),
 		},
 	}
 }
-func resourceNetboxIpamRoleCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxIpamRoleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	data := models.Role{}
 
 	name := d.Get("name").(string)
@@ -70,20 +71,20 @@ func resourceNetboxIpamRoleCreate(d *schema.ResourceData, m interface{}) error {
 	data.Description = description
 	data.Tags = []*models.NestedTag{}
 
-	params := ipam.NewIpamRolesCreateParams().WithData(&data)
+	params := ipam.NewIpamRolesCreateParamsWithContext(ctx).WithData(&data)
 	res, err := api.Ipam.IpamRolesCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxIpamRoleUpdate(d, m)
+	return resourceNetboxIpamRoleUpdate(ctx, d, m)
 }
 
-func resourceNetboxIpamRoleRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxIpamRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamRolesReadParams().WithID(id)
+	params := ipam.NewIpamRolesReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Ipam.IpamRolesRead(params, nil)
 	if err != nil {
@@ -95,7 +96,7 @@ func resourceNetboxIpamRoleRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	if res.GetPayload().Name != nil {
@@ -117,8 +118,8 @@ func resourceNetboxIpamRoleRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceNetboxIpamRoleUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxIpamRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.Role{}
 
@@ -141,18 +142,18 @@ func resourceNetboxIpamRoleUpdate(d *schema.ResourceData, m interface{}) error {
 	data.Description = description
 	data.Tags = []*models.NestedTag{}
 
-	params := ipam.NewIpamRolesUpdateParams().WithID(id).WithData(&data)
+	params := ipam.NewIpamRolesUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 	_, err := api.Ipam.IpamRolesUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
-	return resourceNetboxIpamRoleRead(d, m)
+	return resourceNetboxIpamRoleRead(ctx, d, m)
 }
 
-func resourceNetboxIpamRoleDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxIpamRoleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamRolesDeleteParams().WithID(id)
+	params := ipam.NewIpamRolesDeleteParamsWithContext(ctx).WithID(id)
 	_, err := api.Ipam.IpamRolesDelete(params, nil)
 	if err != nil {
 		if errresp, ok := err.(*ipam.IpamRolesDeleteDefault); ok {
@@ -161,7 +162,7 @@ func resourceNetboxIpamRoleDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	d.SetId("")
 	return nil
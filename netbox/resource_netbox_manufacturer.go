@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxManufacturer() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxManufacturerCreate,
-		Read:   resourceNetboxManufacturerRead,
-		Update: resourceNetboxManufacturerUpdate,
-		Delete: resourceNetboxManufacturerDelete,
+		CreateContext: resourceNetboxManufacturerCreate,
+		ReadContext:   resourceNetboxManufacturerRead,
+		UpdateContext: resourceNetboxManufacturerUpdate,
+		DeleteContext: resourceNetboxManufacturerDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/features/device-types/#manufacturers):
 
@@ -34,13 +35,13 @@ func resourceNetboxManufacturer() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("dcim/manufacturers", true),
 		},
 	}
 }
 
-func resourceNetboxManufacturerCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxManufacturerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.Manufacturer{}
 
@@ -57,22 +58,22 @@ func resourceNetboxManufacturerCreate(d *schema.ResourceData, m interface{}) err
 
 	data.Tags = []*models.NestedTag{}
 
-	params := dcim.NewDcimManufacturersCreateParams().WithData(&data)
+	params := dcim.NewDcimManufacturersCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimManufacturersCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxManufacturerRead(d, m)
+	return resourceNetboxManufacturerRead(ctx, d, m)
 }
 
-func resourceNetboxManufacturerRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxManufacturerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimManufacturersReadParams().WithID(id)
+	params := dcim.NewDcimManufacturersReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimManufacturersRead(params, nil)
 
@@ -85,7 +86,7 @@ func resourceNetboxManufacturerRead(d *schema.ResourceData, m interface{}) error
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.Set("name", res.GetPayload().Name)
@@ -94,8 +95,8 @@ func resourceNetboxManufacturerRead(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceNetboxManufacturerUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxManufacturerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.Manufacturer{}
@@ -113,21 +114,21 @@ func resourceNetboxManufacturerUpdate(d *schema.ResourceData, m interface{}) err
 
 	data.Tags = []*models.NestedTag{}
 
-	params := dcim.NewDcimManufacturersPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimManufacturersPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Dcim.DcimManufacturersPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxManufacturerRead(d, m)
+	return resourceNetboxManufacturerRead(ctx, d, m)
 }
 
-func resourceNetboxManufacturerDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxManufacturerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimManufacturersDeleteParams().WithID(id)
+	params := dcim.NewDcimManufacturersDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimManufacturersDelete(params, nil)
 	if err != nil {
@@ -137,7 +138,7 @@ func resourceNetboxManufacturerDelete(d *schema.ResourceData, m interface{}) err
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
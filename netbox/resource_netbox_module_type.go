@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxModuleType() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxModuleTypeCreate,
-		Read:   resourceNetboxModuleTypeRead,
-		Update: resourceNetboxModuleTypeUpdate,
-		Delete: resourceNetboxModuleTypeDelete,
+		CreateContext: resourceNetboxModuleTypeCreate,
+		ReadContext:   resourceNetboxModuleTypeRead,
+		UpdateContext: resourceNetboxModuleTypeUpdate,
+		DeleteContext: resourceNetboxModuleTypeDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/moduletype/):
 
@@ -57,13 +58,13 @@ func resourceNetboxModuleType() *schema.Resource {
 			customFieldsKey: customFieldsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("dcim/module-types", false),
 		},
 	}
 }
 
-func resourceNetboxModuleTypeCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxModuleTypeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableModuleType{
 		Manufacturer: int64ToPtr(int64(d.Get("manufacturer_id").(int))),
@@ -75,29 +76,30 @@ func resourceNetboxModuleTypeCreate(d *schema.ResourceData, m interface{}) error
 		Comments:     getOptionalStr(d, "comments", false),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "dcim.moduletype")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := dcim.NewDcimModuleTypesCreateParams().WithData(&data)
+	params := dcim.NewDcimModuleTypesCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimModuleTypesCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxModuleTypeRead(d, m)
+	return resourceNetboxModuleTypeRead(ctx, d, m)
 }
 
-func resourceNetboxModuleTypeRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxModuleTypeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimModuleTypesReadParams().WithID(id)
+	params := dcim.NewDcimModuleTypesReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimModuleTypesRead(params, nil)
 
@@ -108,7 +110,7 @@ func resourceNetboxModuleTypeRead(d *schema.ResourceData, m interface{}) error {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	moduleType := res.GetPayload()
@@ -132,17 +134,14 @@ func resourceNetboxModuleTypeRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("description", moduleType.Description)
 	d.Set("comments", moduleType.Comments)
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
-	if cf != nil {
-		d.Set(customFieldsKey, cf)
-	}
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(customFieldsKey, withoutDefaultCustomFields(api, d, getCustomFields(ctx, api, res.GetPayload().CustomFields)))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(res.GetPayload().Tags)))
 
 	return nil
 }
 
-func resourceNetboxModuleTypeUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxModuleTypeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -156,32 +155,33 @@ func resourceNetboxModuleTypeUpdate(d *schema.ResourceData, m interface{}) error
 		Comments:     getOptionalStr(d, "comments", true),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "dcim.moduletype")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := dcim.NewDcimModuleTypesPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimModuleTypesPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimModuleTypesPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimModuleTypesPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxModuleTypeRead(d, m)
+	return resourceNetboxModuleTypeRead(ctx, d, m)
 }
 
-func resourceNetboxModuleTypeDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxModuleTypeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimModuleTypesDeleteParams().WithID(id)
+	params := dcim.NewDcimModuleTypesDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimModuleTypesDelete(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
@@ -0,0 +1,164 @@
+package netbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceNetboxObject() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNetboxObjectCreate,
+		ReadContext:   resourceNetboxObjectRead,
+		UpdateContext: resourceNetboxObjectUpdate,
+		DeleteContext: resourceNetboxObjectDelete,
+
+		Description: `:meta:subcategory:Extras:Manages an arbitrary object through the Netbox REST API. This can be used for object types that have no dedicated resource in this provider, including the object types of Netbox plugins.
+
+The object is created with a ` + "`POST`" + ` request to the API path of the object type and updated with ` + "`PATCH`" + ` requests, so only the fields given in ` + "`body`" + ` are managed. Removing a field from ` + "`body`" + ` does not reset it in Netbox.`,
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "The API path of the object type, relative to `/api/`, e.g. `wireless/wireless-lans` or `plugins/dns/zones`.",
+			},
+			"body": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsJSON,
+				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+					equal, _ := jsonSemanticCompare(oldValue, newValue)
+					return equal
+				},
+				Description: "The fields of the object as a JSON object, as accepted by the Netbox API. Related objects are referenced by their ID and choices by their value. When reading the object, Netbox returns both as nested objects, which are reduced to the ID or value again.",
+			},
+			"response": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The full JSON representation of the object as returned by Netbox.",
+			},
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceNetboxObjectImport,
+		},
+	}
+}
+
+func resourceNetboxObjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+
+	path := genericAPIPath(d.Get("path").(string))
+	res, err := genericAPIRequest(ctx, api, http.MethodPost, path, nil, json.RawMessage(d.Get("body").(string)))
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
+	}
+
+	var created struct {
+		ID json.Number `json:"id"`
+	}
+	if err := json.Unmarshal(res, &created); err != nil || created.ID == "" {
+		return diag.Errorf("could not determine the ID of the object created at %s: %s", path, res)
+	}
+
+	d.SetId(created.ID.String())
+
+	return resourceNetboxObjectRead(ctx, d, m)
+}
+
+func resourceNetboxObjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+
+	res, err := genericAPIRequest(ctx, api, http.MethodGet, genericAPIPath(d.Get("path").(string), d.Id()), nil, nil)
+	if err != nil {
+		if errresp, ok := err.(*genericAPIError); ok {
+			if errresp.Code() == 404 {
+				d.SetId("")
+				return nil
+			}
+		}
+		return apiErrorDiagnostics(d, err)
+	}
+
+	actual, err := decodeJSON(res)
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
+	}
+
+	body := d.Get("body").(string)
+	if body == "" {
+		body = "{}"
+	}
+	config, err := decodeJSON([]byte(body))
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
+	}
+
+	projected, err := json.Marshal(projectJSON(config, actual))
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
+	}
+
+	d.Set("body", string(projected))
+	d.Set("response", string(res))
+
+	return nil
+}
+
+func resourceNetboxObjectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+
+	if d.HasChange("body") {
+		_, err := genericAPIRequest(ctx, api, http.MethodPatch, genericAPIPath(d.Get("path").(string), d.Id()), nil, json.RawMessage(d.Get("body").(string)))
+		if err != nil {
+			return apiErrorDiagnostics(d, err)
+		}
+	}
+
+	return resourceNetboxObjectRead(ctx, d, m)
+}
+
+func resourceNetboxObjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
+
+	_, err := genericAPIRequest(ctx, api, http.MethodDelete, genericAPIPath(d.Get("path").(string), d.Id()), nil, nil)
+	if err != nil {
+		if errresp, ok := err.(*genericAPIError); ok {
+			if errresp.Code() == 404 {
+				d.SetId("")
+				return nil
+			}
+		}
+		return apiErrorDiagnostics(d, err)
+	}
+	return nil
+}
+
+// resourceNetboxObjectImport imports an object by its API path followed by
+// its ID, e.g. `wireless/wireless-lans/12`.
+func resourceNetboxObjectImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	importID := strings.Trim(d.Id(), "/")
+	i := strings.LastIndex(importID, "/")
+	if i < 0 {
+		return nil, fmt.Errorf("invalid import ID %q, expected the API path of the object type followed by the ID of the object, e.g. `wireless/wireless-lans/12`", d.Id())
+	}
+	path, id := importID[:i], importID[i+1:]
+	if _, err := strconv.ParseInt(id, 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid import ID %q, %q is not a valid object ID", d.Id(), id)
+	}
+
+	d.Set("path", path)
+	d.Set("body", "{}")
+	d.SetId(id)
+
+	return []*schema.ResourceData{d}, nil
+}
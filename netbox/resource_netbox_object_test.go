@@ -0,0 +1,64 @@
+package netbox
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccNetboxObject_basic(t *testing.T) {
+	testSlug := "object"
+	testName := testAccGetTestName(testSlug)
+	resourceName := "netbox_object.test"
+	resource.ParallelTest(t, resource.TestCase{
+		Providers: testAccProviders,
+		PreCheck:  func() { testAccPreCheck(t) },
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "netbox_object" "test" {
+  path = "wireless/wireless-lan-groups"
+  body = jsonencode({
+    name = "%[1]s"
+    slug = "%[1]s"
+  })
+}`, testName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "path", "wireless/wireless-lan-groups"),
+					resource.TestCheckResourceAttr(resourceName, "body", fmt.Sprintf(`{"name":"%[1]s","slug":"%[1]s"}`, testName)),
+					resource.TestCheckResourceAttrSet(resourceName, "response"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "netbox_object" "test" {
+  path = "wireless/wireless-lan-groups"
+  body = jsonencode({
+    name        = "%[1]s"
+    slug        = "%[1]s"
+    description = "updated"
+  })
+}`, testName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "body", fmt.Sprintf(`{"description":"updated","name":"%[1]s","slug":"%[1]s"}`, testName)),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"body"},
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					resource, ok := s.RootModule().Resources[resourceName]
+					if !ok {
+						return "", fmt.Errorf("Not found: %s", resourceName)
+					}
+
+					return fmt.Sprintf("wireless/wireless-lan-groups/%s", resource.Primary.ID), nil
+				},
+			},
+		},
+	})
+}
@@ -1,22 +1,23 @@
 package netbox
 
 import (
+	"context"
 	"encoding/json"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/users"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxPermission() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxPermissionCreate,
-		Read:   resourceNetboxPermissionRead,
-		Update: resourceNetboxPermissionUpdate,
-		Delete: resourceNetboxPermissionDelete,
+		CreateContext: resourceNetboxPermissionCreate,
+		ReadContext:   resourceNetboxPermissionRead,
+		UpdateContext: resourceNetboxPermissionUpdate,
+		DeleteContext: resourceNetboxPermissionDelete,
 		Description: `:meta:subcategory:Authentication:This resource manages the object-based permissions for Netbox users, built into the application.
 
 > Object-based permissions enable an administrator to grant users or groups the ability to perform an action on arbitrary subsets of objects in NetBox, rather than all objects of a certain type.
@@ -81,12 +82,12 @@ func resourceNetboxPermission() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("users/permissions", false),
 		},
 	}
 }
-func resourceNetboxPermissionCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPermissionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	data := models.WritableObjectPermission{}
 
 	name := d.Get("name").(string)
@@ -106,7 +107,7 @@ func resourceNetboxPermissionCreate(d *schema.ResourceData, m interface{}) error
 	} else {
 		err := json.Unmarshal([]byte(c), &constraints)
 		if err != nil {
-			return err
+			return apiErrorDiagnostics(d, err)
 		}
 		switch v := constraints.(type) {
 		case []interface{}:
@@ -116,20 +117,20 @@ func resourceNetboxPermissionCreate(d *schema.ResourceData, m interface{}) error
 		}
 	}
 
-	params := users.NewUsersPermissionsCreateParams().WithData(&data)
+	params := users.NewUsersPermissionsCreateParamsWithContext(ctx).WithData(&data)
 	res, err := api.Users.UsersPermissionsCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxPermissionRead(d, m)
+	return resourceNetboxPermissionRead(ctx, d, m)
 }
 
-func resourceNetboxPermissionRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPermissionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := users.NewUsersPermissionsReadParams().WithID(id)
+	params := users.NewUsersPermissionsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Users.UsersPermissionsRead(params, nil)
 	if err != nil {
@@ -141,7 +142,7 @@ func resourceNetboxPermissionRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.Set("name", res.GetPayload().Name)
@@ -170,15 +171,15 @@ func resourceNetboxPermissionRead(d *schema.ResourceData, m interface{}) error {
 
 	b, err := json.Marshal(res.GetPayload().Constraints)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	d.Set("constraints", string(b))
 
 	return nil
 }
 
-func resourceNetboxPermissionUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPermissionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableObjectPermission{}
 
@@ -199,7 +200,7 @@ func resourceNetboxPermissionUpdate(d *schema.ResourceData, m interface{}) error
 	} else {
 		err := json.Unmarshal([]byte(c), &constraints)
 		if err != nil {
-			return err
+			return apiErrorDiagnostics(d, err)
 		}
 		switch v := constraints.(type) {
 		case []interface{}:
@@ -208,18 +209,18 @@ func resourceNetboxPermissionUpdate(d *schema.ResourceData, m interface{}) error
 			data.Constraints = v
 		}
 	}
-	params := users.NewUsersPermissionsUpdateParams().WithID(id).WithData(&data)
+	params := users.NewUsersPermissionsUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 	_, err := api.Users.UsersPermissionsUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
-	return resourceNetboxPermissionRead(d, m)
+	return resourceNetboxPermissionRead(ctx, d, m)
 }
 
-func resourceNetboxPermissionDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPermissionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := users.NewUsersPermissionsDeleteParams().WithID(id)
+	params := users.NewUsersPermissionsDeleteParamsWithContext(ctx).WithID(id)
 	_, err := api.Users.UsersPermissionsDelete(params, nil)
 	if err != nil {
 		if errresp, ok := err.(*users.UsersPermissionsDeleteDefault); ok {
@@ -228,7 +229,7 @@ func resourceNetboxPermissionDelete(d *schema.ResourceData, m interface{}) error
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	d.SetId("")
 	return nil
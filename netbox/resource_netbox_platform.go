@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxPlatform() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxPlatformCreate,
-		Read:   resourceNetboxPlatformRead,
-		Update: resourceNetboxPlatformUpdate,
-		Delete: resourceNetboxPlatformDelete,
+		CreateContext: resourceNetboxPlatformCreate,
+		ReadContext:   resourceNetboxPlatformRead,
+		UpdateContext: resourceNetboxPlatformUpdate,
+		DeleteContext: resourceNetboxPlatformDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/features/devices/#platforms):
 
@@ -39,13 +40,13 @@ func resourceNetboxPlatform() *schema.Resource {
 			tagsKey: tagsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("dcim/platforms", true),
 		},
 	}
 }
 
-func resourceNetboxPlatformCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPlatformCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 
@@ -69,25 +70,25 @@ func resourceNetboxPlatformCreate(d *schema.ResourceData, m interface{}) error {
 		data.Manufacturer = int64ToPtr(int64(manufacturerIDValue.(int)))
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	params := dcim.NewDcimPlatformsCreateParams().WithData(&data)
+	params := dcim.NewDcimPlatformsCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimPlatformsCreate(params, nil)
 	if err != nil {
 		//return errors.New(getTextFromError(err))
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxPlatformRead(d, m)
+	return resourceNetboxPlatformRead(ctx, d, m)
 }
 
-func resourceNetboxPlatformRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPlatformRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimPlatformsReadParams().WithID(id)
+	params := dcim.NewDcimPlatformsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimPlatformsRead(params, nil)
 
@@ -100,7 +101,7 @@ func resourceNetboxPlatformRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	result := res.GetPayload()
@@ -110,12 +111,12 @@ func resourceNetboxPlatformRead(d *schema.ResourceData, m interface{}) error {
 	if result.Manufacturer != nil {
 		d.Set("manufacturer_id", result.Manufacturer.ID)
 	}
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(res.GetPayload().Tags)))
 	return nil
 }
 
-func resourceNetboxPlatformUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPlatformUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritablePlatform{}
@@ -140,23 +141,23 @@ func resourceNetboxPlatformUpdate(d *schema.ResourceData, m interface{}) error {
 		data.Manufacturer = int64ToPtr(int64(manufacturerIDValue.(int)))
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	params := dcim.NewDcimPlatformsPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimPlatformsPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Dcim.DcimPlatformsPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxPlatformRead(d, m)
+	return resourceNetboxPlatformRead(ctx, d, m)
 }
 
-func resourceNetboxPlatformDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPlatformDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimPlatformsDeleteParams().WithID(id)
+	params := dcim.NewDcimPlatformsDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimPlatformsDelete(params, nil)
 	if err != nil {
@@ -166,7 +167,7 @@ func resourceNetboxPlatformDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
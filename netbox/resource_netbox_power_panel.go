@@ -1,20 +1,21 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceNetboxPowerPanel() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxPowerPanelCreate,
-		Read:   resourceNetboxPowerPanelRead,
-		Update: resourceNetboxPowerPanelUpdate,
-		Delete: resourceNetboxPowerPanelDelete,
+		CreateContext: resourceNetboxPowerPanelCreate,
+		ReadContext:   resourceNetboxPowerPanelRead,
+		UpdateContext: resourceNetboxPowerPanelUpdate,
+		DeleteContext: resourceNetboxPowerPanelDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/powerpanel/):
 
@@ -45,13 +46,13 @@ func resourceNetboxPowerPanel() *schema.Resource {
 			customFieldsKey: customFieldsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("dcim/power-panels", false),
 		},
 	}
 }
 
-func resourceNetboxPowerPanelCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPowerPanelCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritablePowerPanel{
 		Site:        int64ToPtr(int64(d.Get("site_id").(int))),
@@ -61,29 +62,30 @@ func resourceNetboxPowerPanelCreate(d *schema.ResourceData, m interface{}) error
 		Comments:    getOptionalStr(d, "comments", false),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "dcim.powerpanel")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := dcim.NewDcimPowerPanelsCreateParams().WithData(&data)
+	params := dcim.NewDcimPowerPanelsCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimPowerPanelsCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxPowerPanelRead(d, m)
+	return resourceNetboxPowerPanelRead(ctx, d, m)
 }
 
-func resourceNetboxPowerPanelRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPowerPanelRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimPowerPanelsReadParams().WithID(id)
+	params := dcim.NewDcimPowerPanelsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimPowerPanelsRead(params, nil)
 
@@ -94,7 +96,7 @@ func resourceNetboxPowerPanelRead(d *schema.ResourceData, m interface{}) error {
 			d.SetId("")
 			return nil
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	powerPanel := res.GetPayload()
@@ -116,17 +118,14 @@ func resourceNetboxPowerPanelRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("description", powerPanel.Description)
 	d.Set("comments", powerPanel.Comments)
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
-	if cf != nil {
-		d.Set(customFieldsKey, cf)
-	}
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(customFieldsKey, withoutDefaultCustomFields(api, d, getCustomFields(ctx, api, res.GetPayload().CustomFields)))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(res.GetPayload().Tags)))
 
 	return nil
 }
 
-func resourceNetboxPowerPanelUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPowerPanelUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -138,32 +137,33 @@ func resourceNetboxPowerPanelUpdate(d *schema.ResourceData, m interface{}) error
 		Comments:    getOptionalStr(d, "comments", true),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "dcim.powerpanel")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := dcim.NewDcimPowerPanelsPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimPowerPanelsPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimPowerPanelsPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimPowerPanelsPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxPowerPanelRead(d, m)
+	return resourceNetboxPowerPanelRead(ctx, d, m)
 }
 
-func resourceNetboxPowerPanelDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPowerPanelDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimPowerPanelsDeleteParams().WithID(id)
+	params := dcim.NewDcimPowerPanelsDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimPowerPanelsDelete(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
@@ -1,11 +1,12 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -14,10 +15,10 @@ var resourceNetboxPrefixStatusOptions = []string{"active", "container", "reserve
 
 func resourceNetboxPrefix() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxPrefixCreate,
-		Read:   resourceNetboxPrefixRead,
-		Update: resourceNetboxPrefixUpdate,
-		Delete: resourceNetboxPrefixDelete,
+		CreateContext: resourceNetboxPrefixCreate,
+		ReadContext:   resourceNetboxPrefixRead,
+		UpdateContext: resourceNetboxPrefixUpdate,
+		DeleteContext: resourceNetboxPrefixDelete,
 
 		Description: `:meta:subcategory:IP Address Management (IPAM):From the [official documentation](https://docs.netbox.dev/en/stable/features/ipam/#prefixes):
 
@@ -73,12 +74,12 @@ func resourceNetboxPrefix() *schema.Resource {
 			tagsKey:         tagsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("ipam/prefixes", false),
 		},
 	}
 }
-func resourceNetboxPrefixCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPrefixCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	data := models.WritablePrefix{}
 
 	prefix := d.Get("prefix").(string)
@@ -115,27 +116,28 @@ func resourceNetboxPrefixCreate(d *schema.ResourceData, m interface{}) error {
 		data.Role = int64ToPtr(int64(roleID.(int)))
 	}
 
-	cf, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = cf
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "ipam.prefix")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	params := ipam.NewIpamPrefixesCreateParams().WithData(&data)
+	params := ipam.NewIpamPrefixesCreateParamsWithContext(ctx).WithData(&data)
 	res, err := api.Ipam.IpamPrefixesCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxPrefixRead(d, m)
+	return resourceNetboxPrefixRead(ctx, d, m)
 }
 
-func resourceNetboxPrefixRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPrefixRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamPrefixesReadParams().WithID(id)
+	params := ipam.NewIpamPrefixesReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Ipam.IpamPrefixesRead(params, nil)
 	if err != nil {
@@ -147,7 +149,7 @@ func resourceNetboxPrefixRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.Set("description", res.GetPayload().Description)
@@ -190,19 +192,16 @@ func resourceNetboxPrefixRead(d *schema.ResourceData, m interface{}) error {
 		d.Set("role_id", nil)
 	}
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
-	if cf != nil {
-		d.Set(customFieldsKey, cf)
-	}
+	d.Set(customFieldsKey, withoutDefaultCustomFields(api, d, getCustomFields(ctx, api, res.GetPayload().CustomFields)))
 
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(res.GetPayload().Tags)))
 	// FIGURE OUT NESTED VRF AND NESTED VLAN (from maybe interfaces?)
 
 	return nil
 }
 
-func resourceNetboxPrefixUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPrefixUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritablePrefix{}
 	prefix := d.Get("prefix").(string)
@@ -242,24 +241,26 @@ func resourceNetboxPrefixUpdate(d *schema.ResourceData, m interface{}) error {
 		data.Role = int64ToPtr(int64(roleID.(int)))
 	}
 
-	if cf, ok := d.GetOk(customFieldsKey); ok {
-		data.CustomFields = cf
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "ipam.prefix")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	params := ipam.NewIpamPrefixesUpdateParams().WithID(id).WithData(&data)
-	_, err := api.Ipam.IpamPrefixesUpdate(params, nil)
+	params := ipam.NewIpamPrefixesUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
+	_, err = api.Ipam.IpamPrefixesUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
-	return resourceNetboxPrefixRead(d, m)
+	return resourceNetboxPrefixRead(ctx, d, m)
 }
 
-func resourceNetboxPrefixDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPrefixDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamPrefixesDeleteParams().WithID(id)
+	params := ipam.NewIpamPrefixesDeleteParamsWithContext(ctx).WithID(id)
 	_, err := api.Ipam.IpamPrefixesDelete(params, nil)
 	if err != nil {
 		if errresp, ok := err.(*ipam.IpamPrefixesDeleteDefault); ok {
@@ -268,7 +269,7 @@ func resourceNetboxPrefixDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	d.SetId("")
 	return nil
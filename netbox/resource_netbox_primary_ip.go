@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/virtualization"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxPrimaryIP() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxPrimaryIPCreate,
-		Read:   resourceNetboxPrimaryIPRead,
-		Update: resourceNetboxPrimaryIPUpdate,
-		Delete: resourceNetboxPrimaryIPDelete,
+		CreateContext: resourceNetboxPrimaryIPCreate,
+		ReadContext:   resourceNetboxPrimaryIPRead,
+		UpdateContext: resourceNetboxPrimaryIPUpdate,
+		DeleteContext: resourceNetboxPrimaryIPDelete,
 
 		Description: `:meta:subcategory:Virtualization:This resource is used to define the primary IP for a given virtual machine. The primary IP is reflected in the Virtual machine Netbox UI, which identifies the Primary IPv4 and IPv6 addresses.`,
 
@@ -36,21 +37,21 @@ func resourceNetboxPrimaryIP() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("virtualization/virtual-machines", false),
 		},
 	}
 }
 
-func resourceNetboxPrimaryIPCreate(d *schema.ResourceData, m interface{}) error {
+func resourceNetboxPrimaryIPCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	d.SetId(strconv.Itoa(d.Get("virtual_machine_id").(int)))
 
-	return resourceNetboxPrimaryIPUpdate(d, m)
+	return resourceNetboxPrimaryIPUpdate(ctx, d, m)
 }
 
-func resourceNetboxPrimaryIPRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPrimaryIPRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := virtualization.NewVirtualizationVirtualMachinesReadParams().WithID(id)
+	params := virtualization.NewVirtualizationVirtualMachinesReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Virtualization.VirtualizationVirtualMachinesRead(params, nil)
 	if err != nil {
@@ -62,7 +63,7 @@ func resourceNetboxPrimaryIPRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	IPAddressVersion := d.Get("ip_address_version")
@@ -81,8 +82,8 @@ func resourceNetboxPrimaryIPRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceNetboxPrimaryIPUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxPrimaryIPUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	virtualMachineID := int64(d.Get("virtual_machine_id").(int))
 	IPAddressID := int64(d.Get("ip_address_id").(int))
@@ -91,10 +92,10 @@ func resourceNetboxPrimaryIPUpdate(d *schema.ResourceData, m interface{}) error
 	// because the go-netbox library does not have patch support atm, we have to get the whole object and re-put it
 
 	// first, get the vm
-	readParams := virtualization.NewVirtualizationVirtualMachinesReadParams().WithID(virtualMachineID)
+	readParams := virtualization.NewVirtualizationVirtualMachinesReadParamsWithContext(ctx).WithID(virtualMachineID)
 	res, err := api.Virtualization.VirtualizationVirtualMachinesRead(readParams, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	vm := res.GetPayload()
@@ -167,17 +168,17 @@ func resourceNetboxPrimaryIPUpdate(d *schema.ResourceData, m interface{}) error
 		}
 	}
 
-	updateParams := virtualization.NewVirtualizationVirtualMachinesUpdateParams().WithID(virtualMachineID).WithData(&data)
+	updateParams := virtualization.NewVirtualizationVirtualMachinesUpdateParamsWithContext(ctx).WithID(virtualMachineID).WithData(&data)
 
 	_, err = api.Virtualization.VirtualizationVirtualMachinesUpdate(updateParams, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
-	return resourceNetboxPrimaryIPRead(d, m)
+	return resourceNetboxPrimaryIPRead(ctx, d, m)
 }
 
-func resourceNetboxPrimaryIPDelete(d *schema.ResourceData, m interface{}) error {
+func resourceNetboxPrimaryIPDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	// Set ip_address_id to minus one and go to update. Update will set nil
 	d.Set("ip_address_id", -1)
-	return resourceNetboxPrimaryIPUpdate(d, m)
+	return resourceNetboxPrimaryIPUpdate(ctx, d, m)
 }
@@ -1,11 +1,12 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -18,10 +19,10 @@ var resourceNetboxRackFormFactorOptions = []string{"2-post-frame", "4-post-frame
 
 func resourceNetboxRack() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxRackCreate,
-		Read:   resourceNetboxRackRead,
-		Update: resourceNetboxRackUpdate,
-		Delete: resourceNetboxRackDelete,
+		CreateContext: resourceNetboxRackCreate,
+		ReadContext:   resourceNetboxRackRead,
+		UpdateContext: resourceNetboxRackUpdate,
+		DeleteContext: resourceNetboxRackDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/rack/):
 
@@ -148,13 +149,13 @@ Each rack is assigned a name and (optionally) a separate facility ID. This is he
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("dcim/racks", false),
 		},
 	}
 }
 
-func resourceNetboxRackCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 	siteID := int64(d.Get("site_id").(int))
@@ -196,29 +197,30 @@ func resourceNetboxRackCreate(d *schema.ResourceData, m interface{}) error {
 	data.Comments = getOptionalStr(d, "comments", false)
 	data.FormFactor = getOptionalStr(d, "form_factor", false)
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "dcim.rack")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := dcim.NewDcimRacksCreateParams().WithData(&data)
+	params := dcim.NewDcimRacksCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimRacksCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxRackRead(d, m)
+	return resourceNetboxRackRead(ctx, d, m)
 }
 
-func resourceNetboxRackRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimRacksReadParams().WithID(id)
+	params := dcim.NewDcimRacksReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimRacksRead(params, nil)
 
@@ -231,7 +233,7 @@ func resourceNetboxRackRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	rack := res.GetPayload()
@@ -310,17 +312,14 @@ func resourceNetboxRackRead(d *schema.ResourceData, m interface{}) error {
 		d.Set("form_factor", nil)
 	}
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
-	if cf != nil {
-		d.Set(customFieldsKey, cf)
-	}
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(customFieldsKey, withoutDefaultCustomFields(api, d, getCustomFields(ctx, api, res.GetPayload().CustomFields)))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(res.GetPayload().Tags)))
 
 	return nil
 }
 
-func resourceNetboxRackUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -366,28 +365,29 @@ func resourceNetboxRackUpdate(d *schema.ResourceData, m interface{}) error {
 	data.Comments = getOptionalStr(d, "comments", true)
 	data.FormFactor = getOptionalStr(d, "form_factor", false)
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	cf, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = cf
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "dcim.rack")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := dcim.NewDcimRacksPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimRacksPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimRacksPartialUpdate(params, nil)
+	_, err = api.Dcim.DcimRacksPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxRackRead(d, m)
+	return resourceNetboxRackRead(ctx, d, m)
 }
 
-func resourceNetboxRackDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimRacksDeleteParams().WithID(id)
+	params := dcim.NewDcimRacksDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimRacksDelete(params, nil)
 	if err != nil {
@@ -397,7 +397,7 @@ func resourceNetboxRackDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
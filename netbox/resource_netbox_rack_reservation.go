@@ -1,20 +1,21 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceNetboxRackReservation() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxRackReservationCreate,
-		Read:   resourceNetboxRackReservationRead,
-		Update: resourceNetboxRackReservationUpdate,
-		Delete: resourceNetboxRackReservationDelete,
+		CreateContext: resourceNetboxRackReservationCreate,
+		ReadContext:   resourceNetboxRackReservationRead,
+		UpdateContext: resourceNetboxRackReservationUpdate,
+		DeleteContext: resourceNetboxRackReservationDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/rackreservation/):
 
@@ -52,17 +53,17 @@ func resourceNetboxRackReservation() *schema.Resource {
 			tagsKey: tagsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("dcim/rack-reservations", false),
 		},
 	}
 }
 
-func resourceNetboxRackReservationCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackReservationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, _ := getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	params := dcim.NewDcimRackReservationsCreateParams().WithData(
+	params := dcim.NewDcimRackReservationsCreateParamsWithContext(ctx).WithData(
 		&models.WritableRackReservation{
 			Rack:        getOptionalInt(d, "rack_id"),
 			Units:       toInt64PtrList(d.Get("units")),
@@ -76,18 +77,18 @@ func resourceNetboxRackReservationCreate(d *schema.ResourceData, m interface{})
 
 	res, err := api.Dcim.DcimRackReservationsCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxRackReservationRead(d, m)
+	return resourceNetboxRackReservationRead(ctx, d, m)
 }
 
-func resourceNetboxRackReservationRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackReservationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimRackReservationsReadParams().WithID(id)
+	params := dcim.NewDcimRackReservationsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimRackReservationsRead(params, nil)
 	if err != nil {
@@ -99,7 +100,7 @@ func resourceNetboxRackReservationRead(d *schema.ResourceData, m interface{}) er
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	rackRes := res.GetPayload()
@@ -126,16 +127,16 @@ func resourceNetboxRackReservationRead(d *schema.ResourceData, m interface{}) er
 
 	d.Set("comments", rackRes.Comments)
 
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(res.GetPayload().Tags)))
 	return nil
 }
 
-func resourceNetboxRackReservationUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackReservationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, _ := getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
 	data := models.WritableRackReservation{
 		Rack:        getOptionalInt(d, "rack_id"),
@@ -147,21 +148,21 @@ func resourceNetboxRackReservationUpdate(d *schema.ResourceData, m interface{})
 		Tags:        tags,
 	}
 
-	params := dcim.NewDcimRackReservationsPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimRackReservationsPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Dcim.DcimRackReservationsPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxRackReservationRead(d, m)
+	return resourceNetboxRackReservationRead(ctx, d, m)
 }
 
-func resourceNetboxRackReservationDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackReservationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimRackReservationsDeleteParams().WithID(id)
+	params := dcim.NewDcimRackReservationsDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimRackReservationsDelete(params, nil)
 	if err != nil {
@@ -171,7 +172,7 @@ func resourceNetboxRackReservationDelete(d *schema.ResourceData, m interface{})
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
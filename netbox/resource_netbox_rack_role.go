@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxRackRole() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxRackRoleCreate,
-		Read:   resourceNetboxRackRoleRead,
-		Update: resourceNetboxRackRoleUpdate,
-		Delete: resourceNetboxRackRoleDelete,
+		CreateContext: resourceNetboxRackRoleCreate,
+		ReadContext:   resourceNetboxRackRoleRead,
+		UpdateContext: resourceNetboxRackRoleUpdate,
+		DeleteContext: resourceNetboxRackRoleDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/models/dcim/rackrole/):
 
@@ -43,13 +44,13 @@ func resourceNetboxRackRole() *schema.Resource {
 			tagsKey: tagsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("dcim/rack-roles", true),
 		},
 	}
 }
 
-func resourceNetboxRackRoleCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackRoleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 	slugValue, slugOk := d.GetOk("slug")
@@ -65,9 +66,9 @@ func resourceNetboxRackRoleCreate(d *schema.ResourceData, m interface{}) error {
 	color := d.Get("color_hex").(string)
 	description := getOptionalStr(d, "description", false)
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, _ := getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	params := dcim.NewDcimRackRolesCreateParams().WithData(
+	params := dcim.NewDcimRackRolesCreateParamsWithContext(ctx).WithData(
 		&models.RackRole{
 			Name:        &name,
 			Slug:        &slug,
@@ -79,18 +80,18 @@ func resourceNetboxRackRoleCreate(d *schema.ResourceData, m interface{}) error {
 
 	res, err := api.Dcim.DcimRackRolesCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxRackRoleRead(d, m)
+	return resourceNetboxRackRoleRead(ctx, d, m)
 }
 
-func resourceNetboxRackRoleRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackRoleRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimRackRolesReadParams().WithID(id)
+	params := dcim.NewDcimRackRolesReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimRackRolesRead(params, nil)
 	if err != nil {
@@ -102,7 +103,7 @@ func resourceNetboxRackRoleRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	rackRole := res.GetPayload()
@@ -111,12 +112,12 @@ func resourceNetboxRackRoleRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("slug", rackRole.Slug)
 	d.Set("description", rackRole.Description)
 	d.Set("color_hex", rackRole.Color)
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(res.GetPayload().Tags)))
 	return nil
 }
 
-func resourceNetboxRackRoleUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackRoleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.RackRole{}
@@ -139,24 +140,24 @@ func resourceNetboxRackRoleUpdate(d *schema.ResourceData, m interface{}) error {
 	data.Description = getOptionalStr(d, "description", true)
 	data.Color = color
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, _ := getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 	data.Tags = tags
 
-	params := dcim.NewDcimRackRolesPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimRackRolesPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Dcim.DcimRackRolesPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxRackRoleRead(d, m)
+	return resourceNetboxRackRoleRead(ctx, d, m)
 }
 
-func resourceNetboxRackRoleDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackRoleDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimRackRolesDeleteParams().WithID(id)
+	params := dcim.NewDcimRackRolesDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimRackRolesDelete(params, nil)
 	if err != nil {
@@ -166,7 +167,7 @@ func resourceNetboxRackRoleDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
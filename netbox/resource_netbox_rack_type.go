@@ -1,11 +1,12 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -17,10 +18,10 @@ var resourceNetboxRackTypeWidthOptions = []int{10, 19, 21, 23}
 
 func resourceNetboxRackType() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxRackTypeCreate,
-		Read:   resourceNetboxRackTypeRead,
-		Update: resourceNetboxRackTypeUpdate,
-		Delete: resourceNetboxRackTypeDelete,
+		CreateContext: resourceNetboxRackTypeCreate,
+		ReadContext:   resourceNetboxRackTypeRead,
+		UpdateContext: resourceNetboxRackTypeUpdate,
+		DeleteContext: resourceNetboxRackTypeDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://netboxlabs.com/docs/netbox/en/stable/models/dcim/racktype/):
 
@@ -182,13 +183,13 @@ func resourceNetboxRackType() *schema.Resource {
 			//			customFieldsKey: customFieldsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("dcim/rack-types", true),
 		},
 	}
 }
 
-func resourceNetboxRackTypeCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackTypeCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	model := d.Get("model").(string)
 	formFactor := d.Get("form_factor").(string)
@@ -223,24 +224,24 @@ func resourceNetboxRackTypeCreate(d *schema.ResourceData, m interface{}) error {
 		MountingDepth: getOptionalInt(d, "mounting_depth_mm"),
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	params := dcim.NewDcimRackTypesCreateParams().WithData(&data)
+	params := dcim.NewDcimRackTypesCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimRackTypesCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxRackTypeRead(d, m)
+	return resourceNetboxRackTypeRead(ctx, d, m)
 }
 
-func resourceNetboxRackTypeRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackTypeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimRackTypesReadParams().WithID(id)
+	params := dcim.NewDcimRackTypesReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimRackTypesRead(params, nil)
 
@@ -253,7 +254,7 @@ func resourceNetboxRackTypeRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	rackType := res.GetPayload()
@@ -270,7 +271,7 @@ func resourceNetboxRackTypeRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	d.Set("u_height", rackType.UHeight)
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(res.GetPayload().Tags)))
 	d.Set("description", rackType.Description)
 	d.Set("comments", rackType.Comments)
 
@@ -297,8 +298,8 @@ func resourceNetboxRackTypeRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceNetboxRackTypeUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackTypeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
@@ -333,21 +334,21 @@ func resourceNetboxRackTypeUpdate(d *schema.ResourceData, m interface{}) error {
 		MountingDepth: getOptionalInt(d, "mounting_depth_mm"),
 	}
 
-	params := dcim.NewDcimRackTypesUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimRackTypesUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Dcim.DcimRackTypesUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxRackTypeRead(d, m)
+	return resourceNetboxRackTypeRead(ctx, d, m)
 }
 
-func resourceNetboxRackTypeDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRackTypeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimRackTypesDeleteParams().WithID(id)
+	params := dcim.NewDcimRackTypesDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimRackTypesDelete(params, nil)
 	if err != nil {
@@ -357,7 +358,7 @@ func resourceNetboxRackTypeDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxRegion() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxRegionCreate,
-		Read:   resourceNetboxRegionRead,
-		Update: resourceNetboxRegionUpdate,
-		Delete: resourceNetboxRegionDelete,
+		CreateContext: resourceNetboxRegionCreate,
+		ReadContext:   resourceNetboxRegionRead,
+		UpdateContext: resourceNetboxRegionUpdate,
+		DeleteContext: resourceNetboxRegionDelete,
 
 		Description: `:meta:subcategory:Data Center Inventory Management (DCIM):From the [official documentation](https://docs.netbox.dev/en/stable/features/sites-and-racks/#regions):
 
@@ -50,13 +51,13 @@ func resourceNetboxRegion() *schema.Resource {
 			tagsKey: tagsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("dcim/regions", true),
 		},
 	}
 }
 
-func resourceNetboxRegionCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRegionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableRegion{}
 
@@ -80,23 +81,23 @@ func resourceNetboxRegionCreate(d *schema.ResourceData, m interface{}) error {
 		data.Parent = int64ToPtr(int64(parentRegionIDValue.(int)))
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	params := dcim.NewDcimRegionsCreateParams().WithData(&data)
+	params := dcim.NewDcimRegionsCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimRegionsCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxRegionRead(d, m)
+	return resourceNetboxRegionRead(ctx, d, m)
 }
 
-func resourceNetboxRegionRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRegionRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimRegionsReadParams().WithID(id)
+	params := dcim.NewDcimRegionsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimRegionsRead(params, nil)
 
@@ -109,7 +110,7 @@ func resourceNetboxRegionRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.Set("name", res.GetPayload().Name)
@@ -120,12 +121,12 @@ func resourceNetboxRegionRead(d *schema.ResourceData, m interface{}) error {
 		d.Set("parent_region_id", nil)
 	}
 	d.Set("description", res.GetPayload().Description)
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(res.GetPayload().Tags)))
 	return nil
 }
 
-func resourceNetboxRegionUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRegionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableRegion{}
@@ -150,23 +151,23 @@ func resourceNetboxRegionUpdate(d *schema.ResourceData, m interface{}) error {
 		data.Parent = int64ToPtr(int64(parentRegionIDValue.(int)))
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	params := dcim.NewDcimRegionsPartialUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimRegionsPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Dcim.DcimRegionsPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxRegionRead(d, m)
+	return resourceNetboxRegionRead(ctx, d, m)
 }
 
-func resourceNetboxRegionDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRegionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimRegionsDeleteParams().WithID(id)
+	params := dcim.NewDcimRegionsDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimRegionsDelete(params, nil)
 	if err != nil {
@@ -176,7 +177,7 @@ func resourceNetboxRegionDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxRir() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxRirCreate,
-		Read:   resourceNetboxRirRead,
-		Update: resourceNetboxRirUpdate,
-		Delete: resourceNetboxRirDelete,
+		CreateContext: resourceNetboxRirCreate,
+		ReadContext:   resourceNetboxRirRead,
+		UpdateContext: resourceNetboxRirUpdate,
+		DeleteContext: resourceNetboxRirDelete,
 
 		Description: `:meta:subcategory:IP Address Management (IPAM):From the [official documentation](https://docs.netbox.dev/en/stable/features/ipam/#regional-internet-registries-rirs):
 
@@ -43,13 +44,13 @@ func resourceNetboxRir() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("ipam/rirs", true),
 		},
 	}
 }
 
-func resourceNetboxRirCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRirCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	data := models.RIR{}
 
 	name := d.Get("name").(string)
@@ -68,20 +69,20 @@ func resourceNetboxRirCreate(d *schema.ResourceData, m interface{}) error {
 	data.Tags = []*models.NestedTag{}
 	data.IsPrivate = d.Get("is_private").(bool)
 
-	params := ipam.NewIpamRirsCreateParams().WithData(&data)
+	params := ipam.NewIpamRirsCreateParamsWithContext(ctx).WithData(&data)
 	res, err := api.Ipam.IpamRirsCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxRirUpdate(d, m)
+	return resourceNetboxRirUpdate(ctx, d, m)
 }
 
-func resourceNetboxRirRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRirRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamRirsReadParams().WithID(id)
+	params := ipam.NewIpamRirsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Ipam.IpamRirsRead(params, nil)
 	if err != nil {
@@ -93,7 +94,7 @@ func resourceNetboxRirRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	rir := res.GetPayload()
@@ -106,8 +107,8 @@ func resourceNetboxRirRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceNetboxRirUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRirUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.RIR{}
 
@@ -127,18 +128,18 @@ func resourceNetboxRirUpdate(d *schema.ResourceData, m interface{}) error {
 	data.Tags = []*models.NestedTag{}
 	data.IsPrivate = d.Get("is_private").(bool)
 
-	params := ipam.NewIpamRirsUpdateParams().WithID(id).WithData(&data)
+	params := ipam.NewIpamRirsUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 	_, err := api.Ipam.IpamRirsUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
-	return resourceNetboxRirRead(d, m)
+	return resourceNetboxRirRead(ctx, d, m)
 }
 
-func resourceNetboxRirDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRirDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamRirsDeleteParams().WithID(id)
+	params := ipam.NewIpamRirsDeleteParamsWithContext(ctx).WithID(id)
 	_, err := api.Ipam.IpamRirsDelete(params, nil)
 	if err != nil {
 		if errresp, ok := err.(*ipam.IpamRirsDeleteDefault); ok {
@@ -147,7 +148,7 @@ func resourceNetboxRirDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	d.SetId("")
 	return nil
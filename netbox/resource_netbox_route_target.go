@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxRouteTarget() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxRouteTargetCreate,
-		Read:   resourceNetboxRouteTargetRead,
-		Update: resourceNetboxRouteTargetUpdate,
-		Delete: resourceNetboxRouteTargetDelete,
+		CreateContext: resourceNetboxRouteTargetCreate,
+		ReadContext:   resourceNetboxRouteTargetRead,
+		UpdateContext: resourceNetboxRouteTargetUpdate,
+		DeleteContext: resourceNetboxRouteTargetDelete,
 
 		Description: `:meta:subcategory:IP Address Management (IPAM):From the [official documentation](https://docs.netbox.dev/en/stable/models/ipam/routetarget/):
 
@@ -39,12 +40,12 @@ func resourceNetboxRouteTarget() *schema.Resource {
 			tagsKey: tagsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("ipam/route-targets", false),
 		},
 	}
 }
-func resourceNetboxRouteTargetCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRouteTargetCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	data := models.WritableRouteTarget{}
 
 	name := d.Get("name").(string)
@@ -52,20 +53,20 @@ func resourceNetboxRouteTargetCreate(d *schema.ResourceData, m interface{}) erro
 	data.Name = &name
 	data.Tags = []*models.NestedTag{}
 
-	params := ipam.NewIpamRouteTargetsCreateParams().WithData(&data)
+	params := ipam.NewIpamRouteTargetsCreateParamsWithContext(ctx).WithData(&data)
 	res, err := api.Ipam.IpamRouteTargetsCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxRouteTargetUpdate(d, m)
+	return resourceNetboxRouteTargetUpdate(ctx, d, m)
 }
 
-func resourceNetboxRouteTargetRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRouteTargetRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamRouteTargetsReadParams().WithID(id)
+	params := ipam.NewIpamRouteTargetsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Ipam.IpamRouteTargetsRead(params, nil)
 	if err != nil {
@@ -77,7 +78,7 @@ func resourceNetboxRouteTargetRead(d *schema.ResourceData, m interface{}) error
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	if res.GetPayload().Name != nil {
@@ -99,8 +100,8 @@ func resourceNetboxRouteTargetRead(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceNetboxRouteTargetUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRouteTargetUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableRouteTarget{}
 
@@ -113,18 +114,18 @@ func resourceNetboxRouteTargetUpdate(d *schema.ResourceData, m interface{}) erro
 	data.Tenant = &tenantID
 	data.Tags = []*models.NestedTag{}
 
-	params := ipam.NewIpamRouteTargetsUpdateParams().WithID(id).WithData(&data)
+	params := ipam.NewIpamRouteTargetsUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 	_, err := api.Ipam.IpamRouteTargetsUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
-	return resourceNetboxRouteTargetRead(d, m)
+	return resourceNetboxRouteTargetRead(ctx, d, m)
 }
 
-func resourceNetboxRouteTargetDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxRouteTargetDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamRouteTargetsDeleteParams().WithID(id)
+	params := ipam.NewIpamRouteTargetsDeleteParamsWithContext(ctx).WithID(id)
 	_, err := api.Ipam.IpamRouteTargetsDelete(params, nil)
 	if err != nil {
 		if errresp, ok := err.(*ipam.IpamRouteTargetsDeleteDefault); ok {
@@ -133,7 +134,7 @@ func resourceNetboxRouteTargetDelete(d *schema.ResourceData, m interface{}) erro
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	d.SetId("")
 	return nil
@@ -1,11 +1,12 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -14,10 +15,10 @@ var resourceNetboxServiceProtocolOptions = []string{"tcp", "udp", "sctp"}
 
 func resourceNetboxService() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxServiceCreate,
-		Read:   resourceNetboxServiceRead,
-		Update: resourceNetboxServiceUpdate,
-		Delete: resourceNetboxServiceDelete,
+		CreateContext: resourceNetboxServiceCreate,
+		ReadContext:   resourceNetboxServiceRead,
+		UpdateContext: resourceNetboxServiceUpdate,
+		DeleteContext: resourceNetboxServiceDelete,
 
 		Description: `:meta:subcategory:IP Address Management (IPAM):From the [official documentation](https://docs.netbox.dev/en/stable/features/services/#services):
 
@@ -82,12 +83,12 @@ func resourceNetboxService() *schema.Resource {
 			customFieldsKey: customFieldsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("ipam/services", false),
 		},
 	}
 }
-func resourceNetboxServiceCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxServiceCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	data := models.WritableService{}
 
 	dataName := d.Get("name").(string)
@@ -124,7 +125,7 @@ func resourceNetboxServiceCreate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	v := d.Get("tags")
-	tags, _ := getNestedTagListFromResourceDataSet(api, v)
+	tags, _ := getNestedTagListFromResourceDataSet(ctx, api, v)
 	data.Tags = tags
 
 	if v, ok := d.GetOk("description"); ok {
@@ -143,25 +144,26 @@ func resourceNetboxServiceCreate(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 	
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "ipam.service")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := ipam.NewIpamServicesCreateParams().WithData(&data)
+	params := ipam.NewIpamServicesCreateParamsWithContext(ctx).WithData(&data)
 	res, err := api.Ipam.IpamServicesCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxServiceUpdate(d, m)
+	return resourceNetboxServiceUpdate(ctx, d, m)
 }
 
-func resourceNetboxServiceRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxServiceRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamServicesReadParams().WithID(id)
+	params := ipam.NewIpamServicesReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Ipam.IpamServicesRead(params, nil)
 	if err != nil {
@@ -173,7 +175,7 @@ func resourceNetboxServiceRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.Set("name", res.GetPayload().Name)
@@ -195,24 +197,16 @@ func resourceNetboxServiceRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if tags := res.GetPayload().Tags; tags != nil {
-		var tagList []interface{}
-		for _, tag := range tags {
-			tagName := tag.Name
-			tagList = append(tagList, *tagName)
-		}
-		d.Set("tags", tagList)
+		d.Set("tags", withoutDefaultTags(api, d, getTagListFromNestedTagList(tags)))
 	}
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
-	if cf != nil {
-		d.Set(customFieldsKey, cf)
-	}
+	d.Set(customFieldsKey, withoutDefaultCustomFields(api, d, getCustomFields(ctx, api, res.GetPayload().CustomFields)))
 
 	return nil
 }
 
-func resourceNetboxServiceUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxServiceUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableService{}
 
@@ -249,7 +243,7 @@ func resourceNetboxServiceUpdate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	v := d.Get("tags")
-	tags, _ := getNestedTagListFromResourceDataSet(api, v)
+	tags, _ := getNestedTagListFromResourceDataSet(ctx, api, v)
 	data.Tags = tags
 
 	if v, ok := d.GetOk("description"); ok {
@@ -266,23 +260,24 @@ func resourceNetboxServiceUpdate(d *schema.ResourceData, m interface{}) error {
 		data.VirtualMachine = &dataVirtualMachineID
 	}
 
-	cf, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = cf
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "ipam.service")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	params := ipam.NewIpamServicesUpdateParams().WithID(id).WithData(&data)
-	_, err := api.Ipam.IpamServicesUpdate(params, nil)
+	params := ipam.NewIpamServicesUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
+	_, err = api.Ipam.IpamServicesUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
-	return resourceNetboxServiceRead(d, m)
+	return resourceNetboxServiceRead(ctx, d, m)
 }
 
-func resourceNetboxServiceDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxServiceDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamServicesDeleteParams().WithID(id)
+	params := ipam.NewIpamServicesDeleteParamsWithContext(ctx).WithID(id)
 	_, err := api.Ipam.IpamServicesDelete(params, nil)
 	if err != nil {
 		if errresp, ok := err.(*ipam.IpamServicesDeleteDefault); ok {
@@ -291,7 +286,7 @@ func resourceNetboxServiceDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
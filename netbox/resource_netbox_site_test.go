@@ -6,7 +6,6 @@ import (
 	"strings"
 	"testing"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
@@ -62,6 +61,12 @@ resource "netbox_site" "test" {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName:      "netbox_site.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateId:     randomSlug,
+			},
 		},
 	})
 }
@@ -153,7 +158,7 @@ func init() {
 			if err != nil {
 				return fmt.Errorf("Error getting client: %s", err)
 			}
-			api := m.(*client.NetBoxAPI)
+			api := m.(*providerState)
 			params := dcim.NewDcimSitesListParams()
 			res, err := api.Dcim.DcimSitesList(params, nil)
 			if err != nil {
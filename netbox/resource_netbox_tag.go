@@ -1,22 +1,23 @@
 package netbox
 
 import (
+	"context"
 	"regexp"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/extras"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxTag() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxTagCreate,
-		Read:   resourceNetboxTagRead,
-		Update: resourceNetboxTagUpdate,
-		Delete: resourceNetboxTagDelete,
+		CreateContext: resourceNetboxTagCreate,
+		ReadContext:   resourceNetboxTagRead,
+		UpdateContext: resourceNetboxTagUpdate,
+		DeleteContext: resourceNetboxTagDelete,
 
 		Description: `:meta:subcategory:Extras:From the [official documentation](https://docs.netbox.dev/en/stable/models/extras/tag/):
 > Tags are user-defined labels which can be applied to a variety of objects within NetBox. They can be used to establish dimensions of organization beyond the relationships built into NetBox. For example, you might create a tag to identify a particular ownership or condition across several types of objects.
@@ -47,13 +48,13 @@ func resourceNetboxTag() *schema.Resource {
 			tagsKey: tagsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("extras/tags", true),
 		},
 	}
 }
 
-func resourceNetboxTagCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTagCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 
@@ -68,7 +69,7 @@ func resourceNetboxTagCreate(d *schema.ResourceData, m interface{}) error {
 
 	color := d.Get("color_hex").(string)
 	description := d.Get("description").(string)
-	params := extras.NewExtrasTagsCreateParams().WithData(
+	params := extras.NewExtrasTagsCreateParamsWithContext(ctx).WithData(
 		&models.Tag{
 			Name:        &name,
 			Slug:        &slug,
@@ -80,18 +81,18 @@ func resourceNetboxTagCreate(d *schema.ResourceData, m interface{}) error {
 	res, err := api.Extras.ExtrasTagsCreate(params, nil)
 	if err != nil {
 		//return errors.New(getTextFromError(err))
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxTagRead(d, m)
+	return resourceNetboxTagRead(ctx, d, m)
 }
 
-func resourceNetboxTagRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTagRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := extras.NewExtrasTagsReadParams().WithID(id)
+	params := extras.NewExtrasTagsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Extras.ExtrasTagsRead(params, nil)
 	if err != nil {
@@ -103,7 +104,7 @@ func resourceNetboxTagRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.Set("name", res.GetPayload().Name)
@@ -113,8 +114,8 @@ func resourceNetboxTagRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceNetboxTagUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTagUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.Tag{}
@@ -137,23 +138,27 @@ func resourceNetboxTagUpdate(d *schema.ResourceData, m interface{}) error {
 	data.Color = color
 	data.Description = description
 
-	params := extras.NewExtrasTagsUpdateParams().WithID(id).WithData(&data)
+	params := extras.NewExtrasTagsUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Extras.ExtrasTagsUpdate(params, nil)
+	// Cached lookups may still refer to the old name of the tag
+	api.tags.invalidate()
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxTagRead(d, m)
+	return resourceNetboxTagRead(ctx, d, m)
 }
 
-func resourceNetboxTagDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTagDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := extras.NewExtrasTagsDeleteParams().WithID(id)
+	params := extras.NewExtrasTagsDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Extras.ExtrasTagsDelete(params, nil)
+	// Cached lookups may still refer to the deleted tag
+	api.tags.invalidate()
 	if err != nil {
 		if errresp, ok := err.(*extras.ExtrasTagsDeleteDefault); ok {
 			if errresp.Code() == 404 {
@@ -161,7 +166,7 @@ func resourceNetboxTagDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
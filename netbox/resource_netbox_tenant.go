@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/tenancy"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxTenant() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxTenantCreate,
-		Read:   resourceNetboxTenantRead,
-		Update: resourceNetboxTenantUpdate,
-		Delete: resourceNetboxTenantDelete,
+		CreateContext: resourceNetboxTenantCreate,
+		ReadContext:   resourceNetboxTenantRead,
+		UpdateContext: resourceNetboxTenantUpdate,
+		DeleteContext: resourceNetboxTenantDelete,
 
 		Description: `:meta:subcategory:Tenancy:From the [official documentation](https://docs.netbox.dev/en/stable/features/tenancy/#tenants):
 
@@ -45,13 +46,13 @@ func resourceNetboxTenant() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("tenancy/tenants", true),
 		},
 	}
 }
 
-func resourceNetboxTenantCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTenantCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 	groupID := int64(d.Get("group_id").(int))
@@ -66,7 +67,7 @@ func resourceNetboxTenantCreate(d *schema.ResourceData, m interface{}) error {
 		slug = slugValue.(string)
 	}
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, _ := getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
 	data := &models.WritableTenant{}
 
@@ -79,22 +80,22 @@ func resourceNetboxTenantCreate(d *schema.ResourceData, m interface{}) error {
 		data.Group = &groupID
 	}
 
-	params := tenancy.NewTenancyTenantsCreateParams().WithData(data)
+	params := tenancy.NewTenancyTenantsCreateParamsWithContext(ctx).WithData(data)
 
 	res, err := api.Tenancy.TenancyTenantsCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxTenantRead(d, m)
+	return resourceNetboxTenantRead(ctx, d, m)
 }
 
-func resourceNetboxTenantRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTenantRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := tenancy.NewTenancyTenantsReadParams().WithID(id)
+	params := tenancy.NewTenancyTenantsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Tenancy.TenancyTenantsRead(params, nil)
 	if err != nil {
@@ -106,7 +107,7 @@ func resourceNetboxTenantRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.Set("name", res.GetPayload().Name)
@@ -119,8 +120,8 @@ func resourceNetboxTenantRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceNetboxTenantUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTenantUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableTenant{}
@@ -137,7 +138,7 @@ func resourceNetboxTenantUpdate(d *schema.ResourceData, m interface{}) error {
 		slug = slugValue.(string)
 	}
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, _ := getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
 	data.Slug = &slug
 	data.Name = &name
@@ -147,21 +148,21 @@ func resourceNetboxTenantUpdate(d *schema.ResourceData, m interface{}) error {
 		data.Group = &groupID
 	}
 
-	params := tenancy.NewTenancyTenantsPartialUpdateParams().WithID(id).WithData(&data)
+	params := tenancy.NewTenancyTenantsPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Tenancy.TenancyTenantsPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxTenantRead(d, m)
+	return resourceNetboxTenantRead(ctx, d, m)
 }
 
-func resourceNetboxTenantDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTenantDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := tenancy.NewTenancyTenantsDeleteParams().WithID(id)
+	params := tenancy.NewTenancyTenantsDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Tenancy.TenancyTenantsDelete(params, nil)
 	if err != nil {
@@ -171,7 +172,7 @@ func resourceNetboxTenantDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
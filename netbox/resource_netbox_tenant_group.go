@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/tenancy"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxTenantGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxTenantGroupCreate,
-		Read:   resourceNetboxTenantGroupRead,
-		Update: resourceNetboxTenantGroupUpdate,
-		Delete: resourceNetboxTenantGroupDelete,
+		CreateContext: resourceNetboxTenantGroupCreate,
+		ReadContext:   resourceNetboxTenantGroupRead,
+		UpdateContext: resourceNetboxTenantGroupUpdate,
+		DeleteContext: resourceNetboxTenantGroupDelete,
 
 		Description: `:meta:subcategory:Tenancy:From the [official documentation](https://docs.netbox.dev/en/stable/features/tenancy/#tenant-groups):
 
@@ -44,13 +45,13 @@ func resourceNetboxTenantGroup() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("tenancy/tenant-groups", true),
 		},
 	}
 }
 
-func resourceNetboxTenantGroupCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTenantGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 	parentID := int64(d.Get("parent_id").(int))
@@ -75,23 +76,23 @@ func resourceNetboxTenantGroupCreate(d *schema.ResourceData, m interface{}) erro
 		data.Parent = &parentID
 	}
 
-	params := tenancy.NewTenancyTenantGroupsCreateParams().WithData(data)
+	params := tenancy.NewTenancyTenantGroupsCreateParamsWithContext(ctx).WithData(data)
 
 	res, err := api.Tenancy.TenancyTenantGroupsCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxTenantGroupRead(d, m)
+	return resourceNetboxTenantGroupRead(ctx, d, m)
 }
 
-func resourceNetboxTenantGroupRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTenantGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
-	params := tenancy.NewTenancyTenantGroupsReadParams().WithID(id)
+	params := tenancy.NewTenancyTenantGroupsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Tenancy.TenancyTenantGroupsRead(params, nil)
 	if err != nil {
@@ -103,7 +104,7 @@ func resourceNetboxTenantGroupRead(d *schema.ResourceData, m interface{}) error
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.Set("name", res.GetPayload().Name)
@@ -115,8 +116,8 @@ func resourceNetboxTenantGroupRead(d *schema.ResourceData, m interface{}) error
 	return nil
 }
 
-func resourceNetboxTenantGroupUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTenantGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableTenantGroup{}
@@ -142,21 +143,21 @@ func resourceNetboxTenantGroupUpdate(d *schema.ResourceData, m interface{}) erro
 	if parentID != 0 {
 		data.Parent = &parentID
 	}
-	params := tenancy.NewTenancyTenantGroupsPartialUpdateParams().WithID(id).WithData(&data)
+	params := tenancy.NewTenancyTenantGroupsPartialUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Tenancy.TenancyTenantGroupsPartialUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxTenantGroupRead(d, m)
+	return resourceNetboxTenantGroupRead(ctx, d, m)
 }
 
-func resourceNetboxTenantGroupDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxTenantGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := tenancy.NewTenancyTenantGroupsDeleteParams().WithID(id)
+	params := tenancy.NewTenancyTenantGroupsDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Tenancy.TenancyTenantGroupsDelete(params, nil)
 	if err != nil {
@@ -166,7 +167,7 @@ func resourceNetboxTenantGroupDelete(d *schema.ResourceData, m interface{}) erro
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/dcim"
 	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -42,13 +41,13 @@ func resourceNetboxVirtualChassis() *schema.Resource {
 			customFieldsKey: customFieldsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("dcim/virtual-chassis", false),
 		},
 	}
 }
 
 func resourceNetboxVirtualChassisCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 
@@ -74,18 +73,19 @@ func resourceNetboxVirtualChassisCreate(ctx context.Context, d *schema.ResourceD
 		data.Comments = comments
 	}
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "dcim.virtualchassis")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	params := dcim.NewDcimVirtualChassisCreateParams().WithData(&data)
+	params := dcim.NewDcimVirtualChassisCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Dcim.DcimVirtualChassisCreate(params, nil)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
@@ -94,11 +94,11 @@ func resourceNetboxVirtualChassisCreate(ctx context.Context, d *schema.ResourceD
 }
 
 func resourceNetboxVirtualChassisRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
-	params := dcim.NewDcimVirtualChassisReadParams().WithID(id)
+	params := dcim.NewDcimVirtualChassisReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Dcim.DcimVirtualChassisRead(params, nil)
 	if err != nil {
@@ -109,7 +109,7 @@ func resourceNetboxVirtualChassisRead(ctx context.Context, d *schema.ResourceDat
 				return nil
 			}
 		}
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 
 	virtualChassis := res.GetPayload()
@@ -119,17 +119,14 @@ func resourceNetboxVirtualChassisRead(ctx context.Context, d *schema.ResourceDat
 	d.Set("description", virtualChassis.Description)
 	d.Set("comments", virtualChassis.Comments)
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
-	if cf != nil {
-		d.Set(customFieldsKey, cf)
-	}
+	d.Set(customFieldsKey, withoutDefaultCustomFields(api, d, getCustomFields(ctx, api, res.GetPayload().CustomFields)))
 
-	d.Set(tagsKey, getTagListFromNestedTagList(virtualChassis.Tags))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(virtualChassis.Tags)))
 	return nil
 }
 
 func resourceNetboxVirtualChassisUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableVirtualChassis{}
@@ -143,12 +140,13 @@ func resourceNetboxVirtualChassisUpdate(ctx context.Context, d *schema.ResourceD
 		data.Domain = domain
 	}
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "dcim.virtualchassis")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
 	if d.HasChanges("comments") {
 		// check if comment is set
@@ -167,21 +165,21 @@ func resourceNetboxVirtualChassisUpdate(ctx context.Context, d *schema.ResourceD
 		}
 	}
 
-	params := dcim.NewDcimVirtualChassisUpdateParams().WithID(id).WithData(&data)
+	params := dcim.NewDcimVirtualChassisUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Dcim.DcimVirtualChassisUpdate(params, nil)
+	_, err = api.Dcim.DcimVirtualChassisUpdate(params, nil)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 
 	return resourceNetboxVirtualChassisRead(ctx, d, m)
 }
 
 func resourceNetboxVirtualChassisDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := dcim.NewDcimVirtualChassisDeleteParams().WithID(id)
+	params := dcim.NewDcimVirtualChassisDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Dcim.DcimVirtualChassisDelete(params, nil)
 	if err != nil {
@@ -191,17 +189,17 @@ func resourceNetboxVirtualChassisDelete(ctx context.Context, d *schema.ResourceD
 				return nil
 			}
 		}
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 
 	return nil
 }
 
-func virtualChassisUpdateMaster(api *client.NetBoxAPI, id int64, master *int64) error {
+func virtualChassisUpdateMaster(ctx context.Context, api *providerState, id int64, master *int64) error {
 	// Need to read the virtual chassis because we cannot do a partial update
 	// because setting `master` to nil would omit it entirely, so we need to
 	// do a PUT request instead of PATCH
-	vcRes, err := api.Dcim.DcimVirtualChassisRead(dcim.NewDcimVirtualChassisReadParams().WithID(id), nil)
+	vcRes, err := api.Dcim.DcimVirtualChassisRead(dcim.NewDcimVirtualChassisReadParamsWithContext(ctx).WithID(id), nil)
 	if err != nil {
 		return err
 	}
@@ -227,6 +225,6 @@ func virtualChassisUpdateMaster(api *client.NetBoxAPI, id int64, master *int64)
 		Master:      master,
 	}
 
-	_, err = api.Dcim.DcimVirtualChassisUpdate(dcim.NewDcimVirtualChassisUpdateParams().WithID(id).WithData(&vcUpdateData), nil)
+	_, err = api.Dcim.DcimVirtualChassisUpdate(dcim.NewDcimVirtualChassisUpdateParamsWithContext(ctx).WithID(id).WithData(&vcUpdateData), nil)
 	return err
 }
@@ -4,7 +4,6 @@ import (
 	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/virtualization"
 	"github.com/fbreckle/go-netbox/netbox/models"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -42,7 +41,7 @@ func resourceNetboxVirtualDisks() *schema.Resource {
 			customFieldsKey: customFieldsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("virtualization/virtual-disks", false),
 		},
 		SchemaVersion: 1,
 		StateUpgraders: []schema.StateUpgrader{
@@ -56,7 +55,7 @@ func resourceNetboxVirtualDisks() *schema.Resource {
 }
 
 func resourceNetboxVirtualDisksCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	name := d.Get("name").(string)
 	size := d.Get("size_mb").(int)
@@ -74,18 +73,19 @@ func resourceNetboxVirtualDisksCreate(ctx context.Context, d *schema.ResourceDat
 		data.Description = description
 	}
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "virtualization.virtualdisk")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	params := virtualization.NewVirtualizationVirtualDisksCreateParams().WithData(&data)
+	params := virtualization.NewVirtualizationVirtualDisksCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Virtualization.VirtualizationVirtualDisksCreate(params, nil)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
@@ -94,11 +94,11 @@ func resourceNetboxVirtualDisksCreate(ctx context.Context, d *schema.ResourceDat
 }
 
 func resourceNetboxVirtualDisksRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 
-	params := virtualization.NewVirtualizationVirtualDisksReadParams().WithID(id)
+	params := virtualization.NewVirtualizationVirtualDisksReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Virtualization.VirtualizationVirtualDisksRead(params, nil)
 	if err != nil {
@@ -109,7 +109,7 @@ func resourceNetboxVirtualDisksRead(ctx context.Context, d *schema.ResourceData,
 				return nil
 			}
 		}
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 
 	VirtualDisks := res.GetPayload()
@@ -124,17 +124,14 @@ func resourceNetboxVirtualDisksRead(ctx context.Context, d *schema.ResourceData,
 		d.Set("virtual_machine_id", VirtualDisks.VirtualMachine.ID)
 	}
 
-	cf := getCustomFields(res.GetPayload().CustomFields)
-	if cf != nil {
-		d.Set(customFieldsKey, cf)
-	}
+	d.Set(customFieldsKey, withoutDefaultCustomFields(api, d, getCustomFields(ctx, api, res.GetPayload().CustomFields)))
 
-	d.Set(tagsKey, getTagListFromNestedTagList(VirtualDisks.Tags))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(VirtualDisks.Tags)))
 	return nil
 }
 
 func resourceNetboxVirtualDisksUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableVirtualDisk{}
@@ -147,12 +144,13 @@ func resourceNetboxVirtualDisksUpdate(ctx context.Context, d *schema.ResourceDat
 	data.Size = &size
 	data.VirtualMachine = &virtualMachineID
 
-	ct, ok := d.GetOk(customFieldsKey)
-	if ok {
-		data.CustomFields = ct
+	cf, err := getCustomFieldsFromResourceData(ctx, api, d, "virtualization.virtualdisk")
+	if err != nil {
+		return apiErrorDiagnostics(d, err)
 	}
+	data.CustomFields = cf
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
 	if d.HasChanges("description") {
 		// check if description is set
@@ -163,21 +161,21 @@ func resourceNetboxVirtualDisksUpdate(ctx context.Context, d *schema.ResourceDat
 		}
 	}
 
-	params := virtualization.NewVirtualizationVirtualDisksUpdateParams().WithID(id).WithData(&data)
+	params := virtualization.NewVirtualizationVirtualDisksUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
-	_, err := api.Virtualization.VirtualizationVirtualDisksUpdate(params, nil)
+	_, err = api.Virtualization.VirtualizationVirtualDisksUpdate(params, nil)
 	if err != nil {
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 
 	return resourceNetboxVirtualDisksRead(ctx, d, m)
 }
 
 func resourceNetboxVirtualDisksDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	api := m.(*client.NetBoxAPI)
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := virtualization.NewVirtualizationVirtualDisksDeleteParams().WithID(id)
+	params := virtualization.NewVirtualizationVirtualDisksDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Virtualization.VirtualizationVirtualDisksDelete(params, nil)
 	if err != nil {
@@ -187,7 +185,7 @@ func resourceNetboxVirtualDisksDelete(ctx context.Context, d *schema.ResourceDat
 				return nil
 			}
 		}
-		return diag.FromErr(err)
+		return apiErrorDiagnostics(d, err)
 	}
 
 	return nil
@@ -1,11 +1,12 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/ipam"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -14,10 +15,10 @@ var resourceNetboxVlanGroupScopeTypeOptions = []string{"dcim.location", "dcim.si
 
 func resourceNetboxVlanGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxVlanGroupCreate,
-		Read:   resourceNetboxVlanGroupRead,
-		Update: resourceNetboxVlanGroupUpdate,
-		Delete: resourceNetboxVlanGroupDelete,
+		CreateContext: resourceNetboxVlanGroupCreate,
+		ReadContext:   resourceNetboxVlanGroupRead,
+		UpdateContext: resourceNetboxVlanGroupUpdate,
+		DeleteContext: resourceNetboxVlanGroupDelete,
 
 		Description: `:meta:subcategory:IP Address Management (IPAM):
 
@@ -62,13 +63,13 @@ func resourceNetboxVlanGroup() *schema.Resource {
 			tagsKey: tagsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("ipam/vlan-groups", true),
 		},
 	}
 }
 
-func resourceNetboxVlanGroupCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVlanGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	data := models.VLANGroup{}
 
 	name := d.Get("name").(string)
@@ -98,22 +99,22 @@ func resourceNetboxVlanGroupCreate(d *schema.ResourceData, m interface{}) error
 		data.ScopeID = int64ToPtr(int64(scopeID.(int)))
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	params := ipam.NewIpamVlanGroupsCreateParams().WithData(&data)
+	params := ipam.NewIpamVlanGroupsCreateParamsWithContext(ctx).WithData(&data)
 	res, err := api.Ipam.IpamVlanGroupsCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxVlanGroupRead(d, m)
+	return resourceNetboxVlanGroupRead(ctx, d, m)
 }
 
-func resourceNetboxVlanGroupRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVlanGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamVlanGroupsReadParams().WithID(id)
+	params := ipam.NewIpamVlanGroupsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Ipam.IpamVlanGroupsRead(params, nil)
 	if err != nil {
@@ -125,7 +126,7 @@ func resourceNetboxVlanGroupRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	vlanGroup := res.GetPayload()
@@ -134,7 +135,7 @@ func resourceNetboxVlanGroupRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("slug", vlanGroup.Slug)
 	d.Set("description", vlanGroup.Description)
 	d.Set("vid_ranges", vlanGroup.VidRanges)
-	d.Set(tagsKey, getTagListFromNestedTagList(vlanGroup.Tags))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(vlanGroup.Tags)))
 
 	if vlanGroup.ScopeType != nil {
 		d.Set("scope_type", vlanGroup.ScopeType)
@@ -147,8 +148,8 @@ func resourceNetboxVlanGroupRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceNetboxVlanGroupUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVlanGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.VLANGroup{}
 
@@ -180,23 +181,23 @@ func resourceNetboxVlanGroupUpdate(d *schema.ResourceData, m interface{}) error
 		data.ScopeID = int64ToPtr(int64(scopeID.(int)))
 	}
 
-	data.Tags, _ = getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	data.Tags, _ = getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 
-	params := ipam.NewIpamVlanGroupsUpdateParams().WithID(id).WithData(&data)
+	params := ipam.NewIpamVlanGroupsUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 	_, err := api.Ipam.IpamVlanGroupsUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
-	return resourceNetboxVlanGroupRead(d, m)
+	return resourceNetboxVlanGroupRead(ctx, d, m)
 }
 
-func resourceNetboxVlanGroupDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVlanGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := ipam.NewIpamVlanGroupsDeleteParams().WithID(id)
+	params := ipam.NewIpamVlanGroupsDeleteParamsWithContext(ctx).WithID(id)
 	_, err := api.Ipam.IpamVlanGroupsDelete(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	return nil
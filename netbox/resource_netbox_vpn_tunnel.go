@@ -1,11 +1,12 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/vpn"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -14,10 +15,10 @@ var resourceNetboxVpnTunnelStatusOptions = []string{"planned", "active", "disabl
 
 func resourceNetboxVpnTunnel() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxVpnTunnelCreate,
-		Read:   resourceNetboxVpnTunnelRead,
-		Update: resourceNetboxVpnTunnelUpdate,
-		Delete: resourceNetboxVpnTunnelDelete,
+		CreateContext: resourceNetboxVpnTunnelCreate,
+		ReadContext:   resourceNetboxVpnTunnelRead,
+		UpdateContext: resourceNetboxVpnTunnelUpdate,
+		DeleteContext: resourceNetboxVpnTunnelDelete,
 
 		Description: `:meta:subcategory:VPN Tunnels:From the [official documentation](https://docs.netbox.dev/en/stable/features/vpn-tunnels/):
 
@@ -57,13 +58,13 @@ func resourceNetboxVpnTunnel() *schema.Resource {
 			tagsKey: tagsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("vpn/tunnels", false),
 		},
 	}
 }
 
-func resourceNetboxVpnTunnelCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVpnTunnelCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableTunnel{}
 
@@ -76,26 +77,26 @@ func resourceNetboxVpnTunnelCreate(d *schema.ResourceData, m interface{}) error
 	data.Tenant = getOptionalInt(d, "tenant_id")
 	data.TunnelID = getOptionalInt(d, "tunnel_id")
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, _ := getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 	data.Tags = tags
 
-	params := vpn.NewVpnTunnelsCreateParams().WithData(&data)
+	params := vpn.NewVpnTunnelsCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Vpn.VpnTunnelsCreate(params, nil)
 	if err != nil {
 		//return errors.New(getTextFromError(err))
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxVpnTunnelRead(d, m)
+	return resourceNetboxVpnTunnelRead(ctx, d, m)
 }
 
-func resourceNetboxVpnTunnelRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVpnTunnelRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := vpn.NewVpnTunnelsReadParams().WithID(id)
+	params := vpn.NewVpnTunnelsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Vpn.VpnTunnelsRead(params, nil)
 	if err != nil {
@@ -107,7 +108,7 @@ func resourceNetboxVpnTunnelRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	tunnel := res.GetPayload()
@@ -131,12 +132,12 @@ func resourceNetboxVpnTunnelRead(d *schema.ResourceData, m interface{}) error {
 
 	d.Set("description", tunnel.Description)
 
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(res.GetPayload().Tags)))
 	return nil
 }
 
-func resourceNetboxVpnTunnelUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVpnTunnelUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableTunnel{}
@@ -150,24 +151,24 @@ func resourceNetboxVpnTunnelUpdate(d *schema.ResourceData, m interface{}) error
 	data.Tenant = getOptionalInt(d, "tenant_id")
 	data.TunnelID = getOptionalInt(d, "tunnel_id")
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, _ := getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 	data.Tags = tags
 
-	params := vpn.NewVpnTunnelsUpdateParams().WithID(id).WithData(&data)
+	params := vpn.NewVpnTunnelsUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Vpn.VpnTunnelsUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxVpnTunnelRead(d, m)
+	return resourceNetboxVpnTunnelRead(ctx, d, m)
 }
 
-func resourceNetboxVpnTunnelDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVpnTunnelDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := vpn.NewVpnTunnelsDeleteParams().WithID(id)
+	params := vpn.NewVpnTunnelsDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Vpn.VpnTunnelsDelete(params, nil)
 	if err != nil {
@@ -177,7 +178,7 @@ func resourceNetboxVpnTunnelDelete(d *schema.ResourceData, m interface{}) error
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
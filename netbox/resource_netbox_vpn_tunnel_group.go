@@ -1,21 +1,22 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/vpn"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceNetboxVpnTunnelGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxVpnTunnelGroupCreate,
-		Read:   resourceNetboxVpnTunnelGroupRead,
-		Update: resourceNetboxVpnTunnelGroupUpdate,
-		Delete: resourceNetboxVpnTunnelGroupDelete,
+		CreateContext: resourceNetboxVpnTunnelGroupCreate,
+		ReadContext:   resourceNetboxVpnTunnelGroupRead,
+		UpdateContext: resourceNetboxVpnTunnelGroupUpdate,
+		DeleteContext: resourceNetboxVpnTunnelGroupDelete,
 
 		Description: `:meta:subcategory:VPN Tunnels:From the [official documentation](https://docs.netbox.dev/en/stable/features/vpn-tunnels/):
 
@@ -38,13 +39,13 @@ func resourceNetboxVpnTunnelGroup() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("vpn/tunnel-groups", true),
 		},
 	}
 }
 
-func resourceNetboxVpnTunnelGroupCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVpnTunnelGroupCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.TunnelGroup{}
 
@@ -67,22 +68,22 @@ func resourceNetboxVpnTunnelGroupCreate(d *schema.ResourceData, m interface{}) e
 
 	data.Tags = []*models.NestedTag{}
 
-	params := vpn.NewVpnTunnelGroupsCreateParams().WithData(&data)
+	params := vpn.NewVpnTunnelGroupsCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Vpn.VpnTunnelGroupsCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxVpnTunnelGroupRead(d, m)
+	return resourceNetboxVpnTunnelGroupRead(ctx, d, m)
 }
 
-func resourceNetboxVpnTunnelGroupRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVpnTunnelGroupRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := vpn.NewVpnTunnelGroupsReadParams().WithID(id)
+	params := vpn.NewVpnTunnelGroupsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Vpn.VpnTunnelGroupsRead(params, nil)
 	if err != nil {
@@ -94,7 +95,7 @@ func resourceNetboxVpnTunnelGroupRead(d *schema.ResourceData, m interface{}) err
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.Set("name", res.GetPayload().Name)
@@ -103,8 +104,8 @@ func resourceNetboxVpnTunnelGroupRead(d *schema.ResourceData, m interface{}) err
 	return nil
 }
 
-func resourceNetboxVpnTunnelGroupUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVpnTunnelGroupUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.TunnelGroup{}
@@ -133,21 +134,21 @@ func resourceNetboxVpnTunnelGroupUpdate(d *schema.ResourceData, m interface{}) e
 
 	data.Tags = []*models.NestedTag{}
 
-	params := vpn.NewVpnTunnelGroupsUpdateParams().WithID(id).WithData(&data)
+	params := vpn.NewVpnTunnelGroupsUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Vpn.VpnTunnelGroupsUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxVpnTunnelGroupRead(d, m)
+	return resourceNetboxVpnTunnelGroupRead(ctx, d, m)
 }
 
-func resourceNetboxVpnTunnelGroupDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVpnTunnelGroupDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := vpn.NewVpnTunnelGroupsDeleteParams().WithID(id)
+	params := vpn.NewVpnTunnelGroupsDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Vpn.VpnTunnelGroupsDelete(params, nil)
 	if err != nil {
@@ -157,7 +158,7 @@ func resourceNetboxVpnTunnelGroupDelete(d *schema.ResourceData, m interface{}) e
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
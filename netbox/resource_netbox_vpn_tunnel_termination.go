@@ -1,11 +1,12 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/vpn"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -13,10 +14,10 @@ var resourceNetboxVpnTunnelTerminationRoleOptions = []string{"peer", "hub", "spo
 
 func resourceNetboxVpnTunnelTermination() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxVpnTunnelTerminationCreate,
-		Read:   resourceNetboxVpnTunnelTerminationRead,
-		Update: resourceNetboxVpnTunnelTerminationUpdate,
-		Delete: resourceNetboxVpnTunnelTerminationDelete,
+		CreateContext: resourceNetboxVpnTunnelTerminationCreate,
+		ReadContext:   resourceNetboxVpnTunnelTerminationRead,
+		UpdateContext: resourceNetboxVpnTunnelTerminationUpdate,
+		DeleteContext: resourceNetboxVpnTunnelTerminationDelete,
 
 		Description: `:meta:subcategory:VPN Tunnels:From the [official documentation](https://docs.netbox.dev/en/stable/features/vpn-tunnels/):
 
@@ -49,13 +50,13 @@ func resourceNetboxVpnTunnelTermination() *schema.Resource {
 			tagsKey: tagsSchema,
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("vpn/tunnel-terminations", false),
 		},
 	}
 }
 
-func resourceNetboxVpnTunnelTerminationCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVpnTunnelTerminationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := models.WritableTunnelTermination{}
 
@@ -78,26 +79,26 @@ func resourceNetboxVpnTunnelTerminationCreate(d *schema.ResourceData, m interfac
 
 	data.OutsideIP = getOptionalInt(d, "outside_ip_address_id")
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, _ := getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 	data.Tags = tags
 
-	params := vpn.NewVpnTunnelTerminationsCreateParams().WithData(&data)
+	params := vpn.NewVpnTunnelTerminationsCreateParamsWithContext(ctx).WithData(&data)
 
 	res, err := api.Vpn.VpnTunnelTerminationsCreate(params, nil)
 	if err != nil {
 		//return errors.New(getTextFromError(err))
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxVpnTunnelTerminationRead(d, m)
+	return resourceNetboxVpnTunnelTerminationRead(ctx, d, m)
 }
 
-func resourceNetboxVpnTunnelTerminationRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVpnTunnelTerminationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := vpn.NewVpnTunnelTerminationsReadParams().WithID(id)
+	params := vpn.NewVpnTunnelTerminationsReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Vpn.VpnTunnelTerminationsRead(params, nil)
 	if err != nil {
@@ -109,7 +110,7 @@ func resourceNetboxVpnTunnelTerminationRead(d *schema.ResourceData, m interface{
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	tunnelTermination := res.GetPayload()
@@ -130,12 +131,12 @@ func resourceNetboxVpnTunnelTerminationRead(d *schema.ResourceData, m interface{
 		d.Set("outside_ip_address_id", tunnelTermination.OutsideIP.ID)
 	}
 
-	d.Set(tagsKey, getTagListFromNestedTagList(res.GetPayload().Tags))
+	d.Set(tagsKey, withoutDefaultTags(api, d, getTagListFromNestedTagList(res.GetPayload().Tags)))
 	return nil
 }
 
-func resourceNetboxVpnTunnelTerminationUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVpnTunnelTerminationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.WritableTunnelTermination{}
@@ -158,24 +159,24 @@ func resourceNetboxVpnTunnelTerminationUpdate(d *schema.ResourceData, m interfac
 
 	data.OutsideIP = getOptionalInt(d, "outside_ip_address_id")
 
-	tags, _ := getNestedTagListFromResourceDataSet(api, d.Get(tagsKey))
+	tags, _ := getNestedTagListFromResourceDataSet(ctx, api, d.Get(tagsKey))
 	data.Tags = tags
 
-	params := vpn.NewVpnTunnelTerminationsUpdateParams().WithID(id).WithData(&data)
+	params := vpn.NewVpnTunnelTerminationsUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Vpn.VpnTunnelTerminationsUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxVpnTunnelTerminationRead(d, m)
+	return resourceNetboxVpnTunnelTerminationRead(ctx, d, m)
 }
 
-func resourceNetboxVpnTunnelTerminationDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxVpnTunnelTerminationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := vpn.NewVpnTunnelTerminationsDeleteParams().WithID(id)
+	params := vpn.NewVpnTunnelTerminationsDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Vpn.VpnTunnelTerminationsDelete(params, nil)
 	if err != nil {
@@ -185,7 +186,7 @@ func resourceNetboxVpnTunnelTerminationDelete(d *schema.ResourceData, m interfac
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
@@ -1,11 +1,12 @@
 package netbox
 
 import (
+	"context"
 	"strconv"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/extras"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
@@ -14,10 +15,10 @@ var resourceNetboxWebhookHTTPMethodOptions = []string{"GET", "POST", "PUT", "PAT
 
 func resourceNetboxWebhook() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceNetboxWebhookCreate,
-		Read:   resourceNetboxWebhookRead,
-		Update: resourceNetboxWebhookUpdate,
-		Delete: resourceNetboxWebhookDelete,
+		CreateContext: resourceNetboxWebhookCreate,
+		ReadContext:   resourceNetboxWebhookRead,
+		UpdateContext: resourceNetboxWebhookUpdate,
+		DeleteContext: resourceNetboxWebhookDelete,
 
 		Description: `:meta:subcategory:Extras:From the [official documentation](https://docs.netbox.dev/en/stable/integrations/webhooks/):
 
@@ -60,13 +61,13 @@ func resourceNetboxWebhook() *schema.Resource {
 			},
 		},
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: importByNaturalKey("extras/webhooks", false),
 		},
 	}
 }
 
-func resourceNetboxWebhookCreate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxWebhookCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	data := &models.Webhook{}
 	name := d.Get("name").(string)
@@ -79,22 +80,22 @@ func resourceNetboxWebhookCreate(d *schema.ResourceData, m interface{}) error {
 	data.HTTPContentType = getOptionalStr(d, "http_content_type", false)
 	data.AdditionalHeaders = getOptionalStr(d, "additional_headers", false)
 
-	params := extras.NewExtrasWebhooksCreateParams().WithData(data)
+	params := extras.NewExtrasWebhooksCreateParamsWithContext(ctx).WithData(data)
 
 	res, err := api.Extras.ExtrasWebhooksCreate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	d.SetId(strconv.FormatInt(res.GetPayload().ID, 10))
 
-	return resourceNetboxWebhookRead(d, m)
+	return resourceNetboxWebhookRead(ctx, d, m)
 }
 
-func resourceNetboxWebhookRead(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxWebhookRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := extras.NewExtrasWebhooksReadParams().WithID(id)
+	params := extras.NewExtrasWebhooksReadParamsWithContext(ctx).WithID(id)
 
 	res, err := api.Extras.ExtrasWebhooksRead(params, nil)
 	if err != nil {
@@ -105,7 +106,7 @@ func resourceNetboxWebhookRead(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
 	webhook := res.GetPayload()
@@ -119,8 +120,8 @@ func resourceNetboxWebhookRead(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceNetboxWebhookUpdate(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxWebhookUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
 	data := models.Webhook{}
@@ -136,21 +137,21 @@ func resourceNetboxWebhookUpdate(d *schema.ResourceData, m interface{}) error {
 	data.HTTPContentType = getOptionalStr(d, "http_content_type", false)
 	data.AdditionalHeaders = getOptionalStr(d, "additional_headers", false)
 
-	params := extras.NewExtrasWebhooksUpdateParams().WithID(id).WithData(&data)
+	params := extras.NewExtrasWebhooksUpdateParamsWithContext(ctx).WithID(id).WithData(&data)
 
 	_, err := api.Extras.ExtrasWebhooksUpdate(params, nil)
 	if err != nil {
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 
-	return resourceNetboxWebhookRead(d, m)
+	return resourceNetboxWebhookRead(ctx, d, m)
 }
 
-func resourceNetboxWebhookDelete(d *schema.ResourceData, m interface{}) error {
-	api := m.(*client.NetBoxAPI)
+func resourceNetboxWebhookDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	api := m.(*providerState)
 
 	id, _ := strconv.ParseInt(d.Id(), 10, 64)
-	params := extras.NewExtrasWebhooksDeleteParams().WithID(id)
+	params := extras.NewExtrasWebhooksDeleteParamsWithContext(ctx).WithID(id)
 
 	_, err := api.Extras.ExtrasWebhooksDelete(params, nil)
 	if err != nil {
@@ -160,7 +161,7 @@ func resourceNetboxWebhookDelete(d *schema.ResourceData, m interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return apiErrorDiagnostics(d, err)
 	}
 	return nil
 }
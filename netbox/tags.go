@@ -1,13 +1,18 @@
 package netbox
 
 import (
+	"context"
 	"fmt"
+	"strings"
+	"sync"
 
-	"github.com/fbreckle/go-netbox/netbox/client"
 	"github.com/fbreckle/go-netbox/netbox/client/extras"
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"golang.org/x/exp/slices"
 )
 
 const tagsKey = "tags"
@@ -30,45 +35,131 @@ var tagsSchemaRead = &schema.Schema{
 	Set:      schema.HashString,
 }
 
-func getNestedTagListFromResourceDataSet(client *client.NetBoxAPI, d interface{}) ([]*models.NestedTag, diag.Diagnostics) {
+// tagCache remembers the tags looked up by name for the lifetime of the
+// provider, so that resources referencing the same tags do not have to look
+// them up over and over again. Names that could not be found are not cached,
+// because the tag might be created later in the same run.
+type tagCache struct {
+	mu   sync.Mutex
+	tags map[string][]*models.Tag
+}
+
+func newTagCache() *tagCache {
+	return &tagCache{
+		tags: make(map[string][]*models.Tag),
+	}
+}
+
+// lookup returns all tags matching each of the given names. Names missing
+// from the cache are looked up in a single batched request.
+func (c *tagCache) lookup(ctx context.Context, api *providerState, names []string) (map[string][]*models.Tag, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var missing []string
+	for _, name := range names {
+		if _, ok := c.tags[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		// The generated params only allow filtering for a single name, so the
+		// names are passed as a repeated query parameter instead
+		tags, _, err := listAll(extras.NewExtrasTagsListParamsWithContext(ctx), 0, func(p *extras.ExtrasTagsListParams) (*listPage[*models.Tag], error) {
+			res, err := api.Extras.ExtrasTagsList(p, nil, withQueryParam("name", missing...))
+			if err != nil {
+				return nil, err
+			}
+			payload := res.GetPayload()
+			return &listPage[*models.Tag]{Count: payload.Count, Next: payload.Next, Results: payload.Results}, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			c.tags[*tag.Name] = append(c.tags[*tag.Name], tag)
+		}
+	}
+
+	result := make(map[string][]*models.Tag, len(names))
+	for _, name := range names {
+		result[name] = c.tags[name]
+	}
+	return result, nil
+}
+
+// invalidate drops all cached tags. It must be called whenever tags are
+// changed in Netbox.
+func (c *tagCache) invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.tags = make(map[string][]*models.Tag)
+}
+
+// withQueryParam adds a query parameter with the given values to a request,
+// on top of the parameters set by the generated params.
+func withQueryParam(name string, values ...string) extras.ClientOption {
+	return func(op *runtime.ClientOperation) {
+		params := op.Params
+		op.Params = runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+			if err := params.WriteToRequest(r, reg); err != nil {
+				return err
+			}
+			return r.SetQueryParam(name, values...)
+		})
+	}
+}
+
+func getNestedTagListFromResourceDataSet(ctx context.Context, api *providerState, d interface{}) ([]*models.NestedTag, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
 	tagList := d.(*schema.Set).List()
 	tags := []*models.NestedTag{}
+
+	names := make([]string, 0, len(tagList)+len(api.defaultTags))
 	for _, tag := range tagList {
-		tagString := tag.(string)
-		params := extras.NewExtrasTagsListParams()
-		params.Name = &tagString
-		limit := int64(2) // We search for a unique tag. Having two hits suffices to know its not unique.
-		params.Limit = &limit
-		res, err := client.Extras.ExtrasTagsList(params, nil)
-		if err != nil {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  fmt.Sprintf("Error retrieving tag %s from netbox", tag.(string)),
-				Detail:   fmt.Sprintf("API Error trying to retrieve tag %s from netbox", tag.(string)),
-			})
-			return tags, diags
+		names = append(names, tag.(string))
+	}
+	for _, tag := range api.defaultTags {
+		if !slices.Contains(names, tag) {
+			names = append(names, tag)
 		}
-		payload := res.GetPayload()
-		switch *payload.Count {
-		case int64(0):
+	}
+	if len(names) == 0 {
+		return tags, diags
+	}
+
+	found, err := api.tags.lookup(ctx, api, names)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error retrieving tag %s from netbox", strings.Join(names, ", ")),
+			Detail:   fmt.Sprintf("API Error trying to retrieve tag %s from netbox", strings.Join(names, ", ")),
+		})
+		return tags, diags
+	}
+
+	for _, tagString := range names {
+		switch matches := found[tagString]; len(matches) {
+		case 0:
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
-				Summary:  fmt.Sprintf("Error retrieving tag %s from netbox", tag.(string)),
-				Detail:   fmt.Sprintf("Could not locate referenced tag %s in netbox", tag.(string)),
+				Summary:  fmt.Sprintf("Error retrieving tag %s from netbox", tagString),
+				Detail:   fmt.Sprintf("Could not locate referenced tag %s in netbox", tagString),
 			})
 			return tags, diags
-		case int64(1):
+		case 1:
 			tags = append(tags, &models.NestedTag{
-				Name: payload.Results[0].Name,
-				Slug: payload.Results[0].Slug,
+				Name: matches[0].Name,
+				Slug: matches[0].Slug,
 			})
 		default:
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Warning,
-				Summary:  fmt.Sprintf("Error retrieving tag %s from netbox", tag.(string)),
-				Detail:   fmt.Sprintf("Could not map tag %s to unique tag in netbox", tag.(string)),
+				Summary:  fmt.Sprintf("Error retrieving tag %s from netbox", tagString),
+				Detail:   fmt.Sprintf("Could not map tag %s to unique tag in netbox", tagString),
 			})
 		}
 	}
@@ -83,3 +174,22 @@ func getTagListFromNestedTagList(nestedTags []*models.NestedTag) []string {
 	}
 	return tags
 }
+
+// withoutDefaultTags removes the default tags of the provider from the tags
+// read from Netbox, unless they are also set on the resource itself. This
+// keeps the default tags out of the diff of every resource.
+func withoutDefaultTags(api *providerState, d *schema.ResourceData, tags []string) []string {
+	if len(api.defaultTags) == 0 {
+		return tags
+	}
+
+	configured := d.Get(tagsKey).(*schema.Set)
+	result := []string{}
+	for _, tag := range tags {
+		if slices.Contains(api.defaultTags, tag) && !configured.Contains(tag) {
+			continue
+		}
+		result = append(result, tag)
+	}
+	return result
+}
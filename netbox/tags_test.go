@@ -1,9 +1,16 @@
 package netbox
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/fbreckle/go-netbox/netbox/models"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -26,3 +33,70 @@ func TestGetTagListFromNestedTagList(t *testing.T) {
 	}
 	assert.Equal(t, flat, expected)
 }
+
+func TestGetNestedTagListFromResourceDataSetUsesCache(t *testing.T) {
+	var queries []url.Values
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query())
+
+		var results []string
+		for _, name := range r.URL.Query()["name"] {
+			if name != "missing" {
+				results = append(results, fmt.Sprintf(`{"id": %d, "name": %q, "slug": %q}`, len(results)+1, name, strings.ToLower(name)))
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"count": %d, "results": [%s]}`, len(results), strings.Join(results, ","))
+	}))
+	defer ts.Close()
+
+	config := Config{
+		APIToken:  "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		ServerURL: ts.URL,
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+	api := newProviderState(client)
+
+	tags, diags := getNestedTagListFromResourceDataSet(context.Background(), api, schema.NewSet(schema.HashString, []interface{}{"Foo", "Bar"}))
+	assert.False(t, diags.HasError())
+	assert.Len(t, tags, 2)
+	assert.Len(t, queries, 1)
+	assert.ElementsMatch(t, []string{"Foo", "Bar"}, queries[0]["name"])
+
+	// Foo is cached, so only Baz is looked up
+	tags, diags = getNestedTagListFromResourceDataSet(context.Background(), api, schema.NewSet(schema.HashString, []interface{}{"Foo", "Baz"}))
+	assert.False(t, diags.HasError())
+	assert.Len(t, tags, 2)
+	assert.Len(t, queries, 2)
+	assert.Equal(t, []string{"Baz"}, queries[1]["name"])
+
+	_, diags = getNestedTagListFromResourceDataSet(context.Background(), api, schema.NewSet(schema.HashString, []interface{}{"Foo", "Bar", "Baz"}))
+	assert.False(t, diags.HasError())
+	assert.Len(t, queries, 2)
+
+	// Tags that were not found are looked up again
+	_, diags = getNestedTagListFromResourceDataSet(context.Background(), api, schema.NewSet(schema.HashString, []interface{}{"missing"}))
+	assert.True(t, diags.HasError())
+	assert.Equal(t, "Could not locate referenced tag missing in netbox", diags[0].Detail)
+	_, diags = getNestedTagListFromResourceDataSet(context.Background(), api, schema.NewSet(schema.HashString, []interface{}{"missing"}))
+	assert.True(t, diags.HasError())
+	assert.Len(t, queries, 4)
+
+	api.tags.invalidate()
+	_, diags = getNestedTagListFromResourceDataSet(context.Background(), api, schema.NewSet(schema.HashString, []interface{}{"Foo"}))
+	assert.False(t, diags.HasError())
+	assert.Len(t, queries, 5)
+}
+
+func TestWithoutDefaultTags(t *testing.T) {
+	api := newProviderState(nil)
+	api.defaultTags = []string{"managed", "owner"}
+
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{tagsKey: tagsSchema}, map[string]interface{}{
+		tagsKey: []interface{}{"Foo", "owner"},
+	})
+
+	tags := withoutDefaultTags(api, d, []string{"Foo", "managed", "owner"})
+	assert.Equal(t, []string{"Foo", "owner"}, tags)
+}
@@ -0,0 +1,162 @@
+package netbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// tokenExpiryMargin is how long before its expiry a token from the token
+// command is replaced, so it does not expire while a request is in flight.
+const tokenExpiryMargin = 30 * time.Second
+
+// tokenCommandOutput is what the token command prints to stdout.
+type tokenCommandOutput struct {
+	Token  string     `json:"token"`
+	Expiry *time.Time `json:"expiry"`
+}
+
+// tokenCommandSource runs the token command whenever a new API token is
+// needed and caches the token until it expires or is rejected by Netbox.
+type tokenCommandSource struct {
+	command []string
+	// logCtx carries the logger used for requests whose context has none.
+	logCtx context.Context
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+func newTokenCommandSource(command []string, logCtx context.Context) *tokenCommandSource {
+	return &tokenCommandSource{command: command, logCtx: logCtx}
+}
+
+// Token returns the cached token or runs the token command for a new one.
+func (s *tokenCommandSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && (s.expiry.IsZero() || time.Until(s.expiry) > tokenExpiryMargin) {
+		return s.token, nil
+	}
+
+	tflog.SubsystemDebug(httpLogContext(ctx, s.logCtx), httpLogSubsystem, "Running token command to get a Netbox API token", map[string]interface{}{
+		"command": s.command[0],
+	})
+
+	output, err := runTokenCommand(ctx, s.command)
+	if err != nil {
+		return "", err
+	}
+
+	s.token = output.Token
+	s.expiry = time.Time{}
+	if output.Expiry != nil {
+		s.expiry = *output.Expiry
+	}
+	return s.token, nil
+}
+
+// Invalidate drops the token if it is still the cached one, so the next call
+// of Token runs the token command again.
+func (s *tokenCommandSource) Invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == token {
+		s.token = ""
+	}
+}
+
+// runTokenCommand runs the token command and parses its output.
+func runTokenCommand(ctx context.Context, command []string) (*tokenCommandOutput, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("error running token command %q: %w: %s", command[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	var output tokenCommandOutput
+	if err := json.Unmarshal(stdout.Bytes(), &output); err != nil {
+		return nil, fmt.Errorf("error parsing the output of token command %q, expected a JSON object like {\"token\": \"...\", \"expiry\": \"2006-01-02T15:04:05Z\"}: %w", command[0], err)
+	}
+	if output.Token == "" {
+		return nil, fmt.Errorf("token command %q returned no token", command[0])
+	}
+	return &output, nil
+}
+
+// tokenCommandTransport is a transport that authenticates requests with a
+// token from the token command. If Netbox rejects the token, e.g. because it
+// was revoked before its expiry, the token command is run again and the
+// request is sent once more if its body can be sent again.
+type tokenCommandTransport struct {
+	original http.RoundTripper
+	source   *tokenCommandSource
+	scheme   string
+}
+
+// RoundTrip sends the request with the current token and retries it with a
+// new token if Netbox responds with 401 Unauthorized.
+func (t tokenCommandTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	resp, token, err := t.send(r, r.Body)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	t.source.Invalidate(token)
+
+	rewindable := r.Body == nil || r.Body == http.NoBody || r.GetBody != nil
+	if !rewindable {
+		return resp, nil
+	}
+
+	body := r.Body
+	if r.GetBody != nil {
+		body, err = r.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+	}
+
+	tflog.SubsystemInfo(httpLogContext(r.Context(), t.source.logCtx), httpLogSubsystem, "Netbox rejected the API token, running the token command again", map[string]interface{}{
+		"http.method": r.Method,
+		"http.path":   r.URL.Path,
+	})
+
+	// Drain the body so the underlying connection can be reused
+	io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	resp, _, err = t.send(r, body)
+	return resp, err
+}
+
+func (t tokenCommandTransport) send(r *http.Request, body io.ReadCloser) (*http.Response, string, error) {
+	token, err := t.source.Token(r.Context())
+	if err != nil {
+		if body != nil {
+			body.Close()
+		}
+		return nil, "", err
+	}
+
+	req := r.Clone(r.Context())
+	req.Body = body
+	req.Header.Set("Authorization", authorizationHeader(t.scheme, token))
+
+	resp, err := t.original.RoundTrip(req)
+	return resp, token, err
+}
@@ -0,0 +1,165 @@
+package netbox
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fbreckle/go-netbox/netbox/client/status"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeTokenCommand writes a token command that returns `token-<n>` on its
+// n-th run, with the given expiry.
+func writeTokenCommand(t *testing.T, expiry string) []string {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "token.sh")
+	counter := filepath.Join(dir, "runs")
+	err := os.WriteFile(script, []byte(fmt.Sprintf(`#!/bin/sh
+echo x >> %[1]q
+printf '{"token": "token-%%d", "expiry": %[2]s}' "$(wc -l < %[1]q)"
+`, counter, expiry)), 0o700)
+	assert.NoError(t, err)
+	return []string{"/bin/sh", script}
+}
+
+func TestTokenCommandSourceExpiry(t *testing.T) {
+	source := newTokenCommandSource(writeTokenCommand(t, "null"), nil)
+
+	token, err := source.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	token, err = source.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	source.Invalidate("token-0")
+	token, err = source.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	source.Invalidate("token-1")
+	token, err = source.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+
+	// Tokens about to expire are replaced
+	expiry := time.Now().Add(tokenExpiryMargin / 2).UTC().Format(time.RFC3339)
+	source = newTokenCommandSource(writeTokenCommand(t, fmt.Sprintf("%q", expiry)), nil)
+
+	token, err = source.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "token-1", token)
+
+	token, err = source.Token(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "token-2", token)
+}
+
+func TestTokenCommandInvalidOutput(t *testing.T) {
+	_, err := newTokenCommandSource([]string{"/bin/sh", "-c", "echo not json"}, nil).Token(context.Background())
+	assert.ErrorContains(t, err, "error parsing the output of token command")
+
+	_, err = newTokenCommandSource([]string{"/bin/sh", "-c", "echo '{}'"}, nil).Token(context.Background())
+	assert.ErrorContains(t, err, "returned no token")
+
+	_, err = newTokenCommandSource([]string{"/bin/sh", "-c", "echo denied >&2; exit 1"}, nil).Token(context.Background())
+	assert.ErrorContains(t, err, "denied")
+}
+
+func TestTokenCommandRetriesOnUnauthorized(t *testing.T) {
+	var tokens []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("Authorization") == "Token token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"detail": "Invalid token"}`))
+			return
+		}
+		w.Write([]byte(`{"netbox-version": "4.1.11"}`))
+	}))
+	defer ts.Close()
+
+	config := Config{
+		TokenCommand: writeTokenCommand(t, "null"),
+		ServerURL:    ts.URL,
+	}
+	client, err := config.Client()
+	assert.NoError(t, err)
+
+	_, err = client.Status.StatusList(status.NewStatusListParams(), nil)
+	assert.NoError(t, err)
+	_, err = client.Status.StatusList(status.NewStatusListParams(), nil)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"Token token-1", "Token token-2", "Token token-2"}, tokens)
+}
+
+func TestTokenCommandConflictsWithAPIToken(t *testing.T) {
+	config := Config{
+		APIToken:     "07b12b765127747e4afd56cb531b7bf9c61f3c30",
+		TokenCommand: []string{"netbox-token-helper"},
+		ServerURL:    "https://localhost:8080",
+	}
+	_, err := config.Client()
+	assert.Error(t, err)
+}
+
+// configureProvider configures the provider the way Terraform does, with the
+// given attributes set and all others left null.
+func configureProvider(t *testing.T, attributes map[string]cty.Value) diag.Diagnostics {
+	p := Provider()
+	configSchema := schema.InternalMap(p.Schema).CoreConfigSchema()
+	values := map[string]cty.Value{}
+	for name, ty := range configSchema.ImpliedType().AttributeTypes() {
+		values[name] = cty.NullVal(ty)
+	}
+	for name, value := range attributes {
+		values[name] = value
+	}
+	config := terraform.NewResourceConfigShimmed(cty.ObjectVal(values), configSchema)
+	config.CtyValue = cty.ObjectVal(values)
+	return p.Configure(context.Background(), config)
+}
+
+func TestTokenCommandTakesPrecedenceOverEnvironment(t *testing.T) {
+	var tokens []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tokens = append(tokens, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"netbox-version": "4.1.11"}`))
+	}))
+	defer ts.Close()
+
+	t.Setenv("NETBOX_API_TOKEN", "07b12b765127747e4afd56cb531b7bf9c61f3c30")
+	var command []cty.Value
+	for _, arg := range writeTokenCommand(t, "null") {
+		command = append(command, cty.StringVal(arg))
+	}
+
+	diags := configureProvider(t, map[string]cty.Value{
+		"server_url":    cty.StringVal(ts.URL),
+		"token_command": cty.ListVal(command),
+	})
+	assert.False(t, diags.HasError(), "%v", diags)
+	assert.Equal(t, []string{"Token token-1"}, tokens)
+
+	// An explicitly configured api_token still conflicts
+	diags = configureProvider(t, map[string]cty.Value{
+		"server_url":    cty.StringVal(ts.URL),
+		"api_token":     cty.StringVal("07b12b765127747e4afd56cb531b7bf9c61f3c30"),
+		"token_command": cty.ListVal(command),
+	})
+	assert.True(t, diags.HasError())
+}
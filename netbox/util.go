@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -55,6 +56,16 @@ func toInt64PtrList(a interface{}) []*int64 {
 	return intList
 }
 
+// sortedKeys returns the keys of a map in sorted order.
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func joinStringWithFinalConjunction(elems []string, sep, con string) string {
 	switch len(elems) {
 	case 0:
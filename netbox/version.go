@@ -0,0 +1,197 @@
+package netbox
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// testedVersionMin and testedVersionMax are the range of Netbox versions the
+// provider is tested against. testedVersionMax is exclusive.
+var (
+	testedVersionMin = mustParseNetboxVersion("4.1.0")
+	testedVersionMax = mustParseNetboxVersion("4.2.0")
+)
+
+// netboxVersionRegexp matches a Netbox version. Only dev, alpha, beta and rc
+// suffixes are prereleases, other suffixes like the `-Docker-3.0.2` of the
+// netbox-docker images or semver build metadata are ignored.
+var netboxVersionRegexp = regexp.MustCompile(`^v?(\d+)\.(\d+)(?:\.(\d+))?(?:-((?:dev|alpha|beta|rc)[0-9.]*))?(?:[-+][0-9A-Za-z.-]+)?$`)
+
+// netboxVersion is a version of Netbox as reported by the status API, e.g.
+// `4.1.3`, `4.2.0-beta1` or `4.1.3-Docker-3.0.2`.
+type netboxVersion struct {
+	major      int
+	minor      int
+	patch      int
+	prerelease string
+}
+
+// parseNetboxVersion parses a semantic version. The patch version may be
+// omitted and defaults to 0. Suffixes that are not prereleases are dropped.
+func parseNetboxVersion(s string) (*netboxVersion, error) {
+	match := netboxVersionRegexp.FindStringSubmatch(s)
+	if match == nil {
+		return nil, fmt.Errorf("invalid Netbox version %q", s)
+	}
+
+	v := &netboxVersion{prerelease: match[4]}
+	v.major, _ = strconv.Atoi(match[1])
+	v.minor, _ = strconv.Atoi(match[2])
+	if match[3] != "" {
+		v.patch, _ = strconv.Atoi(match[3])
+	}
+	return v, nil
+}
+
+func mustParseNetboxVersion(s string) *netboxVersion {
+	v, err := parseNetboxVersion(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func (v *netboxVersion) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.major, v.minor, v.patch)
+	if v.prerelease != "" {
+		s += "-" + v.prerelease
+	}
+	return s
+}
+
+// compare returns -1, 0 or 1 if v is lower than, equal to or greater than
+// other. Like in semantic versioning, a prerelease is lower than the release
+// itself.
+func (v *netboxVersion) compare(other *netboxVersion) int {
+	for _, diff := range []int{v.major - other.major, v.minor - other.minor, v.patch - other.patch} {
+		switch {
+		case diff < 0:
+			return -1
+		case diff > 0:
+			return 1
+		}
+	}
+	switch {
+	case v.prerelease == other.prerelease:
+		return 0
+	case v.prerelease == "":
+		return 1
+	case other.prerelease == "":
+		return -1
+	}
+	return comparePrereleases(v.prerelease, other.prerelease)
+}
+
+// prereleaseIdentifierRegexp splits a prerelease like `beta10` or `rc1.2` into
+// its identifiers.
+var prereleaseIdentifierRegexp = regexp.MustCompile(`[0-9]+|[^0-9.]+`)
+
+// prereleaseStages are the kinds of prereleases in ascending order.
+var prereleaseStages = []string{"dev", "alpha", "beta", "rc"}
+
+// comparePrereleases compares two prereleases identifier by identifier.
+// Numeric identifiers are compared numerically, so `beta10` is greater than
+// `beta2`, and prerelease stages in the order of prereleaseStages. Like in
+// semantic versioning, a prerelease with fewer identifiers is lower.
+func comparePrereleases(a, b string) int {
+	aIdentifiers := prereleaseIdentifierRegexp.FindAllString(a, -1)
+	bIdentifiers := prereleaseIdentifierRegexp.FindAllString(b, -1)
+	for i := 0; i < len(aIdentifiers) && i < len(bIdentifiers); i++ {
+		if c := comparePrereleaseIdentifiers(aIdentifiers[i], bIdentifiers[i]); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(aIdentifiers), len(bIdentifiers))
+}
+
+func comparePrereleaseIdentifiers(a, b string) int {
+	aNumber, aErr := strconv.Atoi(a)
+	bNumber, bErr := strconv.Atoi(b)
+	switch {
+	case aErr == nil && bErr == nil:
+		return cmp.Compare(aNumber, bNumber)
+	case aErr == nil:
+		return -1
+	case bErr == nil:
+		return 1
+	}
+	return cmp.Compare(slices.Index(prereleaseStages, a), slices.Index(prereleaseStages, b))
+}
+
+// inRange reports whether v is at least minVersion and lower than maxVersion.
+// Empty bounds are not checked.
+func (v *netboxVersion) inRange(minVersion, maxVersion string) bool {
+	if minVersion != "" && v.compare(mustParseNetboxVersion(minVersion)) < 0 {
+		return false
+	}
+	if maxVersion != "" && v.compare(mustParseNetboxVersion(maxVersion)) >= 0 {
+		return false
+	}
+	return true
+}
+
+// checkNetboxVersion returns an error if the Netbox version is not at least
+// minVersion or not lower than maxVersion. Empty bounds are not checked.
+// Nothing is checked if the version is unknown because the version check was
+// skipped. what describes the feature, e.g. "`netbox_branch`".
+func (api *providerState) checkNetboxVersion(what, minVersion, maxVersion string) error {
+	if api.version == nil || api.version.inRange(minVersion, maxVersion) {
+		return nil
+	}
+	switch {
+	case maxVersion == "":
+		return fmt.Errorf("%s requires Netbox %s or later, but the version of Netbox is %s", what, minVersion, api.version)
+	case minVersion == "":
+		return fmt.Errorf("%s is not supported by Netbox %s or later, but the version of Netbox is %s", what, maxVersion, api.version)
+	default:
+		return fmt.Errorf("%s requires Netbox %s or later and is not supported by Netbox %s or later, but the version of Netbox is %s", what, minVersion, maxVersion, api.version)
+	}
+}
+
+// requireNetboxVersion returns a CustomizeDiffFunc that fails the plan if the
+// resource is used with a Netbox version that is not at least minVersion or
+// not lower than maxVersion. Empty bounds are not checked.
+func requireNetboxVersion(resourceType, minVersion, maxVersion string) schema.CustomizeDiffFunc {
+	mustParseNetboxVersionRange(minVersion, maxVersion)
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		api, ok := m.(*providerState)
+		if !ok {
+			return nil
+		}
+		return api.checkNetboxVersion(fmt.Sprintf("`%s`", resourceType), minVersion, maxVersion)
+	}
+}
+
+// requireNetboxVersionForAttribute returns a CustomizeDiffFunc that fails the
+// plan if the attribute is set in the configuration and Netbox is not at least
+// minVersion or not lower than maxVersion. Empty bounds are not checked.
+func requireNetboxVersionForAttribute(attribute, minVersion, maxVersion string) schema.CustomizeDiffFunc {
+	mustParseNetboxVersionRange(minVersion, maxVersion)
+	return func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+		api, ok := m.(*providerState)
+		if !ok {
+			return nil
+		}
+		config := d.GetRawConfig()
+		if config.IsNull() || !config.IsKnown() || !config.Type().HasAttribute(attribute) || config.GetAttr(attribute).IsNull() {
+			return nil
+		}
+		return api.checkNetboxVersion(fmt.Sprintf("The `%s` attribute", attribute), minVersion, maxVersion)
+	}
+}
+
+// mustParseNetboxVersionRange makes sure invalid bounds are caught when the
+// provider schema is built instead of during a plan.
+func mustParseNetboxVersionRange(minVersion, maxVersion string) {
+	for _, bound := range []string{minVersion, maxVersion} {
+		if bound != "" {
+			mustParseNetboxVersion(bound)
+		}
+	}
+}
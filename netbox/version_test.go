@@ -0,0 +1,137 @@
+package netbox
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNetboxVersion(t *testing.T) {
+	for s, expected := range map[string]netboxVersion{
+		"4.1.3":                    {major: 4, minor: 1, patch: 3},
+		"v4.1.11":                  {major: 4, minor: 1, patch: 11},
+		"4.2":                      {major: 4, minor: 2},
+		"4.2.0-beta1":              {major: 4, minor: 2, prerelease: "beta1"},
+		"4.1.0-Docker-3.0.2":       {major: 4, minor: 1},
+		"4.2.0-beta1-Docker-3.0.2": {major: 4, minor: 2, prerelease: "beta1"},
+		"4.1.3+build.5":            {major: 4, minor: 1, patch: 3},
+	} {
+		v, err := parseNetboxVersion(s)
+		if assert.NoError(t, err, s) {
+			assert.Equal(t, expected, *v, s)
+		}
+	}
+
+	for _, s := range []string{"", "4", "four.one", "4.1.3 (Docker)"} {
+		_, err := parseNetboxVersion(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestNetboxVersionCompare(t *testing.T) {
+	for _, c := range []struct {
+		a, b     string
+		expected int
+	}{
+		{"4.1.3", "4.1.3", 0},
+		{"4.1", "4.1.0", 0},
+		{"4.1.3", "4.1.11", -1},
+		{"4.2.0", "4.1.11", 1},
+		{"3.7.8", "4.0.0", -1},
+		{"4.2.0-beta1", "4.2.0", -1},
+		{"4.2.0-beta2", "4.2.0-beta1", 1},
+		{"4.2.0-beta10", "4.2.0-beta2", 1},
+		{"4.2.0-rc1", "4.2.0-beta10", 1},
+		{"4.2.0-alpha1", "4.2.0-beta1", -1},
+		{"4.2.0-dev", "4.2.0-alpha1", -1},
+		{"4.2.0-beta1", "4.2.0-beta1.1", -1},
+		{"4.2.0-rc1.10", "4.2.0-rc1.9", 1},
+		{"4.1.0-Docker-3.0.2", "4.1.0", 0},
+		{"4.1.0-Docker-3.0.2", "4.1.1", -1},
+	} {
+		assert.Equal(t, c.expected, mustParseNetboxVersion(c.a).compare(mustParseNetboxVersion(c.b)), "%s <=> %s", c.a, c.b)
+	}
+}
+
+func TestCheckNetboxVersion(t *testing.T) {
+	api := newProviderState(nil)
+	assert.NoError(t, api.checkNetboxVersion("`netbox_example`", "9.0.0", ""))
+
+	api.version = mustParseNetboxVersion("4.1.3")
+	assert.NoError(t, api.checkNetboxVersion("`netbox_example`", "4.1.0", "4.2.0"))
+	assert.NoError(t, api.checkNetboxVersion("`netbox_example`", "", ""))
+	assert.EqualError(t, api.checkNetboxVersion("`netbox_example`", "4.2.0", ""), "`netbox_example` requires Netbox 4.2.0 or later, but the version of Netbox is 4.1.3")
+	assert.EqualError(t, api.checkNetboxVersion("`netbox_example`", "", "4.1.0"), "`netbox_example` is not supported by Netbox 4.1.0 or later, but the version of Netbox is 4.1.3")
+	assert.EqualError(t, api.checkNetboxVersion("`netbox_example`", "4.0.0", "4.1.0"), "`netbox_example` requires Netbox 4.0.0 or later and is not supported by Netbox 4.1.0 or later, but the version of Netbox is 4.1.3")
+}
+
+func TestRequireNetboxVersionForAttribute(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"new_feature": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+		},
+		CustomizeDiff: requireNetboxVersionForAttribute("new_feature", "4.2.0", ""),
+	}
+	api := newProviderState(nil)
+	api.version = mustParseNetboxVersion("4.1.3")
+
+	diff := func(config map[string]cty.Value) error {
+		// Terraform passes the raw config along with the prior state
+		raw := cty.ObjectVal(config)
+		c := terraform.NewResourceConfigShimmed(raw, r.CoreConfigSchema())
+		_, err := r.Diff(context.Background(), &terraform.InstanceState{RawConfig: raw}, c, api)
+		return err
+	}
+
+	assert.NoError(t, diff(map[string]cty.Value{
+		"name":        cty.StringVal("example"),
+		"new_feature": cty.NullVal(cty.String),
+	}))
+	assert.EqualError(t, diff(map[string]cty.Value{
+		"name":        cty.StringVal("example"),
+		"new_feature": cty.StringVal("on"),
+	}), "The `new_feature` attribute requires Netbox 4.2.0 or later, but the version of Netbox is 4.1.3")
+
+	api.version = mustParseNetboxVersion("4.2.0")
+	assert.NoError(t, diff(map[string]cty.Value{
+		"name":        cty.StringVal("example"),
+		"new_feature": cty.StringVal("on"),
+	}))
+}
+
+func TestInterfaceMacAddressRequiresNetboxBefore42(t *testing.T) {
+	r := resourceNetboxInterface()
+	api := newProviderState(nil)
+
+	// All attributes that are not configured are null in the raw config
+	config := map[string]cty.Value{}
+	for name, attrType := range r.CoreConfigSchema().ImpliedType().AttributeTypes() {
+		config[name] = cty.NullVal(attrType)
+	}
+	config["name"] = cty.StringVal("eth0")
+	config["virtual_machine_id"] = cty.NumberIntVal(1)
+	config["mac_address"] = cty.StringVal("00:16:3E:A8:B5:D7")
+	raw := cty.ObjectVal(config)
+	diff := func() error {
+		c := terraform.NewResourceConfigShimmed(raw, r.CoreConfigSchema())
+		_, err := r.Diff(context.Background(), &terraform.InstanceState{RawConfig: raw}, c, api)
+		return err
+	}
+
+	api.version = mustParseNetboxVersion("4.1.3")
+	assert.NoError(t, diff())
+
+	api.version = mustParseNetboxVersion("4.2.0")
+	assert.EqualError(t, diff(), "The `mac_address` attribute is not supported by Netbox 4.2.0 or later, but the version of Netbox is 4.2.0")
+}